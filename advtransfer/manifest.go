@@ -0,0 +1,225 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package advtransfer builds multi-leg advanced transfers from a YAML or
+// JSON manifest, or many blind-match transfers from a settlement CSV, and
+// validates them before anything is sent.
+package advtransfer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	Types = []model.AdvancedTransferType{model.AdvancedTransferTypeBlindMatch}
+
+	LocationTypes = []model.TransferLocationType{
+		model.TransferLocationTypePaymentMethod,
+		model.TransferLocationTypeWallet,
+		model.TransferLocationTypeAddress,
+		model.TransferLocationTypeOther,
+		model.TransferLocationTypeMultipleAddresses,
+		model.TransferLocationTypeCounterpartyId,
+	}
+)
+
+// Manifest describes one advanced transfer. JSON is accepted as well as
+// YAML since it is a subset.
+type Manifest struct {
+	Type          string          `yaml:"type"`
+	BlindMatch    *BlindMatch     `yaml:"blind_match"`
+	FundMovements []*FundMovement `yaml:"fund_movements"`
+}
+
+type BlindMatch struct {
+	ReferenceId    string `yaml:"reference_id"`
+	TradeDate      string `yaml:"trade_date"`
+	SettlementDate string `yaml:"settlement_date"`
+	SettlementTime string `yaml:"settlement_time"`
+}
+
+type FundMovement struct {
+	// Id defaults to one derived from the transfer seed and the movement's
+	// position, so resubmitting the same manifest reuses the same IDs.
+	Id       string    `yaml:"id"`
+	Currency string    `yaml:"currency"`
+	Amount   string    `yaml:"amount"`
+	Source   *Location `yaml:"source"`
+	Target   *Location `yaml:"target"`
+}
+
+type Location struct {
+	Type              string `yaml:"type"`
+	Value             string `yaml:"value"`
+	Address           string `yaml:"address"`
+	AccountIdentifier string `yaml:"account_identifier"`
+}
+
+// LoadManifest reads a manifest file. Unknown keys are rejected.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read transfer manifest: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	m := &Manifest{}
+	if err := decoder.Decode(m); err != nil {
+		return nil, fmt.Errorf("cannot parse transfer manifest %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Validate checks the manifest without calling the API: the transfer and
+// location types are known, every location names where it points, and
+// amounts are positive plain decimals.
+func (m *Manifest) Validate() error {
+	if m.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+	if !containsType(Types, model.AdvancedTransferType(m.Type)) {
+		return fmt.Errorf("unknown type %q (supported: %s)", m.Type, joinTypes(Types))
+	}
+	if len(m.FundMovements) == 0 {
+		return fmt.Errorf("at least one fund movement is required")
+	}
+
+	ids := map[string]bool{}
+	for i, fm := range m.FundMovements {
+		if err := fm.validate(); err != nil {
+			return fmt.Errorf("fund movement %d: %w", i+1, err)
+		}
+		if fm.Id != "" {
+			if ids[fm.Id] {
+				return fmt.Errorf("fund movement %d: duplicate id %q", i+1, fm.Id)
+			}
+			ids[fm.Id] = true
+		}
+	}
+
+	if model.AdvancedTransferType(m.Type) == model.AdvancedTransferTypeBlindMatch {
+		if m.BlindMatch == nil || m.BlindMatch.ReferenceId == "" {
+			return fmt.Errorf("blind_match.reference_id is required for %s", m.Type)
+		}
+	}
+	return nil
+}
+
+func (fm *FundMovement) validate() error {
+	if fm.Currency == "" {
+		return fmt.Errorf("currency is required")
+	}
+	if fm.Amount == "" {
+		return fmt.Errorf("amount is required")
+	}
+	if _, err := utils.ParseAmount("amount", fm.Amount); err != nil {
+		return err
+	}
+	if fm.Source == nil {
+		return fmt.Errorf("source is required")
+	}
+	if err := fm.Source.validate(); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	if fm.Target == nil {
+		return fmt.Errorf("target is required")
+	}
+	if err := fm.Target.validate(); err != nil {
+		return fmt.Errorf("target: %w", err)
+	}
+	return nil
+}
+
+func (l *Location) validate() error {
+	locationType := model.TransferLocationType(l.Type)
+	switch {
+	case l.Type == "":
+		return fmt.Errorf("type is required")
+	case !containsType(LocationTypes, locationType):
+		return fmt.Errorf("unknown location type %q (supported: %s)", l.Type, joinTypes(LocationTypes))
+	case locationType == model.TransferLocationTypeAddress && l.Address == "":
+		return fmt.Errorf("%s needs an address", l.Type)
+	case locationType != model.TransferLocationTypeAddress && l.Value == "" && l.Address == "" && l.AccountIdentifier == "":
+		return fmt.Errorf("%s needs a value", l.Type)
+	}
+	return nil
+}
+
+// Build converts the manifest to the API model. seed derives the IDs of
+// fund movements that have none.
+func (m *Manifest) Build(seed string) *model.AdvancedTransfer {
+	namespace := uuid.NewSHA1(uuid.NameSpaceOID, []byte(seed))
+
+	transfer := &model.AdvancedTransfer{Type: model.AdvancedTransferType(m.Type)}
+	for i, fm := range m.FundMovements {
+		id := fm.Id
+		if id == "" {
+			id = uuid.NewSHA1(namespace, []byte(fmt.Sprint(i))).String()
+		}
+		transfer.FundMovements = append(transfer.FundMovements, &model.FundMovement{
+			Id:       id,
+			Currency: strings.ToUpper(fm.Currency),
+			Amount:   fm.Amount,
+			Source:   fm.Source.build(),
+			Target:   fm.Target.build(),
+		})
+	}
+
+	if bm := m.BlindMatch; bm != nil {
+		transfer.BlindMatchMetadata = &model.BlindMatchMetadata{
+			ReferenceId:    bm.ReferenceId,
+			TradeDate:      bm.TradeDate,
+			SettlementDate: bm.SettlementDate,
+			SettlementTime: bm.SettlementTime,
+		}
+	}
+	return transfer
+}
+
+func (l *Location) build() *model.TransferLocation {
+	return &model.TransferLocation{
+		Type:              model.TransferLocationType(l.Type),
+		Value:             l.Value,
+		Address:           l.Address,
+		AccountIdentifier: l.AccountIdentifier,
+	}
+}
+
+func containsType[T ~string](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinTypes[T ~string](values []T) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = string(v)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,196 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package advtransfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/coinbase/prime-sdk-go/model"
+)
+
+// Settlement CSV columns. Rows sharing a reference_id become the fund
+// movements of one blind-match transfer; the header row may list the
+// columns in any order.
+const (
+	ColumnReferenceId             = "reference_id"
+	ColumnTradeDate               = "trade_date"
+	ColumnSettlementDate          = "settlement_date"
+	ColumnSettlementTime          = "settlement_time"
+	ColumnFundMovementId          = "fund_movement_id"
+	ColumnCurrency                = "currency"
+	ColumnAmount                  = "amount"
+	ColumnSourceType              = "source_type"
+	ColumnSourceValue             = "source_value"
+	ColumnSourceAddress           = "source_address"
+	ColumnSourceAccountIdentifier = "source_account_identifier"
+	ColumnTargetType              = "target_type"
+	ColumnTargetValue             = "target_value"
+	ColumnTargetAddress           = "target_address"
+	ColumnTargetAccountIdentifier = "target_account_identifier"
+)
+
+var (
+	SettlementColumns = []string{
+		ColumnReferenceId, ColumnTradeDate, ColumnSettlementDate, ColumnSettlementTime,
+		ColumnFundMovementId, ColumnCurrency, ColumnAmount,
+		ColumnSourceType, ColumnSourceValue, ColumnSourceAddress, ColumnSourceAccountIdentifier,
+		ColumnTargetType, ColumnTargetValue, ColumnTargetAddress, ColumnTargetAccountIdentifier,
+	}
+	requiredSettlementColumns = []string{ColumnReferenceId, ColumnCurrency, ColumnAmount, ColumnSourceType, ColumnTargetType}
+)
+
+// Settlement is one blind-match transfer built from the CSV rows that share
+// a reference ID. Lines are 1-based and count the header.
+type Settlement struct {
+	Lines    []int
+	Manifest *Manifest
+
+	// firstLine is the row the dates were taken from. It is set even when
+	// that row fails validation and so never makes it into Lines.
+	firstLine int
+}
+
+// ParseSettlements groups the rows by reference ID in order of first
+// appearance and validates each group. Problems are returned per line so
+// that a whole file can be fixed in one pass.
+func ParseSettlements(data []byte) ([]*Settlement, []error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, []error{fmt.Errorf("settlement file is empty")}
+	}
+	if err != nil {
+		return nil, []error{fmt.Errorf("cannot read settlement header: %w", err)}
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(SettlementColumns, name) {
+			return nil, []error{fmt.Errorf("unknown column %q (supported: %s)", name, strings.Join(SettlementColumns, ", "))}
+		}
+		if _, ok := index[name]; ok {
+			return nil, []error{fmt.Errorf("duplicate column %q", name)}
+		}
+		index[name] = i
+	}
+	for _, name := range requiredSettlementColumns {
+		if _, ok := index[name]; !ok {
+			return nil, []error{fmt.Errorf("missing required column %q", name)}
+		}
+	}
+
+	var settlements []*Settlement
+	byReference := map[string]*Settlement{}
+	var errs []error
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, []error{fmt.Errorf("cannot read settlement file: %w", err)}
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		referenceId := get(ColumnReferenceId)
+		if referenceId == "" {
+			errs = append(errs, fmt.Errorf("line %d: %s is required", line, ColumnReferenceId))
+			continue
+		}
+
+		blindMatch := &BlindMatch{
+			ReferenceId:    referenceId,
+			TradeDate:      get(ColumnTradeDate),
+			SettlementDate: get(ColumnSettlementDate),
+			SettlementTime: get(ColumnSettlementTime),
+		}
+
+		s, ok := byReference[referenceId]
+		if !ok {
+			s = &Settlement{firstLine: line, Manifest: &Manifest{
+				Type:       string(model.AdvancedTransferTypeBlindMatch),
+				BlindMatch: blindMatch,
+			}}
+			byReference[referenceId] = s
+			settlements = append(settlements, s)
+		} else if *s.Manifest.BlindMatch != *blindMatch {
+			errs = append(errs, fmt.Errorf("line %d: trade and settlement dates differ from line %d for reference %s", line, s.firstLine, referenceId))
+			continue
+		}
+
+		fm := &FundMovement{
+			Id:       get(ColumnFundMovementId),
+			Currency: get(ColumnCurrency),
+			Amount:   get(ColumnAmount),
+			Source: &Location{
+				Type:              strings.ToUpper(get(ColumnSourceType)),
+				Value:             get(ColumnSourceValue),
+				Address:           get(ColumnSourceAddress),
+				AccountIdentifier: get(ColumnSourceAccountIdentifier),
+			},
+			Target: &Location{
+				Type:              strings.ToUpper(get(ColumnTargetType)),
+				Value:             get(ColumnTargetValue),
+				Address:           get(ColumnTargetAddress),
+				AccountIdentifier: get(ColumnTargetAccountIdentifier),
+			},
+		}
+		if err := fm.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+
+		s.Lines = append(s.Lines, line)
+		s.Manifest.FundMovements = append(s.Manifest.FundMovements, fm)
+	}
+
+	for _, s := range settlements {
+		if len(s.Lines) == 0 {
+			continue
+		}
+		if err := s.Manifest.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("reference %s (line %d): %w", s.Manifest.BlindMatch.ReferenceId, s.Lines[0], err))
+		}
+	}
+
+	if len(settlements) == 0 && len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("settlement file has no rows"))
+	}
+	return settlements, errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
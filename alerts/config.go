@@ -0,0 +1,202 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package alerts evaluates threshold rules against Prime and posts firing and
+// resolved notifications to a webhook.
+package alerts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RuleWalletBalanceBelow          = "wallet_balance_below"
+	RuleCrossMarginUtilizationAbove = "cross_margin_utilization_above"
+	RuleNewMarginCallSummary        = "new_margin_call_summary"
+	RuleFcmMarginCall               = "fcm_margin_call"
+	RuleWithdrawalPending           = "withdrawal_pending"
+
+	defaultInterval       = time.Minute
+	defaultWebhookTimeout = 10 * time.Second
+	defaultPendingAge     = time.Hour
+	defaultLookback       = 7 * 24 * time.Hour
+)
+
+var RuleTypes = []string{
+	RuleWalletBalanceBelow,
+	RuleCrossMarginUtilizationAbove,
+	RuleNewMarginCallSummary,
+	RuleFcmMarginCall,
+	RuleWithdrawalPending,
+}
+
+type Config struct {
+	// Interval between evaluations. Defaults to one minute.
+	Interval time.Duration `yaml:"interval"`
+
+	// StateFile keeps active alerts across restarts so they are not sent
+	// again. Optional.
+	StateFile string `yaml:"state_file"`
+
+	Webhook Webhook `yaml:"webhook"`
+	Rules   []*Rule `yaml:"rules"`
+}
+
+// Webhook values are expanded with environment variables, so secrets can be
+// kept out of the file, e.g. "Bearer ${ALERTS_TOKEN}".
+type Webhook struct {
+	Url     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
+type Rule struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+
+	// Portfolio is a portfolio name, alias or ID. Defaults to the portfolio
+	// given on the command line.
+	Portfolio string `yaml:"portfolio"`
+
+	// Wallet and Symbol select the wallet for wallet_balance_below. Symbol is
+	// optional and guards against a wallet reference matching another asset.
+	Wallet string `yaml:"wallet"`
+	Symbol string `yaml:"symbol"`
+
+	// Threshold is an amount for wallet_balance_below and a percentage for
+	// cross_margin_utilization_above.
+	Threshold string `yaml:"threshold"`
+
+	// OlderThan is how long a withdrawal may stay pending. Defaults to 1h.
+	OlderThan time.Duration `yaml:"older_than"`
+
+	// Lookback bounds how far back withdrawals and margin call summaries are
+	// listed. Defaults to 7 days.
+	Lookback time.Duration `yaml:"lookback"`
+
+	// Repeat re-sends a firing alert after this long. Zero sends it once.
+	Repeat time.Duration `yaml:"repeat"`
+
+	threshold decimal.Decimal
+}
+
+// LoadConfig reads and validates an alerts file. Unknown keys are rejected so
+// that a misspelt setting does not silently disable a rule.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read alerts config: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	cfg := &Config{}
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse alerts config %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid alerts config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.Interval == 0 {
+		c.Interval = defaultInterval
+	}
+	if c.Interval < 0 {
+		return fmt.Errorf("interval cannot be negative")
+	}
+
+	c.Webhook.Url = os.ExpandEnv(c.Webhook.Url)
+	if c.Webhook.Url == "" {
+		return fmt.Errorf("webhook.url is required")
+	}
+	for name, value := range c.Webhook.Headers {
+		c.Webhook.Headers[name] = os.ExpandEnv(value)
+	}
+	if c.Webhook.Timeout == 0 {
+		c.Webhook.Timeout = defaultWebhookTimeout
+	}
+
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("at least one rule is required")
+	}
+
+	names := map[string]bool{}
+	for i, rule := range c.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate rule name %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) validate() error {
+	if r.OlderThan == 0 {
+		r.OlderThan = defaultPendingAge
+	}
+	if r.Lookback == 0 {
+		r.Lookback = defaultLookback
+	}
+	if r.OlderThan < 0 || r.Lookback < 0 || r.Repeat < 0 {
+		return fmt.Errorf("durations cannot be negative")
+	}
+
+	switch r.Type {
+	case RuleWalletBalanceBelow:
+		if r.Wallet == "" {
+			return fmt.Errorf("wallet is required")
+		}
+		return r.parseThreshold()
+	case RuleCrossMarginUtilizationAbove:
+		return r.parseThreshold()
+	case RuleNewMarginCallSummary, RuleFcmMarginCall, RuleWithdrawalPending:
+		return nil
+	case "":
+		return fmt.Errorf("type is required (supported: %s)", strings.Join(RuleTypes, ", "))
+	default:
+		return fmt.Errorf("unknown type %q (supported: %s)", r.Type, strings.Join(RuleTypes, ", "))
+	}
+}
+
+func (r *Rule) parseThreshold() error {
+	if r.Threshold == "" {
+		return fmt.Errorf("threshold is required")
+	}
+	threshold, err := decimal.NewFromString(r.Threshold)
+	if err != nil {
+		return fmt.Errorf("invalid threshold %q: %w", r.Threshold, err)
+	}
+	r.threshold = threshold
+	return nil
+}
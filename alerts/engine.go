@@ -0,0 +1,326 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/resolver"
+	"github.com/coinbase/prime-sdk-go/client"
+)
+
+// Alert is a firing rule instance. Active alerts are kept so that each is
+// sent once and a resolved notification follows when it clears.
+type Alert struct {
+	Rule       string    `json:"rule"`
+	Key        string    `json:"key"`
+	Message    string    `json:"message"`
+	Value      string    `json:"value,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	NotifiedAt time.Time `json:"notified_at"`
+}
+
+type State struct {
+	Active map[string]*Alert `json:"active"`
+
+	// Seen holds the keys already reported by event rules. A rule without an
+	// entry has not been evaluated yet, and its first findings are recorded
+	// without notifying so that a restart does not replay old events.
+	Seen map[string][]string `json:"seen"`
+}
+
+// Result summarizes one rule evaluation.
+type Result struct {
+	Rule     string    `json:"rule"`
+	Type     string    `json:"type"`
+	Findings []Finding `json:"findings"`
+	Sent     []string  `json:"sent,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type walletTarget struct {
+	portfolioId string
+	walletId    string
+}
+
+type Engine struct {
+	client     client.RestClient
+	cfg        *Config
+	entityId   string
+	portfolios map[string]string
+	wallets    map[string]walletTarget
+	state      *State
+	now        func() time.Time
+
+	NewContext func() (context.Context, context.CancelFunc)
+}
+
+// NewEngine resolves the portfolio and wallet references in every rule up
+// front, so that a typo fails at startup rather than on the first
+// evaluation, and loads any saved state.
+func NewEngine(c client.RestClient, cfg *Config, portfolioId, entityId string, newContext func() (context.Context, context.CancelFunc)) (*Engine, error) {
+	e := &Engine{
+		client:     c,
+		cfg:        cfg,
+		entityId:   entityId,
+		portfolios: map[string]string{},
+		wallets:    map[string]walletTarget{},
+		now:        time.Now,
+		NewContext: newContext,
+	}
+
+	r, err := resolver.New(c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range cfg.Rules {
+		rulePortfolioId := portfolioId
+		if rule.Portfolio != "" {
+			ctx, cancel := newContext()
+			rulePortfolioId, err = r.PortfolioId(ctx, rule.Portfolio)
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+		}
+		e.portfolios[rule.Name] = rulePortfolioId
+
+		if rule.Type == RuleWalletBalanceBelow {
+			ctx, cancel := newContext()
+			walletId, err := r.WalletId(ctx, rulePortfolioId, rule.Wallet)
+			cancel()
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			e.wallets[rule.Name] = walletTarget{portfolioId: rulePortfolioId, walletId: walletId}
+		}
+	}
+
+	state, err := loadState(cfg.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	e.state = state
+
+	return e, nil
+}
+
+// Evaluate runs every rule once, sends notifications for changes and saves
+// the state. A rule that fails to evaluate keeps its previous alerts, and a
+// notification that cannot be delivered is retried on the next evaluation.
+func (e *Engine) Evaluate(ctx context.Context) ([]*Result, error) {
+	results := make([]*Result, 0, len(e.cfg.Rules))
+	for _, rule := range e.cfg.Rules {
+		result := &Result{Rule: rule.Name, Type: rule.Type, Findings: []Finding{}}
+		results = append(results, result)
+
+		ruleCtx, cancel := e.NewContext()
+		findings, err := e.evaluate(ruleCtx, rule)
+		cancel()
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if findings != nil {
+			result.Findings = findings
+		}
+
+		var sendErrs []error
+		if isEvent(rule.Type) {
+			sendErrs = e.applyEvents(ctx, rule, findings, result)
+		} else {
+			sendErrs = e.applyConditions(ctx, rule, findings, result)
+		}
+		if err := errors.Join(sendErrs...); err != nil {
+			result.Error = err.Error()
+		}
+	}
+
+	if err := saveState(e.cfg.StateFile, e.state); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// Run evaluates the rules every interval until ctx is cancelled. Each
+// evaluation's results are passed to report.
+func (e *Engine) Run(ctx context.Context, report func([]*Result, error)) {
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		report(e.Evaluate(ctx))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Engine) applyConditions(ctx context.Context, rule *Rule, findings []Finding, result *Result) []error {
+	now := e.now()
+	current := map[string]bool{}
+	var errs []error
+
+	for _, f := range findings {
+		id := rule.Name + "/" + f.Key
+		current[id] = true
+
+		alert, active := e.state.Active[id]
+		if active {
+			alert.Message = f.Message
+			alert.Value = f.Value
+			if rule.Repeat == 0 || now.Sub(alert.NotifiedAt) < rule.Repeat {
+				continue
+			}
+		} else {
+			alert = &Alert{Rule: rule.Name, Key: f.Key, Message: f.Message, Value: f.Value, StartedAt: now}
+		}
+
+		if err := e.notify(ctx, rule, StatusFiring, alert, nil); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		alert.NotifiedAt = now
+		e.state.Active[id] = alert
+		result.Sent = append(result.Sent, StatusFiring+" "+f.Key)
+	}
+
+	for id, alert := range e.state.Active {
+		if alert.Rule != rule.Name || current[id] {
+			continue
+		}
+		if err := e.notify(ctx, rule, StatusResolved, alert, &now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(e.state.Active, id)
+		result.Sent = append(result.Sent, StatusResolved+" "+alert.Key)
+	}
+	return errs
+}
+
+func (e *Engine) applyEvents(ctx context.Context, rule *Rule, findings []Finding, result *Result) []error {
+	now := e.now()
+	previous, known := e.state.Seen[rule.Name]
+
+	seen := map[string]bool{}
+	for _, key := range previous {
+		seen[key] = true
+	}
+
+	// Only keys still returned are kept, which bounds the list to the
+	// lookback window.
+	next := []string{}
+	var errs []error
+	for _, f := range findings {
+		if known && !seen[f.Key] {
+			alert := &Alert{Rule: rule.Name, Key: f.Key, Message: f.Message, Value: f.Value, StartedAt: now}
+			if err := e.notify(ctx, rule, StatusFiring, alert, nil); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			result.Sent = append(result.Sent, StatusFiring+" "+f.Key)
+		}
+		next = append(next, f.Key)
+	}
+	e.state.Seen[rule.Name] = next
+	return errs
+}
+
+func (e *Engine) notify(ctx context.Context, rule *Rule, status string, alert *Alert, resolvedAt *time.Time) error {
+	threshold := rule.Threshold
+	if rule.Type == RuleWithdrawalPending {
+		threshold = rule.OlderThan.String()
+	}
+
+	err := e.cfg.Webhook.post(ctx, Payload{
+		Status:     status,
+		Rule:       rule.Name,
+		Type:       rule.Type,
+		Key:        alert.Key,
+		Message:    alert.Message,
+		Value:      alert.Value,
+		Threshold:  threshold,
+		StartedAt:  alert.StartedAt,
+		ResolvedAt: resolvedAt,
+		SentAt:     e.now(),
+	})
+	if err != nil {
+		return fmt.Errorf("cannot send %s notification for %s: %w", status, alert.Key, err)
+	}
+	return nil
+}
+
+func loadState(path string) (*State, error) {
+	state := &State{Active: map[string]*Alert{}, Seen: map[string][]string{}}
+	if path == "" {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read alerts state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("cannot parse alerts state %s: %w", path, err)
+	}
+	if state.Active == nil {
+		state.Active = map[string]*Alert{}
+	}
+	if state.Seen == nil {
+		state.Seen = map[string][]string{}
+	}
+	return state, nil
+}
+
+// saveState writes through a temporary file so an interrupted write does not
+// leave a truncated state behind.
+func saveState(path string, state *State) error {
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode alerts state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("cannot create alerts state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("cannot write alerts state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("cannot write alerts state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/balances"
+	"github.com/coinbase/prime-sdk-go/financing"
+	"github.com/coinbase/prime-sdk-go/futures"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/transactions"
+	"github.com/shopspring/decimal"
+)
+
+// Finding is one instance of a rule that currently holds, such as a single
+// stuck withdrawal. Key identifies the instance across evaluations.
+type Finding struct {
+	Key     string `json:"key"`
+	Message string `json:"message"`
+	Value   string `json:"value,omitempty"`
+}
+
+// isEvent reports whether a rule describes one-off events rather than a
+// condition. Events are sent once per key and never resolve.
+func isEvent(ruleType string) bool {
+	return ruleType == RuleNewMarginCallSummary
+}
+
+func (e *Engine) evaluate(ctx context.Context, r *Rule) ([]Finding, error) {
+	switch r.Type {
+	case RuleWalletBalanceBelow:
+		return e.walletBalanceBelow(ctx, r)
+	case RuleCrossMarginUtilizationAbove:
+		return e.crossMarginUtilizationAbove(ctx, r)
+	case RuleNewMarginCallSummary:
+		return e.marginCallSummaries(ctx, r)
+	case RuleFcmMarginCall:
+		return e.fcmMarginCalls(ctx)
+	case RuleWithdrawalPending:
+		return e.pendingWithdrawals(ctx, r)
+	default:
+		return nil, fmt.Errorf("unknown rule type %q", r.Type)
+	}
+}
+
+func (e *Engine) walletBalanceBelow(ctx context.Context, r *Rule) ([]Finding, error) {
+	target := e.wallets[r.Name]
+
+	response, err := balances.NewBalancesService(e.client).GetWalletBalance(ctx, &balances.GetWalletBalanceRequest{
+		PortfolioId: target.portfolioId,
+		Id:          target.walletId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get wallet balance: %w", err)
+	}
+	if response.Balance == nil {
+		return nil, fmt.Errorf("wallet %s returned no balance", target.walletId)
+	}
+
+	if r.Symbol != "" && response.Balance.Symbol != "" && !strings.EqualFold(r.Symbol, response.Balance.Symbol) {
+		return nil, fmt.Errorf("wallet %s holds %s, not %s", r.Wallet, response.Balance.Symbol, r.Symbol)
+	}
+
+	amount, err := decimal.NewFromString(response.Balance.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse wallet balance %q: %w", response.Balance.Amount, err)
+	}
+	if !amount.LessThan(r.threshold) {
+		return nil, nil
+	}
+
+	return []Finding{{
+		Key:     target.walletId,
+		Message: fmt.Sprintf("%s balance in wallet %s is %s, below %s", response.Balance.Symbol, r.Wallet, amount.String(), r.threshold.String()),
+		Value:   amount.String(),
+	}}, nil
+}
+
+// crossMarginUtilizationAbove compares consumed credit against the cross
+// margin credit limit, as a percentage.
+func (e *Engine) crossMarginUtilizationAbove(ctx context.Context, r *Rule) ([]Finding, error) {
+	if e.entityId == "" {
+		return nil, fmt.Errorf("an entity ID is required")
+	}
+
+	response, err := financing.NewFinancingService(e.client).GetCrossMarginOverview(ctx, &financing.GetCrossMarginOverviewRequest{
+		EntityId: e.entityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get cross margin overview: %w", err)
+	}
+	if response.Overview == nil || response.Overview.MarginSummary == nil {
+		return nil, nil
+	}
+
+	summary := response.Overview.MarginSummary
+	limit, err := decimal.NewFromString(summary.XMCreditLimit)
+	if err != nil || !limit.IsPositive() {
+		return nil, nil
+	}
+	consumed, err := decimal.NewFromString(summary.ConsumedCredit)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse consumed credit %q: %w", summary.ConsumedCredit, err)
+	}
+
+	utilization := consumed.Div(limit).Mul(decimal.NewFromInt(100)).Round(2)
+	if !utilization.GreaterThan(r.threshold) {
+		return nil, nil
+	}
+
+	return []Finding{{
+		Key:     e.entityId,
+		Message: fmt.Sprintf("cross margin utilization is %s%% (%s of %s), above %s%%", utilization.String(), consumed.String(), limit.String(), r.threshold.String()),
+		Value:   utilization.String(),
+	}}, nil
+}
+
+func (e *Engine) marginCallSummaries(ctx context.Context, r *Rule) ([]Finding, error) {
+	if e.entityId == "" {
+		return nil, fmt.Errorf("an entity ID is required")
+	}
+
+	now := e.now()
+	response, err := financing.NewFinancingService(e.client).ListMarginCallSummaries(ctx, &financing.ListMarginCallSummariesRequest{
+		EntityId:  e.entityId,
+		StartDate: now.Add(-r.Lookback).UTC().Format(time.RFC3339),
+		EndDate:   now.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list margin call summaries: %w", err)
+	}
+
+	var findings []Finding
+	for _, s := range response.MarginSummaries {
+		key := s.ConversionDatetime
+		if key == "" {
+			key = s.ConversionDate
+		}
+		if key == "" {
+			continue
+		}
+
+		finding := Finding{Key: key, Message: fmt.Sprintf("new margin call summary for %s", key)}
+		if s.MarginSummary != nil && s.MarginSummary.ExcessDeficit != "" {
+			finding.Value = s.MarginSummary.ExcessDeficit
+			finding.Message += fmt.Sprintf(", excess/deficit %s", s.MarginSummary.ExcessDeficit)
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// fcmMarginCalls reports each FCM margin call that is not closed and still
+// has an amount remaining.
+func (e *Engine) fcmMarginCalls(ctx context.Context) ([]Finding, error) {
+	if e.entityId == "" {
+		return nil, fmt.Errorf("an entity ID is required")
+	}
+
+	response, err := futures.NewFuturesService(e.client).GetFcmMarginCallDetails(ctx, &futures.GetFcmMarginCallDetailsRequest{
+		EntityId: e.entityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get FCM margin calls: %w", err)
+	}
+
+	var findings []Finding
+	for _, call := range response.MarginCalls {
+		if call.State == model.FcmMarginCallStateClosed {
+			continue
+		}
+		if remaining, err := decimal.NewFromString(call.RemainingAmount); err == nil && !remaining.IsPositive() {
+			continue
+		}
+
+		message := fmt.Sprintf("FCM margin call %s from %s is %s with %s remaining",
+			call.Type, call.BusinessDate, call.State, call.RemainingAmount)
+		if call.CureDeadline != "" {
+			message += ", cure deadline " + call.CureDeadline
+		}
+		findings = append(findings, Finding{
+			Key:     fmt.Sprintf("%s/%s", call.Type, call.BusinessDate),
+			Message: message,
+			Value:   call.RemainingAmount,
+		})
+	}
+	return findings, nil
+}
+
+func (e *Engine) pendingWithdrawals(ctx context.Context, r *Rule) ([]Finding, error) {
+	portfolioId := e.portfolios[r.Name]
+	svc := transactions.NewTransactionsService(e.client)
+	now := e.now()
+
+	var findings []Finding
+	pagination := &model.PaginationParams{Limit: 100}
+	for {
+		response, err := svc.ListPortfolioTransactions(ctx, &transactions.ListPortfolioTransactionsRequest{
+			PortfolioId: portfolioId,
+			Types:       []string{"WITHDRAWAL"},
+			Start:       now.Add(-r.Lookback),
+			End:         now,
+			Pagination:  pagination,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list withdrawals: %w", err)
+		}
+
+		for _, t := range response.Transactions {
+			if terminal, _ := utils.ClassifyStatus(t.Status); terminal {
+				continue
+			}
+			age := now.Sub(t.Created)
+			if t.Created.IsZero() || age < r.OlderThan {
+				continue
+			}
+			findings = append(findings, Finding{
+				Key: t.Id,
+				Message: fmt.Sprintf("withdrawal %s of %s %s has been %s for %s",
+					t.Id, t.Amount, t.Symbol, t.Status, age.Truncate(time.Minute)),
+				Value: age.Truncate(time.Second).String(),
+			})
+		}
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		pagination = &model.PaginationParams{Limit: pagination.Limit, Cursor: response.Pagination.NextCursor}
+	}
+	return findings, nil
+}
@@ -0,0 +1,617 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/coinbase/prime-sdk-go/products"
+	"github.com/shopspring/decimal"
+)
+
+const defaultPollInterval = 2 * time.Second
+
+type Engine struct {
+	client  client.RestClient
+	path    string
+	state   *State
+	product *model.Product
+
+	cancelRequested bool
+
+	NewContext   func() (context.Context, context.CancelFunc)
+	PollInterval time.Duration
+
+	// Log receives progress messages. It may be nil.
+	Log func(format string, args ...any)
+}
+
+func NewEngine(c client.RestClient, path string, state *State, product *model.Product, newContext func() (context.Context, context.CancelFunc)) *Engine {
+	return &Engine{
+		client:       c,
+		path:         path,
+		state:        state,
+		product:      product,
+		NewContext:   newContext,
+		PollInterval: defaultPollInterval,
+	}
+}
+
+func (e *Engine) State() *State {
+	return e.state
+}
+
+func (e *Engine) logf(format string, args ...any) {
+	if e.Log != nil {
+		e.Log(format, args...)
+	}
+}
+
+func (e *Engine) save() error {
+	e.state.UpdatedAt = time.Now()
+	return Save(e.path, e.state)
+}
+
+func (e *Engine) finish(status, reason string) error {
+	e.state.Status = status
+	e.state.Reason = reason
+	e.logf("%s: %s", status, reason)
+	return e.save()
+}
+
+// Run works the parent order until it is filled, expires or ctx is
+// cancelled. Children left over from an earlier run are reconciled first.
+// On cancellation the state stays running so the next run resumes it.
+func (e *Engine) Run(ctx context.Context) error {
+	if e.state.Status != StatusRunning {
+		return fmt.Errorf("algo %s is already %s", e.state.Id, e.state.Status)
+	}
+
+	for _, child := range e.state.Children {
+		if e.childDone(child) {
+			continue
+		}
+		if err := e.resume(ctx, child); err != nil {
+			return err
+		}
+	}
+
+	total, _ := decimal.NewFromString(e.state.Params.Quantity)
+	for e.state.Status == StatusRunning {
+		if err := e.applyUpdate(); err != nil {
+			return err
+		}
+		if e.cancelRequested {
+			return e.finish(StatusCancelled, "cancelled by request")
+		}
+
+		filled, _ := e.state.Filled()
+		remaining := total.Sub(filled)
+		if !remaining.IsPositive() {
+			return e.finish(StatusCompleted, "parent quantity filled")
+		}
+
+		if err := e.next(ctx, remaining); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// next plans, sends and works one child.
+func (e *Engine) next(ctx context.Context, remaining decimal.Decimal) error {
+	p := e.state.Params
+	now := time.Now()
+	index := len(e.state.Children)
+
+	var at, deadline time.Time
+	var size decimal.Decimal
+	slice := 0
+
+	switch p.Strategy {
+	case StrategyTwap:
+		interval := p.Duration / time.Duration(p.Slices)
+		slice = int(now.Sub(e.state.StartedAt) / interval)
+		if n := len(e.state.Children); n > 0 && e.state.Children[n-1].Slice >= slice {
+			slice = e.state.Children[n-1].Slice + 1
+		}
+		if slice >= p.Slices {
+			return e.finish(StatusExpired, fmt.Sprintf("all %d slices used with %s unfilled", p.Slices, remaining.String()))
+		}
+		at = e.state.StartedAt.Add(time.Duration(slice) * interval)
+		deadline = at.Add(interval)
+		size = remaining.Div(decimal.NewFromInt(int64(p.Slices - slice)))
+	case StrategyIceberg:
+		if p.Duration > 0 && !now.Before(e.state.StartedAt.Add(p.Duration)) {
+			return e.finish(StatusExpired, fmt.Sprintf("duration of %s elapsed with %s unfilled", p.Duration, remaining.String()))
+		}
+		at = now
+		deadline = now.Add(p.ChildTimeout)
+		if p.Duration > 0 && deadline.After(e.state.StartedAt.Add(p.Duration)) {
+			deadline = e.state.StartedAt.Add(p.Duration)
+		}
+		size = e.clipSize(index)
+	}
+
+	if err := e.waitUntil(ctx, at); err != nil || e.cancelRequested {
+		return err
+	}
+
+	if size.GreaterThan(remaining) {
+		size = remaining
+	}
+
+	if e.state.Params.ParticipationCap != "" {
+		limit, err := e.participationLimit(ctx)
+		if err != nil {
+			return err
+		}
+		if size.GreaterThan(limit) {
+			e.logf("participation cap limits child %d to %s", index, limit.String())
+			size = limit
+		}
+	}
+
+	size = e.roundSize(size)
+	minSize, _ := decimal.NewFromString(e.product.BaseMinSize)
+	if size.IsZero() || size.LessThan(minSize) {
+		if remaining.LessThan(minSize) {
+			return e.finish(StatusCompleted, fmt.Sprintf("remaining %s is below the product minimum of %s", remaining.String(), minSize.String()))
+		}
+		e.logf("child %d would be below the minimum size, waiting", index)
+		return e.waitUntil(ctx, earliest(deadline, time.Now().Add(e.pollInterval()*5)))
+	}
+
+	child := &Child{
+		Index:         index,
+		Slice:         slice,
+		ClientOrderId: e.state.ClientOrderId(index),
+		Quantity:      size.String(),
+		LimitPrice:    e.state.Params.LimitPrice,
+		Status:        ChildPending,
+		CreatedAt:     time.Now(),
+	}
+
+	// The child is saved before it is sent, so a crash between the two is
+	// recovered by looking it up rather than sending it again.
+	e.state.Children = append(e.state.Children, child)
+	if err := e.save(); err != nil {
+		return err
+	}
+
+	if err := e.submit(ctx, child); err != nil {
+		return err
+	}
+	return e.work(ctx, child, deadline)
+}
+
+func (e *Engine) pollInterval() time.Duration {
+	if e.PollInterval <= 0 {
+		return defaultPollInterval
+	}
+	return e.PollInterval
+}
+
+// clipSize randomizes an iceberg clip. The random source is seeded from the
+// run ID and child index so a resumed run plans the same sizes.
+func (e *Engine) clipSize(index int) decimal.Decimal {
+	clip, _ := decimal.NewFromString(e.state.Params.ClipSize)
+	if e.state.Params.ClipVariance == 0 {
+		return clip
+	}
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%s/%d", e.state.Id, index)
+	r := rand.New(rand.NewSource(int64(h.Sum64())))
+
+	factor := 1 + e.state.Params.ClipVariance*(2*r.Float64()-1)
+	return clip.Mul(decimal.NewFromFloat(factor))
+}
+
+func (e *Engine) roundSize(size decimal.Decimal) decimal.Decimal {
+	increment, err := decimal.NewFromString(e.product.BaseIncrement)
+	if err != nil || !increment.IsPositive() {
+		return size
+	}
+	return size.Div(increment).Floor().Mul(increment)
+}
+
+// participationLimit returns the cap fraction of the product's volume over
+// the participation window, from one-minute candles.
+func (e *Engine) participationLimit(ctx context.Context) (decimal.Decimal, error) {
+	p := e.state.Params
+	fraction, _ := decimal.NewFromString(p.ParticipationCap)
+
+	reqCtx, cancel := e.NewContext()
+	defer cancel()
+
+	now := time.Now()
+	response, err := products.NewProductsService(e.client).GetProductCandles(reqCtx, &products.GetProductCandlesRequest{
+		PortfolioId: p.PortfolioId,
+		ProductId:   p.ProductId,
+		StartTime:   now.Add(-p.ParticipationWindow),
+		EndTime:     now,
+		Granularity: model.CandleGranularityOneMinute,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("cannot get candles for participation cap: %w", err)
+	}
+
+	volume := decimal.Zero
+	for _, candle := range response.Candles {
+		if v, err := decimal.NewFromString(candle.Volume); err == nil {
+			volume = volume.Add(v)
+		}
+	}
+	return volume.Mul(fraction), nil
+}
+
+func (e *Engine) submit(ctx context.Context, child *Child) error {
+	p := e.state.Params
+	order := &model.Order{
+		PortfolioId:   p.PortfolioId,
+		ProductId:     p.ProductId,
+		Side:          p.Side,
+		ClientOrderId: child.ClientOrderId,
+		BaseQuantity:  child.Quantity,
+	}
+	if child.LimitPrice != "" {
+		order.Type = utils.OrderTypeLimit
+		order.LimitPrice = child.LimitPrice
+		order.TimeInForce = utils.TifGoodUntilCancelled
+	} else {
+		order.Type = utils.OrderTypeMarket
+	}
+
+	reqCtx, cancel := e.NewContext()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(e.client).CreateOrder(reqCtx, &orders.CreateOrderRequest{Order: order})
+	if err != nil {
+		// The request may have reached the API, so the child stays pending
+		// and is looked up on the next run.
+		child.Error = err.Error()
+		if saveErr := e.save(); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		return fmt.Errorf("cannot create child %d: %w", child.Index, err)
+	}
+
+	child.OrderId = response.OrderId
+	child.Status = ChildSubmitted
+	child.Error = ""
+	e.logf("child %d: %s %s %s at %s (%s)", child.Index, p.Side, child.Quantity, p.ProductId, priceLabel(child.LimitPrice), child.OrderId)
+	return e.save()
+}
+
+func priceLabel(limitPrice string) string {
+	if limitPrice == "" {
+		return "market"
+	}
+	return limitPrice
+}
+
+// work polls a child until it is done. It is cancelled at the deadline or
+// when the limit price changes, and followed until the cancel settles.
+func (e *Engine) work(ctx context.Context, child *Child, deadline time.Time) error {
+	cancelled := false
+	for {
+		if err := e.refresh(child); err != nil {
+			e.logf("child %d: %v", child.Index, err)
+		} else if e.childDone(child) {
+			e.logf("child %d: %s, filled %s at %s", child.Index, child.Status, orZero(child.FilledQuantity), orZero(child.AverageFilledPrice))
+			return e.save()
+		}
+
+		if !cancelled {
+			if err := e.applyUpdate(); err != nil {
+				return err
+			}
+			repriced := child.LimitPrice != "" && child.LimitPrice != e.state.Params.LimitPrice
+			if repriced || e.cancelRequested || !time.Now().Before(deadline) {
+				if err := e.cancelChild(child); err != nil {
+					e.logf("child %d: %v", child.Index, err)
+				} else {
+					cancelled = true
+				}
+			}
+		}
+
+		if err := sleepUntil(ctx, time.Now().Add(e.pollInterval())); err != nil {
+			return err
+		}
+	}
+}
+
+func orZero(value string) string {
+	if value == "" {
+		return "0"
+	}
+	return value
+}
+
+func (e *Engine) childDone(child *Child) bool {
+	if child.Status == ChildPending || child.Status == ChildSubmitted {
+		return false
+	}
+	terminal, _ := utils.ClassifyStatus(child.Status)
+	return terminal
+}
+
+func (e *Engine) refresh(child *Child) error {
+	reqCtx, cancel := e.NewContext()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(e.client).GetOrder(reqCtx, &orders.GetOrderRequest{
+		PortfolioId: e.state.Params.PortfolioId,
+		OrderId:     child.OrderId,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot get order %s: %w", child.OrderId, err)
+	}
+	if response.Order == nil {
+		return fmt.Errorf("order %s not returned", child.OrderId)
+	}
+	applyOrder(child, response.Order)
+	return nil
+}
+
+func applyOrder(child *Child, o *model.Order) {
+	child.OrderId = o.Id
+	child.Status = o.Status
+	child.FilledQuantity = o.FilledQuantity
+	child.FilledValue = o.FilledValue
+	child.AverageFilledPrice = o.AverageFilledPrice
+}
+
+func (e *Engine) cancelChild(child *Child) error {
+	reqCtx, cancel := e.NewContext()
+	defer cancel()
+
+	if _, err := orders.NewOrdersService(e.client).CancelOrder(reqCtx, &orders.CancelOrderRequest{
+		PortfolioId: e.state.Params.PortfolioId,
+		OrderId:     child.OrderId,
+	}); err != nil {
+		return fmt.Errorf("cannot cancel order %s: %w", child.OrderId, err)
+	}
+	e.logf("child %d: cancel requested", child.Index)
+	return nil
+}
+
+// resume finishes a child left by an earlier run. A pending child is looked
+// up by its client order ID and only sent if the API has no record of it.
+func (e *Engine) resume(ctx context.Context, child *Child) error {
+	if child.OrderId == "" {
+		found, err := e.findByClientOrderId(child)
+		if err != nil {
+			return err
+		}
+		if found != nil {
+			e.logf("child %d: found %s from the earlier run", child.Index, found.Id)
+			applyOrder(child, found)
+			if err := e.save(); err != nil {
+				return err
+			}
+		} else {
+			e.logf("child %d: not found, sending again with the same client order ID", child.Index)
+			if err := e.submit(ctx, child); err != nil {
+				return err
+			}
+		}
+	}
+
+	if e.childDone(child) {
+		return nil
+	}
+	return e.work(ctx, child, e.childDeadline(child))
+}
+
+func (e *Engine) childDeadline(child *Child) time.Time {
+	p := e.state.Params
+	if p.Strategy == StrategyTwap {
+		interval := p.Duration / time.Duration(p.Slices)
+		return e.state.StartedAt.Add(time.Duration(child.Slice+1) * interval)
+	}
+	return child.CreatedAt.Add(p.ChildTimeout)
+}
+
+func (e *Engine) findByClientOrderId(child *Child) (*model.Order, error) {
+	p := e.state.Params
+	svc := orders.NewOrdersService(e.client)
+
+	pagination := &model.PaginationParams{Limit: 100}
+	for {
+		reqCtx, cancel := e.NewContext()
+		response, err := svc.ListOrders(reqCtx, &orders.ListOrdersRequest{
+			PortfolioId: p.PortfolioId,
+			ProductIds:  []string{p.ProductId},
+			OrderSide:   p.Side,
+			Start:       child.CreatedAt.Add(-time.Minute),
+			End:         time.Now().Add(time.Minute),
+			Pagination:  pagination,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot look up child %d: %w", child.Index, err)
+		}
+
+		for _, o := range response.Orders {
+			if o.ClientOrderId == child.ClientOrderId {
+				return o, nil
+			}
+		}
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		pagination = &model.PaginationParams{Limit: pagination.Limit, Cursor: response.Pagination.NextCursor}
+	}
+
+	reqCtx, cancel := e.NewContext()
+	defer cancel()
+	open, err := svc.ListOpenOrders(reqCtx, &orders.ListOpenOrdersRequest{
+		PortfolioId: p.PortfolioId,
+		ProductIds:  []string{p.ProductId},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot look up child %d: %w", child.Index, err)
+	}
+	for _, o := range open.Orders {
+		if o.ClientOrderId == child.ClientOrderId {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyUpdate merges queued parameter changes into the state and removes
+// the update file once they are saved.
+func (e *Engine) applyUpdate() error {
+	update, err := readUpdate(e.path)
+	if err != nil {
+		return err
+	}
+	if update.LimitPrice == nil && update.ParticipationCap == nil && !update.Cancel {
+		return nil
+	}
+
+	if update.Cancel && !e.cancelRequested {
+		e.logf("cancel requested")
+		e.cancelRequested = true
+	}
+
+	if update.LimitPrice != nil && *update.LimitPrice != e.state.Params.LimitPrice {
+		e.logf("limit price changed from %s to %s", priceLabel(e.state.Params.LimitPrice), *update.LimitPrice)
+		e.state.Params.LimitPrice = *update.LimitPrice
+	}
+	if update.ParticipationCap != nil && *update.ParticipationCap != e.state.Params.ParticipationCap {
+		e.logf("participation cap changed from %s to %s", orNone(e.state.Params.ParticipationCap), orNone(*update.ParticipationCap))
+		e.state.Params.ParticipationCap = *update.ParticipationCap
+		if e.state.Params.ParticipationWindow <= 0 {
+			e.state.Params.ParticipationWindow = defaultParticipationWindow
+		}
+	}
+
+	if err := e.save(); err != nil {
+		return err
+	}
+	if err := os.Remove(updatePath(e.path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot remove algo update: %w", err)
+	}
+	return nil
+}
+
+func orNone(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "none"
+	}
+	return value
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// waitUntil sleeps until t, applying queued updates every poll interval. It
+// returns early once a cancel is requested.
+func (e *Engine) waitUntil(ctx context.Context, t time.Time) error {
+	for time.Now().Before(t) {
+		if err := e.applyUpdate(); err != nil {
+			return err
+		}
+		if e.cancelRequested {
+			return nil
+		}
+		if err := sleepUntil(ctx, earliest(t, time.Now().Add(e.pollInterval()))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Cancel stops an algo that no run is working. Children still open are
+// cancelled and re-read, and the state is marked cancelled.
+func (e *Engine) Cancel(ctx context.Context) error {
+	if e.state.Status != StatusRunning {
+		return fmt.Errorf("algo %s is already %s", e.state.Id, e.state.Status)
+	}
+
+	for _, child := range e.state.Children {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if e.childDone(child) {
+			continue
+		}
+		if child.OrderId == "" {
+			found, err := e.findByClientOrderId(child)
+			if err != nil {
+				return err
+			}
+			if found == nil {
+				child.Status = "CANCELLED"
+				child.Error = "never reached the API"
+				continue
+			}
+			applyOrder(child, found)
+			if e.childDone(child) {
+				continue
+			}
+		}
+		if err := e.cancelChild(child); err != nil {
+			return err
+		}
+		if err := e.refresh(child); err != nil {
+			e.logf("child %d: %v", child.Index, err)
+		}
+	}
+	if err := e.finish(StatusCancelled, "cancelled by request"); err != nil {
+		return err
+	}
+	if err := os.Remove(updatePath(e.path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("cannot remove algo update: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coinbase-samples/prime-cli/runlock"
+)
+
+func lockPath(statePath string) string {
+	return statePath + ".lock"
+}
+
+// IsRunning reports whether a live run holds the state file's lock.
+func IsRunning(statePath string) bool {
+	return runlock.Held(lockPath(statePath))
+}
+
+// Lock claims the state file for one run, so two processes never work the
+// same algo. It is held until the returned release is called, so callers
+// take it before loading the state. If another run holds it, the error wraps
+// runlock.ErrLocked.
+func Lock(statePath string) (func(), error) {
+	if dir := filepath.Dir(statePath); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("cannot create algo state directory: %w", err)
+		}
+	}
+	release, err := runlock.Acquire(lockPath(statePath))
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock algo: %w", err)
+	}
+	return release, nil
+}
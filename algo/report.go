@@ -0,0 +1,99 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const averagePriceDecimals = 8
+
+// Report summarizes an algo's progress from its saved state.
+type Report struct {
+	Id           string    `json:"id"`
+	Strategy     string    `json:"strategy"`
+	ProductId    string    `json:"product_id"`
+	Side         string    `json:"side"`
+	Status       string    `json:"status"`
+	Reason       string    `json:"reason,omitempty"`
+	Quantity     string    `json:"base_quantity"`
+	Filled       string    `json:"filled_quantity"`
+	Remaining    string    `json:"remaining_quantity"`
+	FilledValue  string    `json:"filled_value"`
+	AveragePrice string    `json:"average_price,omitempty"`
+	LimitPrice   string    `json:"limit_price,omitempty"`
+	ChildCount   int       `json:"child_count"`
+	StartedAt    time.Time `json:"started_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Children     []*Child  `json:"children,omitempty"`
+}
+
+// BuildReport computes totals and the volume-weighted average fill price.
+// Children are included when withChildren is set.
+func BuildReport(s *State, withChildren bool) *Report {
+	total, _ := decimal.NewFromString(s.Params.Quantity)
+	filled, value := s.Filled()
+
+	remaining := total.Sub(filled)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+
+	report := &Report{
+		Id:          s.Id,
+		Strategy:    s.Params.Strategy,
+		ProductId:   s.Params.ProductId,
+		Side:        s.Params.Side,
+		Status:      s.Status,
+		Reason:      s.Reason,
+		Quantity:    total.String(),
+		Filled:      filled.String(),
+		Remaining:   remaining.String(),
+		FilledValue: value.String(),
+		LimitPrice:  s.Params.LimitPrice,
+		ChildCount:  len(s.Children),
+		StartedAt:   s.StartedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+	if filled.IsPositive() {
+		report.AveragePrice = value.Div(filled).Round(averagePriceDecimals).String()
+	}
+	if withChildren {
+		report.Children = s.Children
+	}
+	return report
+}
+
+// RefreshChildren re-reads every submitted child from the API so fills that
+// arrived after the run stopped are counted. It does not send or cancel
+// anything.
+func (e *Engine) RefreshChildren(ctx context.Context) error {
+	for _, child := range e.state.Children {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if child.OrderId == "" {
+			continue
+		}
+		if err := e.refresh(child); err != nil {
+			e.logf("child %d: %v", child.Index, err)
+		}
+	}
+	return e.save()
+}
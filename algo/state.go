@@ -0,0 +1,331 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package algo slices a parent order into child orders on the client side,
+// persisting its progress so an interrupted run can resume without sending
+// any child twice.
+package algo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	StrategyTwap    = "twap"
+	StrategyIceberg = "iceberg"
+
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusExpired   = "expired"
+	StatusCancelled = "cancelled"
+
+	// ChildPending marks a child that was recorded but may not have reached
+	// the API. It is looked up by client order ID before anything is resent.
+	ChildPending   = "pending"
+	ChildSubmitted = "submitted"
+
+	defaultChildTimeout        = time.Minute
+	defaultParticipationWindow = 5 * time.Minute
+)
+
+var Strategies = []string{StrategyTwap, StrategyIceberg}
+
+type Params struct {
+	Strategy    string `json:"strategy"`
+	PortfolioId string `json:"portfolio_id"`
+	ProductId   string `json:"product_id"`
+	Side        string `json:"side"`
+	Quantity    string `json:"base_quantity"`
+
+	// LimitPrice caps buys and floors sells. TWAP children are market orders
+	// when it is empty; iceberg requires it.
+	LimitPrice string `json:"limit_price,omitempty"`
+
+	// Duration and Slices shape a TWAP. For an iceberg, Duration optionally
+	// bounds the run.
+	Duration time.Duration `json:"duration"`
+	Slices   int           `json:"slices,omitempty"`
+
+	// ClipSize is the average iceberg child size. Each clip is randomized by
+	// up to ClipVariance in either direction, e.g. 0.2 for ±20%.
+	ClipSize     string  `json:"clip_size,omitempty"`
+	ClipVariance float64 `json:"clip_variance,omitempty"`
+
+	// ParticipationCap limits each child to this fraction of the product's
+	// volume over ParticipationWindow, e.g. 0.1 for 10%.
+	ParticipationCap    string        `json:"participation_cap,omitempty"`
+	ParticipationWindow time.Duration `json:"participation_window,omitempty"`
+
+	// ChildTimeout is how long an iceberg child may rest before it is
+	// cancelled and replaced. TWAP children rest until the next slice.
+	ChildTimeout time.Duration `json:"child_timeout,omitempty"`
+}
+
+type Child struct {
+	Index              int       `json:"index"`
+	Slice              int       `json:"slice,omitempty"`
+	ClientOrderId      string    `json:"client_order_id"`
+	OrderId            string    `json:"order_id,omitempty"`
+	Quantity           string    `json:"base_quantity"`
+	LimitPrice         string    `json:"limit_price,omitempty"`
+	Status             string    `json:"status"`
+	CreatedAt          time.Time `json:"created_at"`
+	FilledQuantity     string    `json:"filled_quantity,omitempty"`
+	FilledValue        string    `json:"filled_value,omitempty"`
+	AverageFilledPrice string    `json:"average_filled_price,omitempty"`
+	Error              string    `json:"error,omitempty"`
+}
+
+type State struct {
+	Id        string    `json:"id"`
+	Params    Params    `json:"params"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Children  []*Child  `json:"children"`
+}
+
+// Update holds parameter changes for a running algo. It is written next to
+// the state file by "algo set" and applied before the next child.
+type Update struct {
+	LimitPrice       *string `json:"limit_price,omitempty"`
+	ParticipationCap *string `json:"participation_cap,omitempty"`
+	Cancel           bool    `json:"cancel,omitempty"`
+}
+
+// Validate checks the parameters before a run starts.
+func (p *Params) Validate() error {
+	switch p.Strategy {
+	case StrategyTwap:
+		if p.Duration <= 0 {
+			return fmt.Errorf("twap requires a positive duration")
+		}
+		if p.Slices <= 0 {
+			return fmt.Errorf("twap requires a positive number of slices")
+		}
+	case StrategyIceberg:
+		if p.LimitPrice == "" {
+			return fmt.Errorf("iceberg requires a limit price")
+		}
+		if _, err := positive("clip size", p.ClipSize); err != nil {
+			return err
+		}
+		if p.ClipVariance < 0 || p.ClipVariance >= 1 {
+			return fmt.Errorf("clip variance must be at least 0 and below 1, got %v", p.ClipVariance)
+		}
+		if p.Duration < 0 {
+			return fmt.Errorf("duration cannot be negative")
+		}
+		if p.ChildTimeout == 0 {
+			p.ChildTimeout = defaultChildTimeout
+		}
+	default:
+		return fmt.Errorf("unknown strategy %q (supported: %s)", p.Strategy, strings.Join(Strategies, ", "))
+	}
+
+	if p.Side != "BUY" && p.Side != "SELL" {
+		return fmt.Errorf("side must be BUY or SELL, got %q", p.Side)
+	}
+	if _, err := positive("base quantity", p.Quantity); err != nil {
+		return err
+	}
+	if p.LimitPrice != "" {
+		if _, err := positive("limit price", p.LimitPrice); err != nil {
+			return err
+		}
+	}
+	if p.ParticipationCap != "" {
+		if err := validateCap(p.ParticipationCap); err != nil {
+			return err
+		}
+		if p.ParticipationWindow == 0 {
+			p.ParticipationWindow = defaultParticipationWindow
+		}
+		if p.ParticipationWindow < 0 {
+			return fmt.Errorf("participation window cannot be negative")
+		}
+	}
+	if p.ChildTimeout < 0 {
+		return fmt.Errorf("child timeout cannot be negative")
+	}
+	return nil
+}
+
+func positive(name, value string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if !d.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%s must be greater than zero, got %q", name, value)
+	}
+	return d, nil
+}
+
+func validateCap(value string) error {
+	fraction, err := positive("participation cap", value)
+	if err != nil {
+		return err
+	}
+	if fraction.GreaterThan(decimal.NewFromInt(1)) {
+		return fmt.Errorf("participation cap is a fraction of volume and cannot exceed 1, got %s", value)
+	}
+	return nil
+}
+
+// NewState starts a run. The ID seeds every child's client order ID.
+func NewState(params Params, now time.Time) *State {
+	return &State{
+		Id:        uuid.NewString(),
+		Params:    params,
+		Status:    StatusRunning,
+		StartedAt: now,
+		UpdatedAt: now,
+		Children:  []*Child{},
+	}
+}
+
+// ClientOrderId derives a child's client order ID from the run ID and the
+// child index, so a resent child always carries the same ID.
+func (s *State) ClientOrderId(index int) string {
+	return uuid.NewSHA1(uuid.MustParse(s.Id), []byte(fmt.Sprintf("child-%d", index))).String()
+}
+
+// Filled sums the filled quantity and value across children.
+func (s *State) Filled() (quantity, value decimal.Decimal) {
+	for _, c := range s.Children {
+		if q, err := decimal.NewFromString(c.FilledQuantity); err == nil {
+			quantity = quantity.Add(q)
+		}
+		if v, err := decimal.NewFromString(c.FilledValue); err == nil {
+			value = value.Add(v)
+		} else if p, err := decimal.NewFromString(c.AverageFilledPrice); err == nil {
+			if q, err := decimal.NewFromString(c.FilledQuantity); err == nil {
+				value = value.Add(p.Mul(q))
+			}
+		}
+	}
+	return quantity, value
+}
+
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("cannot parse algo state %s: %w", path, err)
+	}
+	if _, err := uuid.Parse(state.Id); err != nil {
+		return nil, fmt.Errorf("algo state %s has an invalid id: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes through a temporary file and rename, so a crash mid-write
+// leaves the previous state intact.
+func Save(path string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode algo state: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("cannot create algo state directory: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("cannot write algo state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("cannot write algo state: %w", err)
+	}
+	return nil
+}
+
+func updatePath(statePath string) string {
+	return statePath + ".update"
+}
+
+// WriteUpdate queues parameter changes for the run using statePath. Fields
+// already queued and not yet applied are kept unless overwritten.
+func WriteUpdate(statePath string, update Update) error {
+	if update.LimitPrice != nil {
+		if _, err := positive("limit price", *update.LimitPrice); err != nil {
+			return err
+		}
+	}
+	if update.ParticipationCap != nil && *update.ParticipationCap != "" {
+		if err := validateCap(*update.ParticipationCap); err != nil {
+			return err
+		}
+	}
+
+	pending, err := readUpdate(statePath)
+	if err != nil {
+		return err
+	}
+	if update.LimitPrice != nil {
+		pending.LimitPrice = update.LimitPrice
+	}
+	if update.ParticipationCap != nil {
+		pending.ParticipationCap = update.ParticipationCap
+	}
+	if update.Cancel {
+		pending.Cancel = true
+	}
+
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return fmt.Errorf("cannot encode algo update: %w", err)
+	}
+	path := updatePath(statePath)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("cannot write algo update: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("cannot write algo update: %w", err)
+	}
+	return nil
+}
+
+func readUpdate(statePath string) (*Update, error) {
+	data, err := os.ReadFile(updatePath(statePath))
+	if errors.Is(err, os.ErrNotExist) {
+		return &Update{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read algo update: %w", err)
+	}
+	update := &Update{}
+	if err := json.Unmarshal(data, update); err != nil {
+		return nil, fmt.Errorf("cannot parse algo update: %w", err)
+	}
+	return update, nil
+}
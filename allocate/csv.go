@@ -0,0 +1,134 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package allocate
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Allocation file columns. A row may name an order to include, a
+// destination with its share, or both; the header row may list the columns
+// in any order.
+const (
+	ColumnOrderId     = "order_id"
+	ColumnDestination = "destination_portfolio_id"
+	ColumnPercent     = "percent"
+	ColumnAmount      = "amount"
+)
+
+var Columns = []string{ColumnOrderId, ColumnDestination, ColumnPercent, ColumnAmount}
+
+// ReadFile reads order IDs and splits from an allocation file. Lines
+// starting with # are ignored.
+func ReadFile(path string) ([]string, []*Split, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open allocation file: %w", err)
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("allocation file %s is empty", path)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read allocation file header: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(Columns, name) {
+			return nil, nil, fmt.Errorf("unknown column %q (supported: %s)", name, strings.Join(Columns, ", "))
+		}
+		if _, ok := index[name]; ok {
+			return nil, nil, fmt.Errorf("duplicate column %q", name)
+		}
+		index[name] = i
+	}
+
+	var orderIds []string
+	var splits []*Split
+	seenOrders := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read allocation file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		if orderId := get(ColumnOrderId); orderId != "" && !seenOrders[orderId] {
+			seenOrders[orderId] = true
+			orderIds = append(orderIds, orderId)
+		}
+
+		destination, percent, amount := get(ColumnDestination), get(ColumnPercent), get(ColumnAmount)
+		if destination == "" {
+			if percent != "" || amount != "" {
+				return nil, nil, fmt.Errorf("line %d: a share needs a %s", line, ColumnDestination)
+			}
+			continue
+		}
+
+		var share string
+		switch {
+		case percent != "" && amount != "":
+			return nil, nil, fmt.Errorf("line %d: set either %s or %s, not both", line, ColumnPercent, ColumnAmount)
+		case percent != "":
+			share = strings.TrimSuffix(percent, "%") + "%"
+		case amount != "":
+			share = amount
+		default:
+			return nil, nil, fmt.Errorf("line %d: destination %s needs a %s or %s", line, destination, ColumnPercent, ColumnAmount)
+		}
+
+		split, err := NewSplit(destination, share)
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		splits = append(splits, split)
+	}
+	return orderIds, splits, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
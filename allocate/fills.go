@@ -0,0 +1,120 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package allocate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/shopspring/decimal"
+)
+
+// Totals is what a set of orders executed, summed from their fills.
+type Totals struct {
+	ProductId    string          `json:"product_id"`
+	Side         string          `json:"side"`
+	OrderIds     []string        `json:"order_ids"`
+	Fills        int             `json:"fills"`
+	BaseQuantity decimal.Decimal `json:"base_quantity"`
+	QuoteValue   decimal.Decimal `json:"quote_value"`
+}
+
+// Size returns the total in the units of sizeType.
+func (t *Totals) Size(sizeType string) decimal.Decimal {
+	if sizeType == SizeTypeQuote {
+		return t.QuoteValue
+	}
+	return t.BaseQuantity
+}
+
+// FetchTotals sums the fills of every order. All orders must have fills and
+// share one product and side, as an allocation covers a single product.
+func FetchTotals(
+	c client.RestClient,
+	portfolioId string,
+	orderIds []string,
+	newContext func() (context.Context, context.CancelFunc),
+) (*Totals, error) {
+	svc := orders.NewOrdersService(c)
+	totals := &Totals{OrderIds: orderIds}
+
+	for _, orderId := range orderIds {
+		fills, err := listFills(svc, portfolioId, orderId, newContext)
+		if err != nil {
+			return nil, err
+		}
+		if len(fills) == 0 {
+			return nil, fmt.Errorf("order %s has no fills to allocate", orderId)
+		}
+
+		for _, fill := range fills {
+			if totals.ProductId == "" {
+				totals.ProductId, totals.Side = fill.ProductId, fill.Side
+			}
+			if fill.ProductId != totals.ProductId || fill.Side != totals.Side {
+				return nil, fmt.Errorf(
+					"order %s is a %s %s but earlier orders are %s %s; allocate each product and side separately",
+					orderId, fill.Side, fill.ProductId, totals.Side, totals.ProductId,
+				)
+			}
+
+			quantity, err := decimal.NewFromString(fill.FilledQuantity)
+			if err != nil {
+				return nil, fmt.Errorf("fill %s of order %s has an invalid filled quantity %q", fill.Id, orderId, fill.FilledQuantity)
+			}
+			value, err := decimal.NewFromString(fill.FilledValue)
+			if err != nil {
+				return nil, fmt.Errorf("fill %s of order %s has an invalid filled value %q", fill.Id, orderId, fill.FilledValue)
+			}
+			totals.BaseQuantity = totals.BaseQuantity.Add(quantity)
+			totals.QuoteValue = totals.QuoteValue.Add(value)
+			totals.Fills++
+		}
+	}
+	return totals, nil
+}
+
+func listFills(
+	svc orders.OrdersService,
+	portfolioId, orderId string,
+	newContext func() (context.Context, context.CancelFunc),
+) ([]*model.OrderFill, error) {
+	var fills []*model.OrderFill
+	cursor := ""
+	for {
+		ctx, cancel := newContext()
+		response, err := svc.ListOrderFills(ctx, &orders.ListOrderFillsRequest{
+			PortfolioId: portfolioId,
+			OrderId:     orderId,
+			Pagination:  &model.PaginationParams{Cursor: cursor, Limit: 1000},
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list fills for order %s: %w", orderId, err)
+		}
+
+		fills = append(fills, response.Fills...)
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = response.Pagination.NextCursor
+	}
+	return fills, nil
+}
@@ -0,0 +1,279 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package allocate
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/allocations"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/shopspring/decimal"
+)
+
+const allocationStatusRejected = "ALLOCATION_STATUS_ALLOCATION_REJECTED"
+
+// executedStatuses are the terminal order statuses that can carry fills.
+var executedStatuses = []string{"FILLED", "CANCELLED", "EXPIRED"}
+
+// PendingOrder is an executed order with quantity not yet allocated.
+type PendingOrder struct {
+	OrderId             string          `json:"order_id"`
+	ClientOrderId       string          `json:"client_order_id,omitempty"`
+	ProductId           string          `json:"product_id"`
+	Side                string          `json:"side"`
+	Status              string          `json:"status"`
+	CreatedAt           string          `json:"created_at"`
+	Age                 string          `json:"age"`
+	FilledQuantity      decimal.Decimal `json:"filled_quantity"`
+	FilledValue         decimal.Decimal `json:"filled_value"`
+	AllocatedQuantity   decimal.Decimal `json:"allocated_quantity"`
+	UnallocatedQuantity decimal.Decimal `json:"unallocated_quantity"`
+	AllocationIds       []string        `json:"allocation_ids,omitempty"`
+}
+
+// ListExecutedOrders returns the orders created in the range that have fills.
+func ListExecutedOrders(
+	c client.RestClient,
+	portfolioId string,
+	productIds []string,
+	start, end time.Time,
+	newContext func() (context.Context, context.CancelFunc),
+) ([]*model.Order, error) {
+	svc := orders.NewOrdersService(c)
+
+	var executed []*model.Order
+	cursor := ""
+	for {
+		ctx, cancel := newContext()
+		response, err := svc.ListOrders(ctx, &orders.ListOrdersRequest{
+			PortfolioId: portfolioId,
+			Statuses:    executedStatuses,
+			ProductIds:  productIds,
+			Start:       start,
+			End:         end,
+			Pagination:  &model.PaginationParams{Cursor: cursor, Limit: 1000},
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list orders: %w", err)
+		}
+
+		for _, o := range response.Orders {
+			if amount(o.FilledQuantity).IsPositive() {
+				executed = append(executed, o)
+			}
+		}
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = response.Pagination.NextCursor
+	}
+	return executed, nil
+}
+
+// ListAllocations returns the allocations made from the portfolio since
+// start.
+func ListAllocations(
+	c client.RestClient,
+	portfolioId string,
+	productIds []string,
+	start time.Time,
+	newContext func() (context.Context, context.CancelFunc),
+) ([]*model.Allocation, error) {
+	svc := allocations.NewAllocationsService(c)
+
+	var all []*model.Allocation
+	cursor := ""
+	for {
+		ctx, cancel := newContext()
+		response, err := svc.ListPortfolioAllocations(ctx, &allocations.ListPortfolioAllocationsRequest{
+			PortfolioId: portfolioId,
+			ProductIds:  productIds,
+			Start:       start,
+			Pagination:  &model.PaginationParams{Cursor: cursor, Limit: 1000},
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list allocations: %w", err)
+		}
+
+		all = append(all, response.Allocations...)
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		cursor = response.Pagination.NextCursor
+	}
+	return all, nil
+}
+
+// FindPending matches executed orders against allocations. Rejected
+// allocations, reversals and the allocations they reversed do not count. An
+// allocation covering several orders is attributed to them in proportion to
+// their filled quantity, so an allocation that also covers orders outside
+// the listed range can make those in range look more allocated than they are.
+func FindPending(executed []*model.Order, allocs []*model.Allocation, now time.Time) []*PendingOrder {
+	byId := map[string]*PendingOrder{}
+	var pending []*PendingOrder
+	for _, o := range executed {
+		p := &PendingOrder{
+			OrderId:        o.Id,
+			ClientOrderId:  o.ClientOrderId,
+			ProductId:      o.ProductId,
+			Side:           o.Side,
+			Status:         o.Status,
+			CreatedAt:      o.Created,
+			FilledQuantity: amount(o.FilledQuantity),
+			FilledValue:    amount(o.FilledValue),
+		}
+		if created, err := time.Parse(time.RFC3339Nano, o.Created); err == nil {
+			p.Age = now.Sub(created).Truncate(time.Minute).String()
+		}
+		byId[o.Id] = p
+		pending = append(pending, p)
+	}
+
+	reversed := map[string]bool{}
+	for _, a := range allocs {
+		if a.ReversalId != "" {
+			reversed[a.RootId] = true
+		}
+	}
+
+	for _, a := range allocs {
+		if a.ReversalId != "" || reversed[a.RootId] || a.Status == allocationStatusRejected {
+			continue
+		}
+
+		var covered []*PendingOrder
+		filled := decimal.Zero
+		for _, orderId := range a.OrderIds {
+			if p, ok := byId[orderId]; ok {
+				covered = append(covered, p)
+				filled = filled.Add(p.FilledQuantity)
+			}
+		}
+		if len(covered) == 0 || !filled.IsPositive() {
+			continue
+		}
+
+		allocated := amount(a.BaseQuantity)
+		for _, p := range covered {
+			share := allocated.Mul(p.FilledQuantity).Div(filled)
+			p.AllocatedQuantity = p.AllocatedQuantity.Add(share)
+			p.AllocationIds = append(p.AllocationIds, a.RootId)
+		}
+	}
+
+	var result []*PendingOrder
+	for _, p := range pending {
+		p.UnallocatedQuantity = p.FilledQuantity.Sub(p.AllocatedQuantity)
+		if p.UnallocatedQuantity.IsPositive() {
+			result = append(result, p)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt < result[j].CreatedAt
+	})
+	return result
+}
+
+// WriteSkeletons writes one allocation file per product and side for the
+// orders with nothing allocated yet, ready to have destinations added and
+// be passed to the builder. Partly allocated orders are left out because
+// the builder allocates whole orders. It returns the files written.
+func WriteSkeletons(dir string, pending []*PendingOrder) ([]string, error) {
+	groups := map[string][]*PendingOrder{}
+	for _, p := range pending {
+		if !p.AllocatedQuantity.IsZero() {
+			continue
+		}
+		key := p.ProductId + "-" + strings.ToLower(p.Side)
+		groups[key] = append(groups[key], p)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create skeleton directory: %w", err)
+	}
+
+	var paths []string
+	for _, key := range keys {
+		path := filepath.Join(dir, key+".csv")
+		if err := writeSkeleton(path, groups[key]); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func writeSkeleton(path string, group []*PendingOrder) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("cannot create skeleton file: %w", err)
+	}
+	defer f.Close()
+
+	quantity, value := decimal.Zero, decimal.Zero
+	for _, p := range group {
+		quantity = quantity.Add(p.FilledQuantity)
+		value = value.Add(p.FilledValue)
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.Write(Columns); err != nil {
+		return err
+	}
+	w.Flush()
+
+	fmt.Fprintf(f, "# %s %s: %d orders, %s base, %s quote\n", group[0].Side, group[0].ProductId, len(group), quantity, value)
+	fmt.Fprintf(f, "# Add a row per destination with %s and either %s or %s.\n", ColumnDestination, ColumnPercent, ColumnAmount)
+
+	for _, p := range group {
+		if err := w.Write([]string{p.OrderId, "", "", ""}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("cannot write skeleton file: %w", err)
+	}
+	return f.Close()
+}
+
+func amount(value string) decimal.Decimal {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
@@ -0,0 +1,239 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package allocate turns percentage or amount splits of executed orders into
+// allocation legs, and finds executed orders that still need allocating.
+package allocate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	SizeTypeBase  = "BASE"
+	SizeTypeQuote = "QUOTE"
+
+	RoundingDown    = "down"
+	RoundingNearest = "nearest"
+)
+
+var (
+	SizeTypes = []string{SizeTypeBase, SizeTypeQuote}
+	Roundings = []string{RoundingDown, RoundingNearest}
+
+	hundred = decimal.NewFromInt(100)
+)
+
+// Split is a requested share for one destination portfolio, either a
+// percentage of the total or a fixed amount in the size type's units.
+type Split struct {
+	PortfolioId string
+	Percent     decimal.Decimal
+	Amount      decimal.Decimal
+	IsPercent   bool
+}
+
+func (s *Split) String() string {
+	if s.IsPercent {
+		return s.Percent.String() + "%"
+	}
+	return s.Amount.String()
+}
+
+// ParseSplit reads a "portfolio=40%" or "portfolio=1.25" flag value.
+func ParseSplit(value string) (*Split, error) {
+	portfolio, share, ok := strings.Cut(value, "=")
+	portfolio, share = strings.TrimSpace(portfolio), strings.TrimSpace(share)
+	if !ok || portfolio == "" || share == "" {
+		return nil, fmt.Errorf("split %q must be PORTFOLIO=PERCENT%% or PORTFOLIO=AMOUNT", value)
+	}
+	return NewSplit(portfolio, share)
+}
+
+// NewSplit builds a split from a share that is a percentage when it ends in
+// "%" and an amount otherwise.
+func NewSplit(portfolioId, share string) (*Split, error) {
+	s := &Split{PortfolioId: portfolioId}
+
+	value := share
+	if trimmed, ok := strings.CutSuffix(share, "%"); ok {
+		value = strings.TrimSpace(trimmed)
+		s.IsPercent = true
+	}
+
+	d, err := decimal.NewFromString(value)
+	if err != nil || !d.IsPositive() {
+		return nil, fmt.Errorf("share %q for portfolio %s must be a positive number", share, portfolioId)
+	}
+
+	if s.IsPercent {
+		if d.GreaterThan(hundred) {
+			return nil, fmt.Errorf("share %q for portfolio %s is over 100%%", share, portfolioId)
+		}
+		s.Percent = d
+	} else {
+		s.Amount = d
+	}
+	return s, nil
+}
+
+// Leg is a computed allocation to one destination.
+type Leg struct {
+	PortfolioId string          `json:"destination_portfolio_id"`
+	Requested   string          `json:"requested"`
+	Amount      decimal.Decimal `json:"amount"`
+	Remainder   decimal.Decimal `json:"remainder"`
+
+	raw decimal.Decimal
+}
+
+// Plan is the result of applying splits to a total.
+type Plan struct {
+	Total     decimal.Decimal `json:"total"`
+	Increment string          `json:"increment,omitempty"`
+	Legs      []*Leg          `json:"legs"`
+
+	// Remainder is what is left after the legs. It goes to
+	// RemainderPortfolioId, either folded into that destination's leg or
+	// routed there by the allocation API when it is not one of the legs.
+	Remainder            decimal.Decimal `json:"remainder"`
+	RemainderPortfolioId string          `json:"remainder_portfolio_id"`
+}
+
+// Compute rounds each split to the increment and assigns what rounding and
+// any unallocated share leave over to remainderPortfolioId, which defaults
+// to the destination of the largest leg. Splits may not ask for more than the
+// total, and each destination may appear once.
+func Compute(total decimal.Decimal, splits []*Split, increment, rounding, remainderPortfolioId string) (*Plan, error) {
+	if !total.IsPositive() {
+		return nil, fmt.Errorf("nothing to allocate: total is %s", total)
+	}
+	if len(splits) == 0 {
+		return nil, fmt.Errorf("at least one split is required")
+	}
+
+	step := decimal.Zero
+	if increment != "" {
+		var err error
+		if step, err = decimal.NewFromString(increment); err != nil {
+			return nil, fmt.Errorf("invalid increment %q: %w", increment, err)
+		}
+	}
+
+	plan := &Plan{Total: total, Increment: increment}
+	seen := map[string]bool{}
+	percent, requested := decimal.Zero, decimal.Zero
+	for _, s := range splits {
+		if seen[s.PortfolioId] {
+			return nil, fmt.Errorf("portfolio %s has more than one split", s.PortfolioId)
+		}
+		seen[s.PortfolioId] = true
+
+		raw := s.Amount
+		if s.IsPercent {
+			percent = percent.Add(s.Percent)
+			raw = total.Mul(s.Percent).Div(hundred)
+		}
+		requested = requested.Add(raw)
+
+		plan.Legs = append(plan.Legs, &Leg{
+			PortfolioId: s.PortfolioId,
+			Requested:   s.String(),
+			Amount:      round(raw, step, rounding),
+			raw:         raw,
+		})
+	}
+	if percent.GreaterThan(hundred) {
+		return nil, fmt.Errorf("percentages add up to %s%%, more than 100%%", percent)
+	}
+	if requested.GreaterThan(total) {
+		return nil, fmt.Errorf("splits request %s, more than the total %s", requested, total)
+	}
+
+	// Rounding to nearest can overshoot; take the excess back from the legs
+	// that were rounded up the most.
+	sum := sumLegs(plan.Legs)
+	for sum.GreaterThan(total) && step.IsPositive() {
+		leg := mostRoundedUp(plan.Legs)
+		leg.Amount = leg.Amount.Sub(step)
+		sum = sum.Sub(step)
+	}
+
+	plan.Remainder = total.Sub(sum)
+	plan.RemainderPortfolioId = remainderPortfolioId
+	if plan.RemainderPortfolioId == "" {
+		plan.RemainderPortfolioId = largest(plan.Legs).PortfolioId
+	}
+	if plan.Remainder.IsPositive() {
+		for _, leg := range plan.Legs {
+			if leg.PortfolioId == plan.RemainderPortfolioId {
+				leg.Remainder = plan.Remainder
+				leg.Amount = leg.Amount.Add(plan.Remainder)
+				plan.Remainder = decimal.Zero
+				break
+			}
+		}
+	}
+
+	for _, leg := range plan.Legs {
+		if !leg.Amount.IsPositive() {
+			return nil, fmt.Errorf("split %s for portfolio %s rounds to zero at increment %s", leg.Requested, leg.PortfolioId, increment)
+		}
+	}
+	return plan, nil
+}
+
+func round(value, step decimal.Decimal, rounding string) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	units := value.Div(step)
+	if rounding == RoundingNearest {
+		units = units.Round(0)
+	} else {
+		units = units.Floor()
+	}
+	return units.Mul(step)
+}
+
+func sumLegs(legs []*Leg) decimal.Decimal {
+	sum := decimal.Zero
+	for _, leg := range legs {
+		sum = sum.Add(leg.Amount)
+	}
+	return sum
+}
+
+func mostRoundedUp(legs []*Leg) *Leg {
+	sorted := append([]*Leg(nil), legs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Amount.Sub(sorted[i].raw).GreaterThan(sorted[j].Amount.Sub(sorted[j].raw))
+	})
+	return sorted[0]
+}
+
+func largest(legs []*Leg) *Leg {
+	best := legs[0]
+	for _, leg := range legs[1:] {
+		if leg.Amount.GreaterThan(best.Amount) {
+			best = leg
+		}
+	}
+	return best
+}
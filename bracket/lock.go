@@ -0,0 +1,40 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bracket
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/coinbase-samples/prime-cli/runlock"
+)
+
+// Lock claims the state file, so two processes never supervise the same
+// bracket. It is held until the returned release is called, so callers take
+// it before loading the state.
+func Lock(statePath string) (func(), error) {
+	if dir := filepath.Dir(statePath); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, fmt.Errorf("cannot create bracket state directory: %w", err)
+		}
+	}
+	release, err := runlock.Acquire(statePath + ".lock")
+	if err != nil {
+		return nil, fmt.Errorf("cannot lock bracket: %w", err)
+	}
+	return release, nil
+}
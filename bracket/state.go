@@ -0,0 +1,274 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package bracket emulates contingent orders on the client side: an entry
+// order followed by a resting take-profit limit and a watched stop, where
+// one exit leg filling cancels the other.
+package bracket
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+
+	// StatusFailed means the stop order ended without closing the position.
+	StatusFailed = "failed"
+
+	// PhaseEntry waits for the entry order, PhaseProtect rests the take
+	// profit and watches the stop, and PhaseStop works the stop order after
+	// the trigger price was crossed.
+	PhaseEntry   = "entry"
+	PhaseProtect = "protect"
+	PhaseStop    = "stop"
+
+	RoleEntry      = "entry"
+	RoleTakeProfit = "take_profit"
+	RoleStopLoss   = "stop_loss"
+
+	// LegPending marks a leg that was recorded but may not have reached the
+	// API. It is looked up by client order ID before anything is resent.
+	LegPending   = "pending"
+	LegSubmitted = "submitted"
+)
+
+type Params struct {
+	PortfolioId string `json:"portfolio_id"`
+	ProductId   string `json:"product_id"`
+
+	// Side is the entry side: BUY opens a long that the exits sell, SELL a
+	// short that the exits buy back.
+	Side     string `json:"side"`
+	Quantity string `json:"base_quantity"`
+
+	// SkipEntry protects a position already held instead of opening one, so
+	// the two exit legs form a one-cancels-other pair.
+	SkipEntry bool `json:"skip_entry,omitempty"`
+
+	// EntryPrice makes the entry a limit order; it is a market order when
+	// empty. An entry still working after EntryTimeout is cancelled and the
+	// filled part is protected.
+	EntryPrice   string        `json:"entry_price,omitempty"`
+	EntryTimeout time.Duration `json:"entry_timeout,omitempty"`
+
+	TakeProfitPrice string `json:"take_profit_price,omitempty"`
+
+	// StopPrice triggers the stop leg when the last price reaches it. The
+	// stop is sent as a market order, or as a limit at StopLimitPrice.
+	StopPrice      string `json:"stop_price,omitempty"`
+	StopLimitPrice string `json:"stop_limit_price,omitempty"`
+}
+
+type Leg struct {
+	Role               string    `json:"role"`
+	ClientOrderId      string    `json:"client_order_id"`
+	OrderId            string    `json:"order_id,omitempty"`
+	Side               string    `json:"side"`
+	Type               string    `json:"type"`
+	Quantity           string    `json:"base_quantity"`
+	LimitPrice         string    `json:"limit_price,omitempty"`
+	Status             string    `json:"status"`
+	CreatedAt          time.Time `json:"created_at"`
+	FilledQuantity     string    `json:"filled_quantity,omitempty"`
+	FilledValue        string    `json:"filled_value,omitempty"`
+	AverageFilledPrice string    `json:"average_filled_price,omitempty"`
+	Error              string    `json:"error,omitempty"`
+}
+
+type State struct {
+	Id           string     `json:"id"`
+	Params       Params     `json:"params"`
+	Status       string     `json:"status"`
+	Phase        string     `json:"phase"`
+	Reason       string     `json:"reason,omitempty"`
+	StartedAt    time.Time  `json:"started_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	TriggeredAt  *time.Time `json:"triggered_at,omitempty"`
+	TriggerPrice string     `json:"trigger_price,omitempty"`
+	Entry        *Leg       `json:"entry,omitempty"`
+	TakeProfit   *Leg       `json:"take_profit,omitempty"`
+	StopLoss     *Leg       `json:"stop_loss,omitempty"`
+}
+
+// ExitSide is the side of both exit legs.
+func (p *Params) ExitSide() string {
+	if p.Side == "BUY" {
+		return "SELL"
+	}
+	return "BUY"
+}
+
+// Validate checks the parameters before a bracket starts, including that the
+// prices sit on the correct sides of each other.
+func (p *Params) Validate() error {
+	if p.Side != "BUY" && p.Side != "SELL" {
+		return fmt.Errorf("side must be BUY or SELL, got %q", p.Side)
+	}
+	if _, err := positive("base quantity", p.Quantity); err != nil {
+		return err
+	}
+	if p.TakeProfitPrice == "" && p.StopPrice == "" {
+		return fmt.Errorf("a take profit price, a stop price or both are required")
+	}
+	if p.SkipEntry && p.EntryPrice != "" {
+		return fmt.Errorf("an entry price cannot be used when the entry is skipped")
+	}
+	if p.StopLimitPrice != "" && p.StopPrice == "" {
+		return fmt.Errorf("a stop limit price requires a stop price")
+	}
+	if p.EntryTimeout < 0 {
+		return fmt.Errorf("entry timeout cannot be negative")
+	}
+
+	prices := map[string]decimal.Decimal{}
+	for name, value := range map[string]string{
+		"entry price":       p.EntryPrice,
+		"take profit price": p.TakeProfitPrice,
+		"stop price":        p.StopPrice,
+		"stop limit price":  p.StopLimitPrice,
+	} {
+		if value == "" {
+			continue
+		}
+		d, err := positive(name, value)
+		if err != nil {
+			return err
+		}
+		prices[name] = d
+	}
+
+	// For a long the order is stop < entry < take profit; a short mirrors it.
+	above := func(high, low string) error {
+		h, hok := prices[high]
+		l, lok := prices[low]
+		if !hok || !lok {
+			return nil
+		}
+		if p.Side == "SELL" {
+			h, l = l, h
+			high, low = low, high
+		}
+		if !h.GreaterThan(l) {
+			return fmt.Errorf("%s must be above the %s for a %s entry", high, low, p.Side)
+		}
+		return nil
+	}
+	if err := above("take profit price", "stop price"); err != nil {
+		return err
+	}
+	if err := above("take profit price", "entry price"); err != nil {
+		return err
+	}
+	return above("entry price", "stop price")
+}
+
+func positive(name, value string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+	if !d.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%s must be greater than zero, got %q", name, value)
+	}
+	return d, nil
+}
+
+// NewState starts a bracket. The ID seeds every leg's client order ID.
+func NewState(params Params, now time.Time) *State {
+	phase := PhaseEntry
+	if params.SkipEntry {
+		phase = PhaseProtect
+	}
+	return &State{
+		Id:        uuid.NewString(),
+		Params:    params,
+		Status:    StatusRunning,
+		Phase:     phase,
+		StartedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// ClientOrderId derives a leg's client order ID from the bracket ID and the
+// leg's role, so a resent leg always carries the same ID.
+func (s *State) ClientOrderId(role string) string {
+	return uuid.NewSHA1(uuid.MustParse(s.Id), []byte(role)).String()
+}
+
+// ExitQuantity is the position the exit legs protect: the filled entry, or
+// the full quantity when the entry is skipped.
+func (s *State) ExitQuantity() decimal.Decimal {
+	if s.Params.SkipEntry {
+		quantity, _ := decimal.NewFromString(s.Params.Quantity)
+		return quantity
+	}
+	return filled(s.Entry)
+}
+
+func filled(leg *Leg) decimal.Decimal {
+	if leg == nil {
+		return decimal.Zero
+	}
+	quantity, _ := decimal.NewFromString(leg.FilledQuantity)
+	return quantity
+}
+
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("cannot parse bracket state %s: %w", path, err)
+	}
+	if _, err := uuid.Parse(state.Id); err != nil {
+		return nil, fmt.Errorf("bracket state %s has an invalid id: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes through a temporary file and rename, so a crash mid-write
+// leaves the previous state intact.
+func Save(path string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode bracket state: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return fmt.Errorf("cannot create bracket state directory: %w", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("cannot write bracket state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("cannot write bracket state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,566 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bracket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/coinbase/prime-sdk-go/products"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+
+	// priceLookback bounds the candle request for the last traded price.
+	priceLookback = 10 * time.Minute
+)
+
+// Supervisor works one bracket from its saved state to completion.
+type Supervisor struct {
+	client  client.RestClient
+	path    string
+	state   *State
+	product *model.Product
+
+	NewContext   func() (context.Context, context.CancelFunc)
+	PollInterval time.Duration
+
+	// Log receives progress messages. It may be nil.
+	Log func(format string, args ...any)
+}
+
+func NewSupervisor(c client.RestClient, path string, state *State, product *model.Product, newContext func() (context.Context, context.CancelFunc)) *Supervisor {
+	return &Supervisor{
+		client:       c,
+		path:         path,
+		state:        state,
+		product:      product,
+		NewContext:   newContext,
+		PollInterval: defaultPollInterval,
+	}
+}
+
+func (s *Supervisor) State() *State {
+	return s.state
+}
+
+func (s *Supervisor) logf(format string, args ...any) {
+	if s.Log != nil {
+		s.Log(format, args...)
+	}
+}
+
+func (s *Supervisor) save() error {
+	s.state.UpdatedAt = time.Now()
+	return Save(s.path, s.state)
+}
+
+func (s *Supervisor) finish(status, reason string) error {
+	s.state.Status = status
+	s.state.Reason = reason
+	s.logf("%s: %s", status, reason)
+	return s.save()
+}
+
+// Run supervises the bracket until it completes or ctx is cancelled. On
+// cancellation the state stays running and the orders stay as they are, so
+// the next run resumes where this one stopped.
+func (s *Supervisor) Run(ctx context.Context) error {
+	for s.state.Status == StatusRunning {
+		var err error
+		switch s.state.Phase {
+		case PhaseEntry:
+			err = s.entry(ctx)
+		case PhaseProtect:
+			err = s.protect(ctx)
+		case PhaseStop:
+			err = s.stop(ctx)
+		default:
+			err = fmt.Errorf("bracket %s has an unknown phase %q", s.state.Id, s.state.Phase)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entry sends the entry order and waits for it to end. The filled quantity,
+// even if partial, becomes the position the exit legs protect.
+func (s *Supervisor) entry(ctx context.Context) error {
+	p := s.state.Params
+	if err := s.place(&s.state.Entry, RoleEntry, p.Side, p.Quantity, p.EntryPrice); err != nil {
+		return err
+	}
+
+	leg := s.state.Entry
+	cancelled := false
+	for !legDone(leg) {
+		if err := s.sleep(ctx); err != nil {
+			return err
+		}
+		if err := s.refresh(leg); err != nil {
+			s.logf("%s: %v", leg.Role, err)
+			continue
+		}
+		if !legDone(leg) && !cancelled && p.EntryTimeout > 0 && time.Since(leg.CreatedAt) >= p.EntryTimeout {
+			s.logf("entry still working after %s, cancelling the remainder", p.EntryTimeout)
+			if err := s.cancel(leg); err != nil {
+				s.logf("%s: %v", leg.Role, err)
+			} else {
+				cancelled = true
+			}
+		}
+	}
+	if err := s.save(); err != nil {
+		return err
+	}
+
+	quantity := filled(leg)
+	if !quantity.IsPositive() {
+		return s.finish(StatusCompleted, fmt.Sprintf("entry ended %s without a fill", leg.Status))
+	}
+	s.logf("entry %s, filled %s at %s", leg.Status, quantity.String(), orZero(leg.AverageFilledPrice))
+
+	s.state.Phase = PhaseProtect
+	return s.save()
+}
+
+// protect rests the take profit and watches the last price for the stop
+// trigger. A filled take profit ends the bracket, so the stop is never sent.
+func (s *Supervisor) protect(ctx context.Context) error {
+	p := s.state.Params
+	exit := s.state.ExitQuantity()
+
+	if p.TakeProfitPrice != "" {
+		if err := s.place(&s.state.TakeProfit, RoleTakeProfit, p.ExitSide(), exit.String(), p.TakeProfitPrice); err != nil {
+			return err
+		}
+	}
+
+	stop, _ := decimal.NewFromString(p.StopPrice)
+	for {
+		if leg := s.state.TakeProfit; leg != nil && !legDone(leg) {
+			if err := s.refresh(leg); err != nil {
+				s.logf("%s: %v", leg.Role, err)
+			} else if legDone(leg) {
+				if err := s.save(); err != nil {
+					return err
+				}
+				if !filled(leg).LessThan(exit) {
+					return s.finish(StatusCompleted, fmt.Sprintf("take profit filled %s at %s", filled(leg).String(), orZero(leg.AverageFilledPrice)))
+				}
+				s.logf("take profit ended %s with %s filled", leg.Status, orZero(leg.FilledQuantity))
+				if p.StopPrice == "" {
+					return s.finish(StatusCompleted, fmt.Sprintf("take profit ended %s with %s of %s filled", leg.Status, orZero(leg.FilledQuantity), exit.String()))
+				}
+			}
+		}
+
+		if p.StopPrice != "" {
+			price, err := s.lastPrice()
+			if err != nil {
+				s.logf("%v", err)
+			} else if s.triggered(price, stop) {
+				s.logf("last price %s crossed the stop at %s", price.String(), stop.String())
+				s.state.Phase = PhaseStop
+				now := time.Now()
+				s.state.TriggeredAt = &now
+				s.state.TriggerPrice = price.String()
+				return s.save()
+			}
+		}
+
+		if err := s.sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// triggered reports whether price has reached the stop: at or below it when
+// the exits sell, at or above it when they buy.
+func (s *Supervisor) triggered(price, stop decimal.Decimal) bool {
+	if s.state.Params.ExitSide() == "SELL" {
+		return price.LessThanOrEqual(stop)
+	}
+	return price.GreaterThanOrEqual(stop)
+}
+
+// stop cancels the take profit, then sends the stop order for whatever the
+// take profit did not fill.
+func (s *Supervisor) stop(ctx context.Context) error {
+	p := s.state.Params
+
+	if leg := s.state.TakeProfit; leg != nil && !legDone(leg) {
+		if err := s.cancelAndSettle(ctx, leg); err != nil {
+			return err
+		}
+	}
+
+	remaining := s.roundSize(s.state.ExitQuantity().Sub(filled(s.state.TakeProfit)))
+	if !remaining.IsPositive() {
+		return s.finish(StatusCompleted, fmt.Sprintf("take profit filled %s at %s before the stop was sent", orZero(s.state.TakeProfit.FilledQuantity), orZero(s.state.TakeProfit.AverageFilledPrice)))
+	}
+
+	if err := s.place(&s.state.StopLoss, RoleStopLoss, p.ExitSide(), remaining.String(), p.StopLimitPrice); err != nil {
+		return err
+	}
+
+	leg := s.state.StopLoss
+	for !legDone(leg) {
+		if err := s.sleep(ctx); err != nil {
+			return err
+		}
+		if err := s.refresh(leg); err != nil {
+			s.logf("%s: %v", leg.Role, err)
+		}
+	}
+
+	quantity, _ := decimal.NewFromString(leg.Quantity)
+	if filled(leg).LessThan(quantity) {
+		return s.finish(StatusFailed, fmt.Sprintf("stop loss ended %s with %s of %s filled", leg.Status, orZero(leg.FilledQuantity), leg.Quantity))
+	}
+	return s.finish(StatusCompleted, fmt.Sprintf("stop loss filled %s at %s", leg.FilledQuantity, orZero(leg.AverageFilledPrice)))
+}
+
+// Cancel stops a bracket that no run is supervising. Working legs are
+// cancelled; a filled entry is left unprotected.
+func (s *Supervisor) Cancel(ctx context.Context) error {
+	if s.state.Status != StatusRunning {
+		return fmt.Errorf("bracket %s is already %s", s.state.Id, s.state.Status)
+	}
+	for _, leg := range []*Leg{s.state.Entry, s.state.TakeProfit, s.state.StopLoss} {
+		if leg == nil || legDone(leg) {
+			continue
+		}
+		if err := s.cancelAndSettle(ctx, leg); err != nil {
+			return err
+		}
+	}
+
+	reason := "cancelled by request"
+	if filled(s.state.Entry).IsPositive() {
+		reason += fmt.Sprintf("; the %s entered is no longer protected", filled(s.state.Entry).Sub(filled(s.state.TakeProfit)).Sub(filled(s.state.StopLoss)).String())
+	}
+	return s.finish(StatusCancelled, reason)
+}
+
+// cancelAndSettle cancels a working leg and waits until the API reports it
+// done, so its final fill is known. A pending leg that never reached the API
+// is marked cancelled without sending it.
+func (s *Supervisor) cancelAndSettle(ctx context.Context, leg *Leg) error {
+	if leg.OrderId == "" {
+		found, err := s.findByClientOrderId(leg)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			leg.Status = "CANCELLED"
+			leg.Error = "never reached the API"
+			return s.save()
+		}
+		applyOrder(leg, found)
+		if legDone(leg) {
+			return s.save()
+		}
+	}
+
+	// A failed cancel is sent again on each poll until one is accepted or the
+	// leg is done, so a transient error cannot leave it working.
+	cancelled := false
+	for {
+		if !cancelled {
+			if err := s.cancel(leg); err != nil {
+				s.logf("%s: %v", leg.Role, err)
+			} else {
+				cancelled = true
+			}
+		}
+		if err := s.refresh(leg); err != nil {
+			s.logf("%s: %v", leg.Role, err)
+		} else if legDone(leg) {
+			s.logf("%s %s, filled %s", leg.Role, leg.Status, orZero(leg.FilledQuantity))
+			return s.save()
+		}
+		if err := s.sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// place makes sure the leg in slot has reached the API. A new leg is saved
+// as pending before it is sent; a pending leg from an earlier run is looked
+// up by its client order ID and only sent if the API has no record of it.
+func (s *Supervisor) place(slot **Leg, role, side, quantity, limitPrice string) error {
+	if *slot == nil {
+		leg := &Leg{
+			Role:          role,
+			ClientOrderId: s.state.ClientOrderId(role),
+			Side:          side,
+			Type:          utils.OrderTypeMarket,
+			Quantity:      quantity,
+			LimitPrice:    limitPrice,
+			Status:        LegPending,
+			CreatedAt:     time.Now(),
+		}
+		if limitPrice != "" {
+			leg.Type = utils.OrderTypeLimit
+		}
+		*slot = leg
+		if err := s.save(); err != nil {
+			return err
+		}
+		return s.submit(leg)
+	}
+
+	leg := *slot
+	if leg.OrderId != "" {
+		return nil
+	}
+
+	found, err := s.findByClientOrderId(leg)
+	if err != nil {
+		return err
+	}
+	if found != nil {
+		s.logf("%s: found %s from the earlier run", leg.Role, found.Id)
+		applyOrder(leg, found)
+		return s.save()
+	}
+	s.logf("%s: not found, sending again with the same client order ID", leg.Role)
+	return s.submit(leg)
+}
+
+func (s *Supervisor) submit(leg *Leg) error {
+	p := s.state.Params
+	order := &model.Order{
+		PortfolioId:   p.PortfolioId,
+		ProductId:     p.ProductId,
+		Side:          leg.Side,
+		Type:          leg.Type,
+		ClientOrderId: leg.ClientOrderId,
+		BaseQuantity:  leg.Quantity,
+		LimitPrice:    leg.LimitPrice,
+	}
+	if leg.Type == utils.OrderTypeLimit {
+		order.TimeInForce = utils.TifGoodUntilCancelled
+	}
+
+	reqCtx, cancel := s.NewContext()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(s.client).CreateOrder(reqCtx, &orders.CreateOrderRequest{Order: order})
+	if err != nil {
+		// The request may have reached the API, so the leg stays pending and
+		// is looked up on the next run.
+		leg.Error = err.Error()
+		if saveErr := s.save(); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		return fmt.Errorf("cannot create %s order: %w", leg.Role, err)
+	}
+
+	leg.OrderId = response.OrderId
+	leg.Status = LegSubmitted
+	leg.Error = ""
+	s.logf("%s: %s %s %s at %s (%s)", leg.Role, leg.Side, leg.Quantity, p.ProductId, priceLabel(leg.LimitPrice), leg.OrderId)
+	return s.save()
+}
+
+func (s *Supervisor) refresh(leg *Leg) error {
+	reqCtx, cancel := s.NewContext()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(s.client).GetOrder(reqCtx, &orders.GetOrderRequest{
+		PortfolioId: s.state.Params.PortfolioId,
+		OrderId:     leg.OrderId,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot get order %s: %w", leg.OrderId, err)
+	}
+	if response.Order == nil {
+		return fmt.Errorf("order %s not returned", leg.OrderId)
+	}
+	applyOrder(leg, response.Order)
+	return nil
+}
+
+func (s *Supervisor) cancel(leg *Leg) error {
+	reqCtx, cancel := s.NewContext()
+	defer cancel()
+
+	if _, err := orders.NewOrdersService(s.client).CancelOrder(reqCtx, &orders.CancelOrderRequest{
+		PortfolioId: s.state.Params.PortfolioId,
+		OrderId:     leg.OrderId,
+	}); err != nil {
+		return fmt.Errorf("cannot cancel order %s: %w", leg.OrderId, err)
+	}
+	s.logf("%s: cancel requested", leg.Role)
+	return nil
+}
+
+func (s *Supervisor) findByClientOrderId(leg *Leg) (*model.Order, error) {
+	p := s.state.Params
+	svc := orders.NewOrdersService(s.client)
+
+	pagination := &model.PaginationParams{Limit: 100}
+	for {
+		reqCtx, cancel := s.NewContext()
+		response, err := svc.ListOrders(reqCtx, &orders.ListOrdersRequest{
+			PortfolioId: p.PortfolioId,
+			ProductIds:  []string{p.ProductId},
+			OrderSide:   leg.Side,
+			Start:       leg.CreatedAt.Add(-time.Minute),
+			End:         time.Now().Add(time.Minute),
+			Pagination:  pagination,
+		})
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot look up %s order: %w", leg.Role, err)
+		}
+
+		for _, o := range response.Orders {
+			if o.ClientOrderId == leg.ClientOrderId {
+				return o, nil
+			}
+		}
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		pagination = &model.PaginationParams{Limit: pagination.Limit, Cursor: response.Pagination.NextCursor}
+	}
+
+	reqCtx, cancel := s.NewContext()
+	defer cancel()
+	open, err := svc.ListOpenOrders(reqCtx, &orders.ListOpenOrdersRequest{
+		PortfolioId: p.PortfolioId,
+		ProductIds:  []string{p.ProductId},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot look up %s order: %w", leg.Role, err)
+	}
+	for _, o := range open.Orders {
+		if o.ClientOrderId == leg.ClientOrderId {
+			return o, nil
+		}
+	}
+	return nil, nil
+}
+
+// lastPrice returns the close of the most recent one-minute candle.
+func (s *Supervisor) lastPrice() (decimal.Decimal, error) {
+	p := s.state.Params
+	reqCtx, cancel := s.NewContext()
+	defer cancel()
+
+	now := time.Now()
+	response, err := products.NewProductsService(s.client).GetProductCandles(reqCtx, &products.GetProductCandlesRequest{
+		PortfolioId: p.PortfolioId,
+		ProductId:   p.ProductId,
+		StartTime:   now.Add(-priceLookback),
+		EndTime:     now,
+		Granularity: model.CandleGranularityOneMinute,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("cannot get candles for %s: %w", p.ProductId, err)
+	}
+
+	var latest *model.Candle
+	var latestAt time.Time
+	for _, candle := range response.Candles {
+		t, err := time.Parse(time.RFC3339, candle.Timestamp)
+		if err != nil {
+			continue
+		}
+		if latest == nil || t.After(latestAt) {
+			latest, latestAt = candle, t
+		}
+	}
+	if latest == nil {
+		return decimal.Zero, fmt.Errorf("no candles for %s in the last %s", p.ProductId, priceLookback)
+	}
+
+	price, err := decimal.NewFromString(latest.Close)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid close %q for %s: %w", latest.Close, p.ProductId, err)
+	}
+	return price, nil
+}
+
+func (s *Supervisor) roundSize(size decimal.Decimal) decimal.Decimal {
+	if s.product == nil {
+		return size
+	}
+	increment, err := decimal.NewFromString(s.product.BaseIncrement)
+	if err != nil || !increment.IsPositive() {
+		return size
+	}
+	return size.Div(increment).Floor().Mul(increment)
+}
+
+func (s *Supervisor) sleep(ctx context.Context) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func legDone(leg *Leg) bool {
+	if leg.Status == LegPending || leg.Status == LegSubmitted {
+		return false
+	}
+	terminal, _ := utils.ClassifyStatus(leg.Status)
+	return terminal
+}
+
+func applyOrder(leg *Leg, o *model.Order) {
+	leg.OrderId = o.Id
+	leg.Status = o.Status
+	leg.FilledQuantity = o.FilledQuantity
+	leg.FilledValue = o.FilledValue
+	leg.AverageFilledPrice = o.AverageFilledPrice
+}
+
+func priceLabel(limitPrice string) string {
+	if limitPrice == "" {
+		return "market"
+	}
+	return limitPrice
+}
+
+func orZero(value string) string {
+	if value == "" {
+		return "0"
+	}
+	return value
+}
@@ -0,0 +1,278 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package bulkorders
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/shopspring/decimal"
+)
+
+// ProductTotal aggregates the previews of one product. Notional and fees are
+// in the product's quote currency.
+type ProductTotal struct {
+	ProductId    string `json:"product_id"`
+	Orders       int    `json:"orders"`
+	BuyQuantity  string `json:"buy_base_quantity"`
+	SellQuantity string `json:"sell_base_quantity"`
+	Notional     string `json:"notional"`
+	Fees         string `json:"fees"`
+
+	// Unpriced counts previews that returned neither a value nor a price,
+	// so their notional is missing from the total.
+	Unpriced int `json:"unpriced,omitempty"`
+}
+
+type CurrencyTotal struct {
+	Currency string `json:"currency"`
+	Notional string `json:"notional"`
+	Fees     string `json:"fees"`
+}
+
+type Summary struct {
+	Orders     int              `json:"orders"`
+	Products   []*ProductTotal  `json:"products"`
+	Currencies []*CurrencyTotal `json:"currencies"`
+}
+
+// CheckProducts validates every row's sizes and limit price against its
+// product's increments and limits.
+func CheckProducts(c client.RestClient, portfolioId string, rows []*Row) []error {
+	products := map[string]*model.Product{}
+	failed := map[string]bool{}
+
+	var errs []error
+	for _, row := range rows {
+		o := row.Order
+		if failed[o.ProductId] {
+			continue
+		}
+		product, ok := products[o.ProductId]
+		if !ok {
+			var err error
+			product, err = utils.GetProduct(c, portfolioId, o.ProductId)
+			if err != nil {
+				failed[o.ProductId] = true
+				errs = append(errs, &RowError{row.Line, err})
+				continue
+			}
+			products[o.ProductId] = product
+		}
+		if err := utils.ValidateProductAmounts(product, o.BaseQuantity, o.QuoteValue, o.LimitPrice); err != nil {
+			errs = append(errs, &RowError{row.Line, err})
+		}
+	}
+	return errs
+}
+
+// Preview calls the order preview endpoint for every row and keeps the
+// result on the row. No order is placed.
+func Preview(c client.RestClient, rows []*Row, newContext func() (context.Context, context.CancelFunc)) []error {
+	svc := orders.NewOrdersService(c)
+
+	var errs []error
+	for _, row := range rows {
+		ctx, cancel := newContext()
+		response, err := svc.CreateOrderPreview(ctx, &orders.CreateOrderRequest{Order: row.Order})
+		cancel()
+		if err != nil {
+			errs = append(errs, &RowError{row.Line, fmt.Errorf("cannot preview order: %w", err)})
+			continue
+		}
+		row.Preview = response.Order
+	}
+	return errs
+}
+
+// Summarize totals the previews per product and per quote currency.
+func Summarize(rows []*Row) *Summary {
+	type totals struct {
+		orders         int
+		buy, sell      decimal.Decimal
+		notional, fees decimal.Decimal
+		unpriced       int
+	}
+	byProduct := map[string]*totals{}
+	byCurrency := map[string]*totals{}
+
+	for _, row := range rows {
+		o := row.Order
+		t, ok := byProduct[o.ProductId]
+		if !ok {
+			t = &totals{}
+			byProduct[o.ProductId] = t
+		}
+		t.orders++
+
+		quantity := amount(o.BaseQuantity)
+		var notional, fees decimal.Decimal
+		priced := false
+		if p := row.Preview; p != nil {
+			if q := amount(p.BaseQuantity); q.IsPositive() {
+				quantity = q
+			}
+			notional, priced = estimateNotional(o, p, quantity)
+			fees = amount(p.Commission).Add(amount(p.ExchangeFee))
+		}
+
+		if o.Side == utils.OrderSideBuy {
+			t.buy = t.buy.Add(quantity)
+		} else {
+			t.sell = t.sell.Add(quantity)
+		}
+		if !priced {
+			t.unpriced++
+		}
+		t.notional = t.notional.Add(notional)
+		t.fees = t.fees.Add(fees)
+
+		currency := quoteCurrency(o.ProductId)
+		ct, ok := byCurrency[currency]
+		if !ok {
+			ct = &totals{}
+			byCurrency[currency] = ct
+		}
+		ct.notional = ct.notional.Add(notional)
+		ct.fees = ct.fees.Add(fees)
+	}
+
+	summary := &Summary{Orders: len(rows)}
+	for _, id := range sortedKeys(byProduct) {
+		t := byProduct[id]
+		summary.Products = append(summary.Products, &ProductTotal{
+			ProductId:    id,
+			Orders:       t.orders,
+			BuyQuantity:  t.buy.String(),
+			SellQuantity: t.sell.String(),
+			Notional:     t.notional.String(),
+			Fees:         t.fees.String(),
+			Unpriced:     t.unpriced,
+		})
+	}
+	for _, currency := range sortedKeys(byCurrency) {
+		t := byCurrency[currency]
+		summary.Currencies = append(summary.Currencies, &CurrencyTotal{
+			Currency: currency,
+			Notional: t.notional.String(),
+			Fees:     t.fees.String(),
+		})
+	}
+	return summary
+}
+
+// estimateNotional prefers the previewed quote value, then the previewed
+// average price, the limit price and finally the side of the book the order
+// would take.
+func estimateNotional(o, preview *model.Order, quantity decimal.Decimal) (decimal.Decimal, bool) {
+	if v := amount(preview.QuoteValue); v.IsPositive() {
+		return v, true
+	}
+	if v := amount(o.QuoteValue); v.IsPositive() {
+		return v, true
+	}
+
+	book := preview.BestBid
+	if o.Side == utils.OrderSideBuy {
+		book = preview.BestAsk
+	}
+	for _, price := range []string{preview.AverageFilledPrice, o.LimitPrice, book} {
+		if p := amount(price); p.IsPositive() && quantity.IsPositive() {
+			return p.Mul(quantity), true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// Submit sends every row not already recorded in submitted, which maps
+// client order IDs to order IDs from an earlier results file. Rows already
+// submitted are written to results before anything is sent, so results may
+// replace that earlier file without losing them if the run is interrupted.
+// Each new outcome is written as soon as it is known. It stops early if ctx
+// is cancelled, leaving the remaining rows unsent.
+func Submit(
+	ctx context.Context,
+	c client.RestClient,
+	rows []*Row,
+	submitted map[string]string,
+	results *ResultsWriter,
+	newContext func() (context.Context, context.CancelFunc),
+) error {
+	svc := orders.NewOrdersService(c)
+
+	var pending []*Row
+	for _, row := range rows {
+		orderId, ok := submitted[row.Order.ClientOrderId]
+		if !ok {
+			pending = append(pending, row)
+			continue
+		}
+		row.Result = &Result{Status: StatusAlreadySubmitted, OrderId: orderId}
+		if err := results.Write(row); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range pending {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		reqCtx, cancel := newContext()
+		response, err := svc.CreateOrder(reqCtx, &orders.CreateOrderRequest{Order: row.Order})
+		cancel()
+		if err != nil {
+			row.Result = &Result{Status: StatusFailed, Error: err.Error()}
+		} else {
+			row.Result = &Result{Status: StatusSubmitted, OrderId: response.OrderId}
+		}
+
+		if err := results.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func amount(value string) decimal.Decimal {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
+
+func quoteCurrency(productId string) string {
+	if i := strings.LastIndex(productId, "-"); i >= 0 {
+		return productId[i+1:]
+	}
+	return productId
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
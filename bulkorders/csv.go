@@ -0,0 +1,316 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package bulkorders validates, previews and submits a batch of orders read
+// from CSV.
+package bulkorders
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/google/uuid"
+)
+
+// Input columns. Only product_id, side and type are required; the header
+// row may list the columns in any order.
+const (
+	ColumnProductId     = "product_id"
+	ColumnSide          = "side"
+	ColumnType          = "type"
+	ColumnBaseQuantity  = "base_quantity"
+	ColumnQuoteValue    = "quote_value"
+	ColumnLimitPrice    = "limit_price"
+	ColumnTimeInForce   = "time_in_force"
+	ColumnStartTime     = "start_time"
+	ColumnExpiryTime    = "expiry_time"
+	ColumnClientOrderId = "client_order_id"
+)
+
+const (
+	StatusSubmitted        = "submitted"
+	StatusFailed           = "failed"
+	StatusAlreadySubmitted = "already_submitted"
+)
+
+var (
+	Columns         = []string{ColumnProductId, ColumnSide, ColumnType, ColumnBaseQuantity, ColumnQuoteValue, ColumnLimitPrice, ColumnTimeInForce, ColumnStartTime, ColumnExpiryTime, ColumnClientOrderId}
+	requiredColumns = []string{ColumnProductId, ColumnSide, ColumnType}
+
+	resultColumns = []string{"line", ColumnClientOrderId, ColumnProductId, ColumnSide, ColumnType, ColumnBaseQuantity, ColumnQuoteValue, ColumnLimitPrice, "status", "order_id", "error"}
+)
+
+// Row is one order from the input file. Line is the 1-based line number in
+// the file, counting the header.
+type Row struct {
+	Line  int
+	Order *model.Order
+
+	Preview *model.Order
+	Result  *Result
+}
+
+type Result struct {
+	Status  string
+	OrderId string
+	Error   string
+}
+
+// RowError ties a problem to the input line it came from.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads the orders in data. Rows without a client_order_id get one
+// derived from the batch ID and the row's own values, so submitting the same
+// batch again reuses the same IDs even if other rows were edited in between.
+func Parse(data []byte, portfolioId, batchId string) ([]*Row, error) {
+	reader := csv.NewReader(strings.NewReader(string(data)))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("orders file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read orders header: %w", err)
+	}
+
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !contains(Columns, name) {
+			return nil, fmt.Errorf("unknown column %q (supported: %s)", name, strings.Join(Columns, ", "))
+		}
+		if _, ok := index[name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		index[name] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("missing required column %q", name)
+		}
+	}
+
+	namespace := uuid.NewSHA1(uuid.NameSpaceOID, []byte(batchId))
+	occurrences := map[string]int{}
+
+	var rows []*Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read orders file: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		get := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		order := &model.Order{
+			PortfolioId:   portfolioId,
+			ProductId:     get(ColumnProductId),
+			Side:          get(ColumnSide),
+			Type:          get(ColumnType),
+			ClientOrderId: get(ColumnClientOrderId),
+			BaseQuantity:  get(ColumnBaseQuantity),
+			QuoteValue:    get(ColumnQuoteValue),
+			LimitPrice:    get(ColumnLimitPrice),
+			TimeInForce:   get(ColumnTimeInForce),
+			StartTime:     get(ColumnStartTime),
+			ExpiryTime:    get(ColumnExpiryTime),
+		}
+		if order.ClientOrderId == "" {
+			// Identical rows are told apart by how many came before them.
+			key := strings.Join([]string{order.ProductId, order.Side, order.Type, order.BaseQuantity, order.QuoteValue,
+				order.LimitPrice, order.TimeInForce, order.StartTime, order.ExpiryTime}, "|")
+			occurrences[key]++
+			key += "#" + strconv.Itoa(occurrences[key])
+			order.ClientOrderId = uuid.NewSHA1(namespace, []byte(key)).String()
+		}
+
+		rows = append(rows, &Row{Line: line, Order: order})
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("orders file has no rows")
+	}
+	return rows, nil
+}
+
+// Validate applies the "orders create" checks to every row and returns all
+// problems found, not just the first.
+func Validate(rows []*Row) []error {
+	var errs []error
+	seen := map[string]int{}
+	for _, row := range rows {
+		o := row.Order
+		if o.ProductId == "" {
+			errs = append(errs, &RowError{row.Line, fmt.Errorf("%s is required", ColumnProductId)})
+			continue
+		}
+		for _, err := range []error{
+			utils.ValidateSideValue(o.Side),
+			utils.ValidateOrderTypeValue(o.Type, o.LimitPrice),
+			utils.ValidateTimeInForceValue(o.TimeInForce),
+			utils.ValidateQuantityValues(o.BaseQuantity, o.QuoteValue),
+			validateAmount(utils.BaseQuantityFlag, o.BaseQuantity),
+			validateAmount(utils.QuoteValueFlag, o.QuoteValue),
+			validateAmount(utils.LimitPriceFlag, o.LimitPrice),
+		} {
+			if err != nil {
+				errs = append(errs, &RowError{row.Line, err})
+			}
+		}
+		if first, ok := seen[o.ClientOrderId]; ok {
+			errs = append(errs, &RowError{row.Line, fmt.Errorf("client order ID %s is also used on line %d", o.ClientOrderId, first)})
+		}
+		seen[o.ClientOrderId] = row.Line
+	}
+	return errs
+}
+
+func validateAmount(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := utils.ParseAmount(name, value)
+	return err
+}
+
+// LoadSubmitted reads a results file from an earlier run and returns the
+// order ID of every row it recorded as submitted, keyed by client order ID.
+// A missing file yields an empty map.
+func LoadSubmitted(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read results %s: %w", path, err)
+	}
+	defer f.Close()
+
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse results %s: %w", path, err)
+	}
+
+	submitted := map[string]string{}
+	if len(records) == 0 {
+		return submitted, nil
+	}
+	index := map[string]int{}
+	for i, name := range records[0] {
+		index[name] = i
+	}
+	idCol, okId := index[ColumnClientOrderId]
+	statusCol, okStatus := index["status"]
+	orderCol, okOrder := index["order_id"]
+	if !okId || !okStatus || !okOrder {
+		return nil, fmt.Errorf("%s is not a bulk-create results file", path)
+	}
+	for _, record := range records[1:] {
+		status := record[statusCol]
+		if status == StatusSubmitted || status == StatusAlreadySubmitted {
+			submitted[record[idCol]] = record[orderCol]
+		}
+	}
+	return submitted, nil
+}
+
+// ResultsWriter writes one results line per row and flushes it immediately,
+// so the file is complete up to the last order sent even after a crash.
+type ResultsWriter struct {
+	f *os.File
+	w *csv.Writer
+}
+
+func CreateResults(path string) (*ResultsWriter, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create results %s: %w", path, err)
+	}
+	rw := &ResultsWriter{f: f, w: csv.NewWriter(f)}
+	if err := rw.write(resultColumns); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return rw, nil
+}
+
+func (rw *ResultsWriter) Write(row *Row) error {
+	o := row.Order
+	return rw.write([]string{
+		strconv.Itoa(row.Line),
+		o.ClientOrderId,
+		o.ProductId,
+		o.Side,
+		o.Type,
+		o.BaseQuantity,
+		o.QuoteValue,
+		o.LimitPrice,
+		row.Result.Status,
+		row.Result.OrderId,
+		row.Result.Error,
+	})
+}
+
+func (rw *ResultsWriter) write(record []string) error {
+	if err := rw.w.Write(record); err != nil {
+		return fmt.Errorf("cannot write results: %w", err)
+	}
+	rw.w.Flush()
+	if err := rw.w.Error(); err != nil {
+		return fmt.Errorf("cannot write results: %w", err)
+	}
+	return nil
+}
+
+func (rw *ResultsWriter) Close() error {
+	return rw.f.Close()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package advancedtransfers
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/coinbase-samples/prime-cli/advtransfer"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/advancedtransfers"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fileFlag   = "file"
+	dryRunFlag = "dry-run"
+	yesFlag    = "yes"
+
+	outcomeCreated     = "created"
+	outcomeFailed      = "failed"
+	outcomeWouldCreate = "would_create"
+)
+
+type batchOutcome struct {
+	ReferenceId        string `json:"reference_id"`
+	Lines              []int  `json:"lines"`
+	FundMovements      int    `json:"fund_movements"`
+	Outcome            string `json:"outcome"`
+	AdvancedTransferId string `json:"advanced_transfer_id,omitempty"`
+	State              string `json:"state,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+var batchCreateAdvancedTransfersCmd = &cobra.Command{
+	Use:   "batch-create",
+	Short: "Create one blind-match transfer per reference ID in a settlement CSV",
+	Long: `Read a settlement CSV and create a blind-match advanced transfer for each
+reference_id, with one fund movement per row. Every row is validated before
+anything is sent. One JSON document is printed per transfer with its
+outcome. Fund movements without an ID get one derived from the reference ID,
+so resubmitting the file reuses the same IDs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		path := utils.GetFlagStringValue(cmd, fileFlag)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("cannot read settlement file: %w", err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool(dryRunFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", dryRunFlag, err)
+		}
+		yes, err := cmd.Flags().GetBool(yesFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", yesFlag, err)
+		}
+
+		cmd.SilenceUsage = true
+
+		settlements, errs := advtransfer.ParseSettlements(data)
+		if len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return fmt.Errorf("%s has %d problems; nothing was submitted", path, len(errs))
+		}
+
+		var movements []*advtransfer.FundMovement
+		for _, s := range settlements {
+			movements = append(movements, s.Manifest.FundMovements...)
+		}
+		if err := validatePrecision(cmd, client, movements); err != nil {
+			return err
+		}
+
+		if !dryRun && !yes {
+			confirmed, err := utils.Confirm(fmt.Sprintf("Create %d blind-match transfers with %d fund movements?", len(settlements), len(movements)))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("no transfers were submitted")
+			}
+		}
+
+		svc := advancedtransfers.NewAdvancedTransfersService(client)
+
+		failed := 0
+		for _, s := range settlements {
+			referenceId := s.Manifest.BlindMatch.ReferenceId
+			outcome := &batchOutcome{
+				ReferenceId:   referenceId,
+				Lines:         s.Lines,
+				FundMovements: len(s.Manifest.FundMovements),
+				Outcome:       outcomeWouldCreate,
+			}
+
+			if !dryRun {
+				ctx, cancel := utils.GetContextWithTimeout()
+				response, err := svc.CreateAdvancedTransfer(ctx, &advancedtransfers.CreateAdvancedTransferRequest{
+					PortfolioId:      portfolioId,
+					AdvancedTransfer: s.Manifest.Build(referenceId),
+				})
+				cancel()
+
+				if err != nil {
+					failed++
+					outcome.Outcome = outcomeFailed
+					outcome.Error = err.Error()
+				} else {
+					outcome.Outcome = outcomeCreated
+					if t := response.AdvancedTransfer; t != nil {
+						outcome.AdvancedTransferId = t.Id
+						outcome.State = string(t.State)
+					}
+				}
+			}
+
+			jsonResponse, err := utils.FormatResponseAsJson(cmd, outcome)
+			if err != nil {
+				return err
+			}
+			fmt.Println(jsonResponse)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d transfers failed", failed, len(settlements))
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(batchCreateAdvancedTransfersCmd)
+
+	batchCreateAdvancedTransfersCmd.Flags().StringP(fileFlag, "f", "", "Settlement CSV with one row per fund movement (Required)")
+	batchCreateAdvancedTransfersCmd.Flags().Bool(dryRunFlag, false, "Validate the file and list the transfers without creating them")
+	batchCreateAdvancedTransfersCmd.Flags().Bool(yesFlag, false, "Create the transfers without asking for confirmation")
+	utils.AddPortfolioIdFlag(batchCreateAdvancedTransfersCmd)
+
+	batchCreateAdvancedTransfersCmd.MarkFlagRequired(fileFlag)
+}
@@ -18,9 +18,12 @@ package advancedtransfers
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/coinbase-samples/prime-cli/advtransfer"
 	"github.com/coinbase-samples/prime-cli/utils"
 	"github.com/coinbase/prime-sdk-go/advancedtransfers"
+	"github.com/coinbase/prime-sdk-go/client"
 	"github.com/coinbase/prime-sdk-go/model"
 	"github.com/spf13/cobra"
 )
@@ -40,8 +43,18 @@ const (
 	settlementDateFlag          = "settlement-date"
 	tradeDateFlag               = "trade-date"
 	settlementTimeFlag          = "settlement-time"
+	manifestFlag                = "manifest"
 )
 
+// movementFlags describe a single fund movement and are replaced by a
+// manifest.
+var movementFlags = []string{
+	utils.TransferTypeFlag, utils.AmountFlag, currencyFlag, fundMovementIdFlag,
+	sourceTypeFlag, sourceValueFlag, sourceAddressFlag, sourceAccountIdentifierFlag,
+	targetTypeFlag, targetValueFlag, targetAddressFlag, targetAccountIdentifierFlag,
+	referenceIdFlag, settlementDateFlag, tradeDateFlag, settlementTimeFlag,
+}
+
 var createAdvancedTransferCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Creates a new advanced transfer",
@@ -58,56 +71,14 @@ var createAdvancedTransferCmd = &cobra.Command{
 			return err
 		}
 
-		fundMovementId := utils.GetFlagStringValue(cmd, fundMovementIdFlag)
-		if fundMovementId == "" {
-			fundMovementId = utils.GetFlagStringValue(cmd, utils.IdempotencyKeyFlag)
-		}
-		if fundMovementId == "" {
-			fundMovementId = utils.NewUuidStr()
-		}
-
-		transfer := &model.AdvancedTransfer{
-			Type: model.AdvancedTransferType(utils.GetFlagStringValue(cmd, utils.TransferTypeFlag)),
-		}
-
-		movement := &model.FundMovement{
-			Id:       fundMovementId,
-			Currency: utils.GetFlagStringValue(cmd, currencyFlag),
-			Amount:   utils.GetFlagStringValue(cmd, utils.AmountFlag),
-		}
-
-		if source := buildTransferLocation(
-			utils.GetFlagStringValue(cmd, sourceTypeFlag),
-			utils.GetFlagStringValue(cmd, sourceValueFlag),
-			utils.GetFlagStringValue(cmd, sourceAddressFlag),
-			utils.GetFlagStringValue(cmd, sourceAccountIdentifierFlag),
-		); source != nil {
-			movement.Source = source
+		var transfer *model.AdvancedTransfer
+		if path := utils.GetFlagStringValue(cmd, manifestFlag); path != "" {
+			transfer, err = transferFromManifest(cmd, client, path)
+		} else {
+			transfer, err = transferFromFlags(cmd, client)
 		}
-
-		if target := buildTransferLocation(
-			utils.GetFlagStringValue(cmd, targetTypeFlag),
-			utils.GetFlagStringValue(cmd, targetValueFlag),
-			utils.GetFlagStringValue(cmd, targetAddressFlag),
-			utils.GetFlagStringValue(cmd, targetAccountIdentifierFlag),
-		); target != nil {
-			movement.Target = target
-		}
-
-		transfer.FundMovements = []*model.FundMovement{movement}
-
-		referenceId := utils.GetFlagStringValue(cmd, referenceIdFlag)
-		settlementDate := utils.GetFlagStringValue(cmd, settlementDateFlag)
-		tradeDate := utils.GetFlagStringValue(cmd, tradeDateFlag)
-		settlementTime := utils.GetFlagStringValue(cmd, settlementTimeFlag)
-
-		if referenceId != "" || settlementDate != "" || tradeDate != "" || settlementTime != "" {
-			transfer.BlindMatchMetadata = &model.BlindMatchMetadata{
-				ReferenceId:    referenceId,
-				SettlementDate: settlementDate,
-				TradeDate:      tradeDate,
-				SettlementTime: settlementTime,
-			}
+		if err != nil {
+			return err
 		}
 
 		request := &advancedtransfers.CreateAdvancedTransferRequest{
@@ -130,10 +101,137 @@ var createAdvancedTransferCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindAdvancedTransfer, portfolioId, advancedTransferId(response))
 	},
 }
 
+func transferFromFlags(cmd *cobra.Command, c client.RestClient) (*model.AdvancedTransfer, error) {
+	if err := utils.ValidateAssetAmount(cmd, c, utils.AmountFlag, utils.GetFlagStringValue(cmd, currencyFlag)); err != nil {
+		return nil, err
+	}
+
+	fundMovementId := utils.GetFlagStringValue(cmd, fundMovementIdFlag)
+	if fundMovementId == "" {
+		fundMovementId = utils.GetFlagStringValue(cmd, utils.IdempotencyKeyFlag)
+	}
+	if fundMovementId == "" {
+		fundMovementId = utils.NewUuidStr()
+	}
+
+	transfer := &model.AdvancedTransfer{
+		Type: model.AdvancedTransferType(utils.GetFlagStringValue(cmd, utils.TransferTypeFlag)),
+	}
+
+	movement := &model.FundMovement{
+		Id:       fundMovementId,
+		Currency: utils.GetFlagStringValue(cmd, currencyFlag),
+		Amount:   utils.GetFlagStringValue(cmd, utils.AmountFlag),
+	}
+
+	if source := buildTransferLocation(
+		utils.GetFlagStringValue(cmd, sourceTypeFlag),
+		utils.GetFlagStringValue(cmd, sourceValueFlag),
+		utils.GetFlagStringValue(cmd, sourceAddressFlag),
+		utils.GetFlagStringValue(cmd, sourceAccountIdentifierFlag),
+	); source != nil {
+		movement.Source = source
+	}
+
+	if target := buildTransferLocation(
+		utils.GetFlagStringValue(cmd, targetTypeFlag),
+		utils.GetFlagStringValue(cmd, targetValueFlag),
+		utils.GetFlagStringValue(cmd, targetAddressFlag),
+		utils.GetFlagStringValue(cmd, targetAccountIdentifierFlag),
+	); target != nil {
+		movement.Target = target
+	}
+
+	transfer.FundMovements = []*model.FundMovement{movement}
+
+	referenceId := utils.GetFlagStringValue(cmd, referenceIdFlag)
+	settlementDate := utils.GetFlagStringValue(cmd, settlementDateFlag)
+	tradeDate := utils.GetFlagStringValue(cmd, tradeDateFlag)
+	settlementTime := utils.GetFlagStringValue(cmd, settlementTimeFlag)
+
+	if referenceId != "" || settlementDate != "" || tradeDate != "" || settlementTime != "" {
+		transfer.BlindMatchMetadata = &model.BlindMatchMetadata{
+			ReferenceId:    referenceId,
+			SettlementDate: settlementDate,
+			TradeDate:      tradeDate,
+			SettlementTime: settlementTime,
+		}
+	}
+	return transfer, nil
+}
+
+// transferFromManifest loads and validates a manifest. Fund movements
+// without an ID get one derived from --idempotency-key, or else from the
+// blind match reference ID, so resubmitting a manifest reuses its IDs.
+func transferFromManifest(cmd *cobra.Command, c client.RestClient, path string) (*model.AdvancedTransfer, error) {
+	for _, name := range movementFlags {
+		if cmd.Flags().Changed(name) {
+			return nil, fmt.Errorf("--%s cannot be combined with --%s; set it in the manifest", name, manifestFlag)
+		}
+	}
+
+	m, err := advtransfer.LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transfer manifest %s: %w", path, err)
+	}
+	if err := validatePrecision(cmd, c, m.FundMovements); err != nil {
+		return nil, err
+	}
+
+	seed := utils.GetFlagStringValue(cmd, utils.IdempotencyKeyFlag)
+	if seed == "" && m.BlindMatch != nil {
+		seed = m.BlindMatch.ReferenceId
+	}
+	if seed == "" {
+		seed = utils.NewUuidStr()
+	}
+	return m.Build(seed), nil
+}
+
+// validatePrecision checks each amount against its asset's decimal
+// precision, looking each currency up once. Like ValidateAssetAmount it is
+// skipped when no entity ID is available.
+func validatePrecision(cmd *cobra.Command, c client.RestClient, movements []*advtransfer.FundMovement) error {
+	entityId := utils.GetFlagStringValue(cmd, utils.EntityIdFlag)
+	if entityId == "" {
+		if creds := c.Credentials(); creds != nil {
+			entityId = creds.EntityId
+		}
+	}
+	if entityId == "" {
+		return nil
+	}
+
+	assets := map[string]*model.Asset{}
+	for i, fm := range movements {
+		symbol := strings.ToUpper(fm.Currency)
+		asset, ok := assets[symbol]
+		if !ok {
+			var err error
+			if asset, err = utils.GetAsset(c, entityId, symbol); err != nil {
+				return fmt.Errorf("fund movement %d: %w", i+1, err)
+			}
+			assets[symbol] = asset
+		}
+
+		amount, err := utils.ParseAmount(utils.AmountFlag, fm.Amount)
+		if err != nil {
+			return fmt.Errorf("fund movement %d: %w", i+1, err)
+		}
+		if err := utils.ValidatePrecision(utils.AmountFlag, amount, asset.DecimalPrecision); err != nil {
+			return fmt.Errorf("fund movement %d: %s: %w", i+1, asset.Symbol, err)
+		}
+	}
+	return nil
+}
+
 func buildTransferLocation(typeStr, value, address, accountIdentifier string) *model.TransferLocation {
 	if typeStr == "" && value == "" && address == "" && accountIdentifier == "" {
 		return nil
@@ -151,10 +249,12 @@ func init() {
 
 	utils.AddPortfolioIdFlag(createAdvancedTransferCmd)
 	utils.AddIdempotencyKeyFlag(createAdvancedTransferCmd)
+	utils.AddWaitFlags(createAdvancedTransferCmd)
 
-	createAdvancedTransferCmd.Flags().String(utils.TransferTypeFlag, "", "Advanced transfer type, e.g. ADVANCED_TRANSFER_TYPE_BLIND_MATCH (Required)")
-	createAdvancedTransferCmd.Flags().String(utils.AmountFlag, "", "Amount to transfer (Required)")
-	createAdvancedTransferCmd.Flags().String(currencyFlag, "", "Currency symbol for the transfer (Required)")
+	createAdvancedTransferCmd.Flags().StringP(manifestFlag, "f", "", "YAML or JSON manifest with the transfer type, blind match metadata and any number of fund movements")
+	createAdvancedTransferCmd.Flags().String(utils.TransferTypeFlag, "", "Advanced transfer type, e.g. ADVANCED_TRANSFER_TYPE_BLIND_MATCH (Required without --manifest)")
+	createAdvancedTransferCmd.Flags().String(utils.AmountFlag, "", "Amount to transfer (Required without --manifest)")
+	createAdvancedTransferCmd.Flags().String(currencyFlag, "", "Currency symbol for the transfer (Required without --manifest)")
 
 	createAdvancedTransferCmd.Flags().String(fundMovementIdFlag, "", "Optional client-supplied fund movement ID. Defaults to --idempotency-key or a generated UUID")
 
@@ -173,7 +273,27 @@ func init() {
 	createAdvancedTransferCmd.Flags().String(tradeDateFlag, "", "Blind match trade date")
 	createAdvancedTransferCmd.Flags().String(settlementTimeFlag, "", "Blind match settlement time")
 
-	createAdvancedTransferCmd.MarkFlagRequired(utils.TransferTypeFlag)
-	createAdvancedTransferCmd.MarkFlagRequired(utils.AmountFlag)
-	createAdvancedTransferCmd.MarkFlagRequired(currencyFlag)
+	createAdvancedTransferCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if utils.GetFlagStringValue(cmd, manifestFlag) != "" {
+			return nil
+		}
+
+		var missing []string
+		for _, name := range []string{utils.TransferTypeFlag, utils.AmountFlag, currencyFlag} {
+			if !cmd.Flags().Changed(name) {
+				missing = append(missing, fmt.Sprintf("%q", name))
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("required flag(s) %s not set (or use --%s)", strings.Join(missing, ", "), manifestFlag)
+		}
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
+}
+
+func advancedTransferId(response *advancedtransfers.CreateAdvancedTransferResponse) string {
+	if response.AdvancedTransfer == nil {
+		return ""
+	}
+	return response.AdvancedTransfer.Id
 }
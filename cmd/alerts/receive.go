@@ -0,0 +1,65 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var receiveCmd = &cobra.Command{
+	Use:   "receive",
+	Short: "Print webhook payloads posted to a local address, for testing alert rules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen := utils.GetFlagStringValue(cmd, listenFlag)
+		fmt.Fprintf(os.Stderr, "receiving alerts on http://%s\n", listen)
+
+		return http.ListenAndServe(listen, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			var out bytes.Buffer
+			if err := json.Indent(&out, body, "", utils.JsonIndent); err != nil {
+				out.Reset()
+				out.Write(body)
+			}
+			fmt.Println(out.String())
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+	},
+}
+
+func init() {
+	Cmd.AddCommand(receiveCmd)
+
+	receiveCmd.Flags().String(listenFlag, "127.0.0.1:9000", "Address to listen on")
+}
@@ -0,0 +1,150 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/alerts"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Evaluate alert rules on an interval and notify a webhook on changes",
+	Long: `Evaluate the rules in an alerts file and post JSON to its webhook when an
+alert starts firing and again when it resolves. Active alerts are sent once;
+set a rule's repeat to re-send while it keeps firing.
+
+Rule types: ` + strings.Join(alerts.RuleTypes, ", ") + `
+
+Example alerts.yaml:
+
+  interval: 1m
+  state_file: alerts-state.json
+  webhook:
+    url: http://127.0.0.1:9000/alerts
+    headers:
+      Authorization: "Bearer ${ALERTS_TOKEN}"
+  rules:
+    - name: usd-trading-low
+      type: wallet_balance_below
+      wallet: "USD Trading"
+      symbol: USD
+      threshold: "250000"
+    - name: xm-utilization
+      type: cross_margin_utilization_above
+      threshold: "80"
+    - name: margin-summary
+      type: new_margin_call_summary
+    - name: fcm-margin-call
+      type: fcm_margin_call
+    - name: stuck-withdrawals
+      type: withdrawal_pending
+      older_than: 1h`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := cmd.Flags().GetString(configFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", configFlag, err)
+		}
+
+		cfg, err := alerts.LoadConfig(path)
+		if err != nil {
+			return err
+		}
+
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		// Entity rules report an error on each evaluation rather than
+		// stopping the daemon when no entity ID is available.
+		entityId, err := cmd.Flags().GetString(utils.EntityIdFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.EntityIdFlag, err)
+		}
+		if entityId == "" && client.Credentials() != nil {
+			entityId = client.Credentials().EntityId
+		}
+
+		cmd.SilenceUsage = true
+		engine, err := alerts.NewEngine(client, cfg, portfolioId, entityId, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		if utils.GetFlagBoolValue(cmd, onceFlag) {
+			results, err := engine.Evaluate(context.Background())
+			if err != nil {
+				return err
+			}
+			if err := utils.PrintJsonDocs(cmd, results); err != nil {
+				return err
+			}
+			for _, result := range results {
+				if result.Error != "" {
+					return fmt.Errorf("one or more rules failed")
+				}
+			}
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Fprintf(os.Stderr, "evaluating %d rules every %s\n", len(cfg.Rules), cfg.Interval)
+		engine.Run(ctx, logResults)
+		return nil
+	},
+}
+
+func logResults(results []*alerts.Result, err error) {
+	stamp := time.Now().Format(time.RFC3339)
+	for _, result := range results {
+		for _, sent := range result.Sent {
+			fmt.Fprintf(os.Stderr, "%s: %s: sent %s\n", stamp, result.Rule, sent)
+		}
+		if result.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s: %s: %s\n", stamp, result.Rule, result.Error)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", stamp, err)
+	}
+}
+
+func init() {
+	Cmd.AddCommand(runCmd)
+
+	runCmd.Flags().StringP(configFlag, "c", "", "Alerts file (required)")
+	runCmd.Flags().Bool(onceFlag, false, "Evaluate the rules once, print the results and exit")
+	utils.AddPortfolioIdFlag(runCmd)
+	utils.AddEntityIdFlag(runCmd)
+
+	runCmd.MarkFlagRequired(configFlag)
+}
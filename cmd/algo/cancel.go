@@ -0,0 +1,105 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/coinbase-samples/prime-cli/algo"
+	"github.com/coinbase-samples/prime-cli/runlock"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel",
+	Short: "Stop an algo and cancel its working child order",
+	Long: `Ask the run using --state to stop. A live run cancels its working child and
+exits; if no run is active, the open children are cancelled here and the algo
+is marked cancelled.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := getStatePath(cmd)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		// Holding the lock means no run is working the algo, so it is
+		// cancelled here. Otherwise the live run is asked to stop. The state
+		// is read only after the lock is settled so its status is current.
+		release, err := algo.Lock(path)
+		locked := errors.Is(err, runlock.ErrLocked)
+		if err != nil && !locked {
+			return err
+		}
+		if !locked {
+			defer release()
+		}
+
+		state, err := algo.Load(path)
+		if err != nil {
+			return fmt.Errorf("cannot load algo state: %w", err)
+		}
+		if state.Status != algo.StatusRunning {
+			return fmt.Errorf("algo %s is already %s", state.Id, state.Status)
+		}
+
+		if locked {
+			if err := algo.WriteUpdate(path, algo.Update{Cancel: true}); err != nil {
+				return err
+			}
+			jsonResponse, err := utils.FormatResponseAsJson(cmd, map[string]any{
+				"id":   state.Id,
+				"note": "cancel queued; the running process will stop after cancelling its working child",
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(jsonResponse)
+			return nil
+		}
+
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		engine := algo.NewEngine(client, path, state, nil, utils.GetContextWithTimeout)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		if err := engine.Cancel(ctx); err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, algo.BuildReport(engine.State(), false))
+		if err != nil {
+			return err
+		}
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(cancelCmd)
+
+	addStateFlag(cancelCmd)
+}
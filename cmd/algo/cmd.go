@@ -0,0 +1,55 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	stateFlag               = "state"
+	strategyFlag            = "strategy"
+	durationFlag            = "duration"
+	slicesFlag              = "slices"
+	clipSizeFlag            = "clip-size"
+	clipVarianceFlag        = "clip-variance"
+	participationCapFlag    = "participation-cap"
+	participationWindowFlag = "participation-window"
+	childTimeoutFlag        = "child-timeout"
+	pollIntervalFlag        = "poll-interval"
+	childrenFlag            = "children"
+	refreshFlag             = "refresh"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "algo",
+	Short: "Slice a parent order into child orders with client-side TWAP or iceberg execution",
+}
+
+func addStateFlag(cmd *cobra.Command) {
+	cmd.Flags().String(stateFlag, "", "Algo state file (Required)")
+	cmd.MarkFlagRequired(stateFlag)
+}
+
+func getStatePath(cmd *cobra.Command) (string, error) {
+	path, err := cmd.Flags().GetString(stateFlag)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve %s: %w", stateFlag, err)
+	}
+	return path, nil
+}
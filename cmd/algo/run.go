@@ -0,0 +1,228 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/algo"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/spf13/cobra"
+)
+
+// orderFlags describe a new run and are rejected when resuming, so a resumed
+// run cannot silently differ from the one that was started.
+var orderFlags = []string{
+	strategyFlag,
+	utils.ProductIdFlag,
+	utils.SideFlag,
+	utils.BaseQuantityFlag,
+	utils.LimitPriceFlag,
+	durationFlag,
+	slicesFlag,
+	clipSizeFlag,
+	clipVarianceFlag,
+	participationCapFlag,
+	participationWindowFlag,
+	childTimeoutFlag,
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Start or resume a client-side TWAP or iceberg execution",
+	Long: `Slice a parent order into child orders and work them until the parent is
+filled or the schedule ends.
+
+twap     splits the quantity over --duration into --slices equal slices. Each
+         child rests until the next slice starts and is then cancelled; the
+         unfilled remainder is spread over the remaining slices.
+iceberg  sends one limit child of about --clip-size at a time, randomized by
+         --clip-variance, and replaces it after --child-timeout.
+
+--participation-cap limits each child to a fraction of the product's volume
+over --participation-window. Use "algo set" to change the limit price or the
+cap while the algo is running; a resting child at the old price is replaced.
+
+Progress is saved to --state. Child client order IDs are derived from the run
+ID and child index, so after a crash rerun with only --state to resume: an
+unconfirmed child is looked up by its client order ID before it is resent.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		path, err := getStatePath(cmd)
+		if err != nil {
+			return err
+		}
+
+		pollInterval, err := cmd.Flags().GetDuration(pollIntervalFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", pollIntervalFlag, err)
+		}
+
+		// The lock is taken before the state is read, so two first runs on
+		// the same --state cannot both start a fresh algo.
+		release, err := algo.Lock(path)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		defer release()
+
+		state, err := algo.Load(path)
+		resuming := err == nil
+		switch {
+		case resuming:
+			for _, name := range orderFlags {
+				if cmd.Flags().Changed(name) {
+					return fmt.Errorf("%s already holds algo %s; rerun with only --%s to resume it, or use \"algo set\" to change parameters", path, state.Id, stateFlag)
+				}
+			}
+		case errors.Is(err, os.ErrNotExist):
+			params, err := getParams(cmd, client)
+			if err != nil {
+				return err
+			}
+			state = algo.NewState(*params, time.Now())
+		default:
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		if state.Status != algo.StatusRunning {
+			return fmt.Errorf("algo %s is already %s", state.Id, state.Status)
+		}
+
+		product, err := utils.GetProduct(client, state.Params.PortfolioId, state.Params.ProductId)
+		if err != nil {
+			return err
+		}
+		if !resuming {
+			if err := utils.ValidateProductAmounts(product, state.Params.Quantity, "", state.Params.LimitPrice); err != nil {
+				return err
+			}
+		}
+
+		if err := algo.Save(path, state); err != nil {
+			return err
+		}
+
+		engine := algo.NewEngine(client, path, state, product, utils.GetContextWithTimeout)
+		engine.PollInterval = pollInterval
+		engine.Log = func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Fprintf(os.Stderr, "algo %s: %s %s %s %s, state in %s\n",
+			state.Id, state.Params.Strategy, state.Params.Side, state.Params.Quantity, state.Params.ProductId, path)
+
+		runErr := engine.Run(ctx)
+		if errors.Is(runErr, context.Canceled) {
+			fmt.Fprintf(os.Stderr, "interrupted; rerun with --%s %s to resume\n", stateFlag, path)
+			runErr = nil
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, algo.BuildReport(engine.State(), false))
+		if err != nil {
+			return errors.Join(runErr, err)
+		}
+		fmt.Println(jsonResponse)
+		return runErr
+	},
+}
+
+func getParams(cmd *cobra.Command, c client.RestClient) (*algo.Params, error) {
+	portfolioId, err := utils.GetPortfolioId(cmd, c)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := cmd.Flags().GetDuration(durationFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", durationFlag, err)
+	}
+	slices, err := cmd.Flags().GetInt(slicesFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", slicesFlag, err)
+	}
+	clipVariance, err := cmd.Flags().GetFloat64(clipVarianceFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", clipVarianceFlag, err)
+	}
+	participationWindow, err := cmd.Flags().GetDuration(participationWindowFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", participationWindowFlag, err)
+	}
+	childTimeout, err := cmd.Flags().GetDuration(childTimeoutFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", childTimeoutFlag, err)
+	}
+
+	params := &algo.Params{
+		Strategy:            strings.ToLower(utils.GetFlagStringValue(cmd, strategyFlag)),
+		PortfolioId:         portfolioId,
+		ProductId:           strings.ToUpper(utils.GetFlagStringValue(cmd, utils.ProductIdFlag)),
+		Side:                strings.ToUpper(utils.GetFlagStringValue(cmd, utils.SideFlag)),
+		Quantity:            utils.GetFlagStringValue(cmd, utils.BaseQuantityFlag),
+		LimitPrice:          utils.GetFlagStringValue(cmd, utils.LimitPriceFlag),
+		Duration:            duration,
+		Slices:              slices,
+		ClipSize:            utils.GetFlagStringValue(cmd, clipSizeFlag),
+		ClipVariance:        clipVariance,
+		ParticipationCap:    utils.GetFlagStringValue(cmd, participationCapFlag),
+		ParticipationWindow: participationWindow,
+		ChildTimeout:        childTimeout,
+	}
+	if params.ProductId == "" {
+		return nil, fmt.Errorf("--%s is required for a new algo", utils.ProductIdFlag)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func init() {
+	Cmd.AddCommand(runCmd)
+
+	addStateFlag(runCmd)
+	runCmd.Flags().String(strategyFlag, "", "Execution strategy: "+strings.Join(algo.Strategies, " or "))
+	runCmd.Flags().String(utils.ProductIdFlag, "", "ID of the product")
+	runCmd.Flags().String(utils.SideFlag, "", "Order side, e.g. BUY")
+	runCmd.Flags().String(utils.BaseQuantityFlag, "", "Parent order size in base asset units")
+	runCmd.Flags().String(utils.LimitPriceFlag, "", "Limit price for every child. TWAP children are market orders if blank; required for iceberg")
+	runCmd.Flags().Duration(durationFlag, 0, "TWAP length, or the optional maximum iceberg run time")
+	runCmd.Flags().Int(slicesFlag, 0, "Number of TWAP slices")
+	runCmd.Flags().String(clipSizeFlag, "", "Average iceberg child size in base asset units")
+	runCmd.Flags().Float64(clipVarianceFlag, 0, "Randomize each iceberg clip by up to this fraction, e.g. 0.2 for ±20%")
+	runCmd.Flags().String(participationCapFlag, "", "Limit each child to this fraction of recent product volume, e.g. 0.1")
+	runCmd.Flags().Duration(participationWindowFlag, 5*time.Minute, "Volume lookback for --participation-cap")
+	runCmd.Flags().Duration(childTimeoutFlag, time.Minute, "How long an iceberg child rests before it is replaced")
+	runCmd.Flags().Duration(pollIntervalFlag, 2*time.Second, "How often working children are checked")
+	utils.AddPortfolioIdFlag(runCmd)
+}
@@ -0,0 +1,84 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/algo"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var setCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Change the limit price or participation cap of a running algo",
+	Long: `Queue new parameters for the algo using --state. The running process applies
+them before its next child and replaces a resting child at the old limit
+price. Pass an empty --participation-cap to remove the cap.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := getStatePath(cmd)
+		if err != nil {
+			return err
+		}
+
+		state, err := algo.Load(path)
+		if err != nil {
+			return fmt.Errorf("cannot load algo state: %w", err)
+		}
+		if state.Status != algo.StatusRunning {
+			return fmt.Errorf("algo %s is already %s", state.Id, state.Status)
+		}
+
+		update := algo.Update{}
+		if cmd.Flags().Changed(utils.LimitPriceFlag) {
+			limitPrice := utils.GetFlagStringValue(cmd, utils.LimitPriceFlag)
+			update.LimitPrice = &limitPrice
+		}
+		if cmd.Flags().Changed(participationCapFlag) {
+			participationCap := utils.GetFlagStringValue(cmd, participationCapFlag)
+			update.ParticipationCap = &participationCap
+		}
+		if update.LimitPrice == nil && update.ParticipationCap == nil {
+			return fmt.Errorf("nothing to change; set --%s or --%s", utils.LimitPriceFlag, participationCapFlag)
+		}
+
+		cmd.SilenceUsage = true
+		if err := algo.WriteUpdate(path, update); err != nil {
+			return err
+		}
+
+		result := map[string]any{"id": state.Id, "queued": update}
+		if !algo.IsRunning(path) {
+			result["note"] = "no run is active; the change applies when the algo is resumed"
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(setCmd)
+
+	addStateFlag(setCmd)
+	setCmd.Flags().String(utils.LimitPriceFlag, "", "New limit price for the remaining children")
+	setCmd.Flags().String(participationCapFlag, "", "New fraction of recent volume each child may take")
+}
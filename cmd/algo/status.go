@@ -0,0 +1,82 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package algo
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/algo"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Report an algo's fills and average price from its state file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := getStatePath(cmd)
+		if err != nil {
+			return err
+		}
+
+		children, err := cmd.Flags().GetBool(childrenFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", childrenFlag, err)
+		}
+
+		refresh, err := cmd.Flags().GetBool(refreshFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", refreshFlag, err)
+		}
+
+		state, err := algo.Load(path)
+		if err != nil {
+			return fmt.Errorf("cannot load algo state: %w", err)
+		}
+
+		cmd.SilenceUsage = true
+
+		// A live run keeps the state current and owns the file, so only a
+		// stopped algo is refreshed from the API.
+		if refresh && !algo.IsRunning(path) {
+			client, err := utils.GetClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to initialize client: %w", err)
+			}
+			engine := algo.NewEngine(client, path, state, nil, utils.GetContextWithTimeout)
+			ctx, cancel := utils.GetContextWithTimeout()
+			defer cancel()
+			if err := engine.RefreshChildren(ctx); err != nil {
+				return err
+			}
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, algo.BuildReport(state, children))
+		if err != nil {
+			return err
+		}
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(statusCmd)
+
+	addStateFlag(statusCmd)
+	statusCmd.Flags().Bool(childrenFlag, false, "Include every child order")
+	statusCmd.Flags().Bool(refreshFlag, false, "Re-read child orders from the API when no run is active")
+}
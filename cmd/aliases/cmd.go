@@ -0,0 +1,61 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aliases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/spf13/cobra"
+)
+
+const (
+	kindFlag  = "kind"
+	valueFlag = "value"
+
+	kindWallet    = "wallet"
+	kindPortfolio = "portfolio"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "aliases",
+	Short: "Manage wallet and portfolio aliases stored in the primectl config",
+}
+
+// aliasMap returns the alias table for kind, creating it when create is set.
+func aliasMap(cfg *config.Config, kind string, create bool) (map[string]string, error) {
+	switch strings.ToLower(kind) {
+	case kindWallet:
+		if cfg.Aliases.Wallets == nil && create {
+			cfg.Aliases.Wallets = map[string]string{}
+		}
+		return cfg.Aliases.Wallets, nil
+	case kindPortfolio:
+		if cfg.Aliases.Portfolios == nil && create {
+			cfg.Aliases.Portfolios = map[string]string{}
+		}
+		return cfg.Aliases.Portfolios, nil
+	default:
+		return nil, fmt.Errorf("kind must be either '%s' or '%s'", kindWallet, kindPortfolio)
+	}
+}
+
+func addKindFlag(cmd *cobra.Command) {
+	cmd.Flags().String(kindFlag, "", "Alias kind: wallet or portfolio (Required)")
+	cmd.MarkFlagRequired(kindFlag)
+}
@@ -0,0 +1,68 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aliases
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var deleteAliasCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an alias",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		kind := utils.GetFlagStringValue(cmd, kindFlag)
+		aliases, err := aliasMap(cfg, kind, false)
+		if err != nil {
+			return err
+		}
+
+		name := utils.GetFlagStringValue(cmd, utils.NameFlag)
+		if _, ok := aliases[name]; !ok {
+			return fmt.Errorf("no %s alias named %q", kind, name)
+		}
+		delete(aliases, name)
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, cfg.Aliases)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(deleteAliasCmd)
+
+	addKindFlag(deleteAliasCmd)
+	deleteAliasCmd.Flags().String(utils.NameFlag, "", "Alias name (Required)")
+	deleteAliasCmd.MarkFlagRequired(utils.NameFlag)
+}
@@ -0,0 +1,67 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package aliases
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var setAliasCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Create or replace an alias",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		aliases, err := aliasMap(cfg, utils.GetFlagStringValue(cmd, kindFlag), true)
+		if err != nil {
+			return err
+		}
+
+		name := utils.GetFlagStringValue(cmd, utils.NameFlag)
+		aliases[name] = utils.GetFlagStringValue(cmd, valueFlag)
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, cfg.Aliases)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(setAliasCmd)
+
+	addKindFlag(setAliasCmd)
+	setAliasCmd.Flags().String(utils.NameFlag, "", "Alias name (Required)")
+	setAliasCmd.Flags().String(valueFlag, "", "Wallet or portfolio ID, name, or SYMBOL:TYPE the alias points to (Required)")
+
+	setAliasCmd.MarkFlagRequired(utils.NameFlag)
+	setAliasCmd.MarkFlagRequired(valueFlag)
+}
@@ -0,0 +1,323 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package allocations
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/allocate"
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/coinbase-samples/prime-cli/resolver"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/allocations"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	splitFlag    = "split"
+	fileFlag     = "file"
+	roundingFlag = "rounding"
+	yesFlag      = "yes"
+	dryRunFlag   = "dry-run"
+)
+
+var buildAllocationCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Compute allocation legs from order fills and target splits, then submit",
+	Long: `Sum the fills of the given orders and split the total across destination
+portfolios by percentage (--split PORTFOLIO=40%) or amount
+(--split PORTFOLIO=1.25). Orders and splits can also come from a CSV file
+with order_id, destination_portfolio_id, percent and amount columns.
+
+Each leg is rounded to the product's base or quote increment. What rounding
+and any unassigned share leave over goes to the remainder portfolio, which
+defaults to the destination of the largest leg. The computed legs are shown
+before anything is submitted.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		orderIds, splits, err := getOrdersAndSplits(cmd)
+		if err != nil {
+			return err
+		}
+
+		sizeType := strings.ToUpper(utils.GetFlagStringValue(cmd, utils.SizeTypeFlag))
+		if !contains(allocate.SizeTypes, sizeType) {
+			return fmt.Errorf("unknown --%s %q (supported: %s)", utils.SizeTypeFlag, sizeType, strings.Join(allocate.SizeTypes, ", "))
+		}
+		rounding := utils.GetFlagStringValue(cmd, roundingFlag)
+		if !contains(allocate.Roundings, rounding) {
+			return fmt.Errorf("unknown --%s %q (supported: %s)", roundingFlag, rounding, strings.Join(allocate.Roundings, ", "))
+		}
+
+		if err := resolveDestinations(client, splits); err != nil {
+			return err
+		}
+		remainderPortfolioId := utils.GetFlagStringValue(cmd, utils.RemainderDestPortfolioIdFlag)
+		if remainderPortfolioId != "" {
+			if remainderPortfolioId, err = resolvePortfolio(client, remainderPortfolioId); err != nil {
+				return err
+			}
+		}
+
+		yes, err := cmd.Flags().GetBool(yesFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", yesFlag, err)
+		}
+		dryRun, err := cmd.Flags().GetBool(dryRunFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", dryRunFlag, err)
+		}
+
+		cmd.SilenceUsage = true
+
+		totals, err := allocate.FetchTotals(client, portfolioId, orderIds, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		product, err := utils.GetProduct(client, portfolioId, totals.ProductId)
+		if err != nil {
+			return err
+		}
+		base, quote, _ := strings.Cut(totals.ProductId, "-")
+		increment, unit := product.BaseIncrement, base
+		if sizeType == allocate.SizeTypeQuote {
+			increment, unit = product.QuoteIncrement, quote
+		}
+
+		plan, err := allocate.Compute(totals.Size(sizeType), splits, increment, rounding, remainderPortfolioId)
+		if err != nil {
+			return err
+		}
+
+		if err := writePlan(totals, plan, unit); err != nil {
+			return err
+		}
+
+		if dryRun {
+			jsonResponse, err := utils.FormatResponseAsJson(cmd, map[string]any{
+				"totals": totals,
+				"plan":   plan,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(jsonResponse)
+			return nil
+		}
+
+		if !yes {
+			confirmed, err := utils.Confirm(fmt.Sprintf("Allocate %s %s across %d portfolios?", plan.Total, unit, len(plan.Legs)))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("allocation not submitted")
+			}
+		}
+
+		allocationId := utils.GetFlagStringValue(cmd, utils.AllocationIdFlag)
+		if allocationId == "" {
+			allocationId = utils.NewUuidStr()
+		}
+
+		legs := make([]*model.AllocationLeg, 0, len(plan.Legs))
+		for _, leg := range plan.Legs {
+			legs = append(legs, &model.AllocationLeg{
+				LegId:                  utils.NewUuidStr(),
+				DestinationPortfolioId: leg.PortfolioId,
+				Amount:                 leg.Amount.String(),
+			})
+		}
+
+		ctx, cancel := utils.GetContextWithTimeout()
+		defer cancel()
+
+		response, err := allocations.NewAllocationsService(client).CreatePortfolioAllocations(ctx, &allocations.CreatePortfolioAllocationsRequest{
+			AllocationId:                    allocationId,
+			SourcePortfolioId:               portfolioId,
+			ProductId:                       totals.ProductId,
+			OrderIds:                        totals.OrderIds,
+			AllocationLegs:                  legs,
+			SizeType:                        sizeType,
+			RemainderDestinationPortfolioId: plan.RemainderPortfolioId,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot create portfolio allocations: %w", err)
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, response)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+
+		if !response.Success {
+			return fmt.Errorf("allocation %s failed: %s", allocationId, response.FailureReason)
+		}
+		return nil
+	},
+}
+
+func getOrdersAndSplits(cmd *cobra.Command) ([]string, []*allocate.Split, error) {
+	flagOrderIds, err := cmd.Flags().GetStringSlice(utils.OrderIdsFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not retrieve %s: %w", utils.OrderIdsFlag, err)
+	}
+
+	// An order listed twice would have its fills counted twice.
+	var orderIds []string
+	for _, orderId := range flagOrderIds {
+		if !contains(orderIds, orderId) {
+			orderIds = append(orderIds, orderId)
+		}
+	}
+
+	values, err := cmd.Flags().GetStringArray(splitFlag)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not retrieve %s: %w", splitFlag, err)
+	}
+
+	var splits []*allocate.Split
+	for _, value := range values {
+		split, err := allocate.ParseSplit(value)
+		if err != nil {
+			return nil, nil, err
+		}
+		splits = append(splits, split)
+	}
+
+	if path := utils.GetFlagStringValue(cmd, fileFlag); path != "" {
+		fileOrderIds, fileSplits, err := allocate.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, orderId := range fileOrderIds {
+			if !contains(orderIds, orderId) {
+				orderIds = append(orderIds, orderId)
+			}
+		}
+		splits = append(splits, fileSplits...)
+	}
+
+	if len(orderIds) == 0 {
+		return nil, nil, fmt.Errorf("at least one order ID is required from --%s or --%s", utils.OrderIdsFlag, fileFlag)
+	}
+	if len(splits) == 0 {
+		return nil, nil, fmt.Errorf("at least one split is required from --%s or --%s", splitFlag, fileFlag)
+	}
+	return orderIds, splits, nil
+}
+
+// resolveDestinations replaces portfolio names and aliases with IDs.
+func resolveDestinations(c client.RestClient, splits []*allocate.Split) error {
+	for _, split := range splits {
+		id, err := resolvePortfolio(c, split.PortfolioId)
+		if err != nil {
+			return err
+		}
+		split.PortfolioId = id
+	}
+	return nil
+}
+
+func resolvePortfolio(c client.RestClient, ref string) (string, error) {
+	if resolver.IsId(ref) {
+		return ref, nil
+	}
+
+	r, err := resolver.New(c)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	return r.PortfolioId(ctx, ref)
+}
+
+func writePlan(totals *allocate.Totals, plan *allocate.Plan, unit string) error {
+	table := &reports.Table{
+		Title: fmt.Sprintf(
+			"%s %s %s %s from %d orders (%d fills), increment %s",
+			totals.Side, plan.Total, unit, totals.ProductId, len(totals.OrderIds), totals.Fills, plan.Increment,
+		),
+		Headers: []string{"DESTINATION", "REQUESTED", "AMOUNT", "REMAINDER", "SHARE"},
+	}
+	for _, leg := range plan.Legs {
+		table.Rows = append(table.Rows, []string{
+			leg.PortfolioId,
+			leg.Requested,
+			leg.Amount.String(),
+			leg.Remainder.String(),
+			share(leg.Amount, plan.Total),
+		})
+	}
+	if plan.Remainder.IsPositive() {
+		table.Rows = append(table.Rows, []string{
+			plan.RemainderPortfolioId,
+			"remainder",
+			plan.Remainder.String(),
+			plan.Remainder.String(),
+			share(plan.Remainder, plan.Total),
+		})
+	}
+	return reports.WriteTables(os.Stderr, reports.OutputTable, table)
+}
+
+func share(amount, total decimal.Decimal) string {
+	return amount.Div(total).Mul(decimal.NewFromInt(100)).StringFixed(4) + "%"
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	Cmd.AddCommand(buildAllocationCmd)
+
+	buildAllocationCmd.Flags().StringSlice(utils.OrderIdsFlag, []string{}, "IDs of the executed orders to allocate")
+	buildAllocationCmd.Flags().StringArray(splitFlag, []string{}, "Destination share as PORTFOLIO=PERCENT% or PORTFOLIO=AMOUNT. Repeat for each destination")
+	buildAllocationCmd.Flags().StringP(fileFlag, "f", "", "CSV file with order_id, destination_portfolio_id, percent and amount columns")
+	buildAllocationCmd.Flags().String(utils.SizeTypeFlag, allocate.SizeTypeBase, "Units of the split amounts: BASE or QUOTE")
+	buildAllocationCmd.Flags().String(roundingFlag, allocate.RoundingDown, "Round legs to the product increment: down or nearest")
+	buildAllocationCmd.Flags().String(utils.RemainderDestPortfolioIdFlag, "", "Portfolio that receives the remainder. Defaults to the destination of the largest leg")
+	buildAllocationCmd.Flags().String(utils.AllocationIdFlag, "", "ID of the allocation. Generated when omitted")
+	buildAllocationCmd.Flags().Bool(yesFlag, false, "Submit without asking for confirmation")
+	buildAllocationCmd.Flags().Bool(dryRunFlag, false, "Show the computed legs as JSON and stop")
+	utils.AddPortfolioIdFlag(buildAllocationCmd)
+}
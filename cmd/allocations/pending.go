@@ -0,0 +1,135 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package allocations
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/allocate"
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFlag      = "output"
+	outputJson      = "json"
+	skeletonDirFlag = "skeleton-dir"
+)
+
+var pendingAllocationsCmd = &cobra.Command{
+	Use:   "pending",
+	Short: "List executed orders that are not fully allocated",
+	Long: `Match the orders with fills created in the range against the allocations
+made from the portfolio since the start of the range. Each order that still
+has unallocated quantity is listed with its age. With --skeleton-dir, one
+allocation file per product and side is written for the orders with nothing
+allocated, ready for destinations to be added and passed to allocations build.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		productIds, err := cmd.Flags().GetStringSlice(utils.ProductIdsFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.ProductIdsFlag, err)
+		}
+
+		start, end, err := utils.GetStartEndFlagsAsTime(cmd)
+		if err != nil {
+			return err
+		}
+
+		output := strings.ToLower(utils.GetFlagStringValue(cmd, outputFlag))
+		outputs := append([]string{outputJson}, reports.Outputs...)
+		if !contains(outputs, output) {
+			return fmt.Errorf("--%s must be one of: %s", outputFlag, strings.Join(outputs, ", "))
+		}
+
+		cmd.SilenceUsage = true
+
+		executed, err := allocate.ListExecutedOrders(client, portfolioId, productIds, start, end, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		allocs, err := allocate.ListAllocations(client, portfolioId, productIds, start, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		pending := allocate.FindPending(executed, allocs, time.Now())
+
+		if dir := utils.GetFlagStringValue(cmd, skeletonDirFlag); dir != "" {
+			paths, err := allocate.WriteSkeletons(dir, pending)
+			if err != nil {
+				return err
+			}
+			for _, path := range paths {
+				fmt.Fprintf(os.Stderr, "wrote %s\n", path)
+			}
+		}
+
+		if output == outputJson {
+			jsonResponse, err := utils.FormatResponseAsJson(cmd, pending)
+			if err != nil {
+				return err
+			}
+			fmt.Println(jsonResponse)
+			return nil
+		}
+
+		table := &reports.Table{
+			Title:   fmt.Sprintf("%d of %d executed orders not fully allocated", len(pending), len(executed)),
+			Headers: []string{"ORDER_ID", "PRODUCT", "SIDE", "STATUS", "CREATED", "AGE", "FILLED", "ALLOCATED", "UNALLOCATED"},
+		}
+		for _, p := range pending {
+			table.Rows = append(table.Rows, []string{
+				p.OrderId,
+				p.ProductId,
+				p.Side,
+				p.Status,
+				p.CreatedAt,
+				p.Age,
+				p.FilledQuantity.String(),
+				p.AllocatedQuantity.String(),
+				p.UnallocatedQuantity.String(),
+			})
+		}
+		return table.Write(os.Stdout, output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(pendingAllocationsCmd)
+
+	pendingAllocationsCmd.Flags().StringSlice(utils.ProductIdsFlag, []string{}, "List of product IDs")
+	pendingAllocationsCmd.Flags().String(outputFlag, reports.OutputTable, "Output format: json, table, csv")
+	pendingAllocationsCmd.Flags().String(skeletonDirFlag, "", "Directory to write allocation files for allocations build, one per product and side")
+	utils.AddPortfolioIdFlag(pendingAllocationsCmd)
+	utils.AddStartEndFlags(pendingAllocationsCmd)
+
+	pendingAllocationsCmd.MarkFlagRequired(utils.StartFlag)
+}
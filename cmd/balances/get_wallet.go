@@ -41,12 +41,17 @@ var getWalletBalanceCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
 		request := &balances.GetWalletBalanceRequest{
 			PortfolioId: portfolioId,
-			Id:          utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			Id:          walletId,
 		}
 
 		response, err := balancesService.GetWalletBalance(ctx, request)
@@ -42,9 +42,9 @@ var listOnchainBalancesCmd = &cobra.Command{
 			return err
 		}
 
-		walletId, err := cmd.Flags().GetString(utils.WalletIdFlag)
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
 		if err != nil {
-			return fmt.Errorf("cannot get wallet id: %w", err)
+			return err
 		}
 
 		ctx, cancel := utils.GetContextWithTimeout()
@@ -20,24 +20,35 @@ import (
 
 	"github.com/coinbase-samples/prime-cli/cmd/activities"
 	"github.com/coinbase-samples/prime-cli/cmd/addressbook"
+	"github.com/coinbase-samples/prime-cli/cmd/alerts"
+	"github.com/coinbase-samples/prime-cli/cmd/algo"
+	"github.com/coinbase-samples/prime-cli/cmd/aliases"
 	mcpcmd "github.com/coinbase-samples/prime-cli/cmd/mcp"
 	"github.com/coinbase-samples/prime-cli/cmd/advancedtransfers"
 	"github.com/coinbase-samples/prime-cli/cmd/allocations"
 	"github.com/coinbase-samples/prime-cli/cmd/assets"
 	"github.com/coinbase-samples/prime-cli/cmd/balances"
 	"github.com/coinbase-samples/prime-cli/cmd/commission"
+	"github.com/coinbase-samples/prime-cli/cmd/exporter"
 	"github.com/coinbase-samples/prime-cli/cmd/financing"
 	"github.com/coinbase-samples/prime-cli/cmd/futures"
 	"github.com/coinbase-samples/prime-cli/cmd/invoices"
+	"github.com/coinbase-samples/prime-cli/cmd/localdb"
 	"github.com/coinbase-samples/prime-cli/cmd/onchainaddressbook"
 	"github.com/coinbase-samples/prime-cli/cmd/orders"
 	"github.com/coinbase-samples/prime-cli/cmd/paymentmethods"
 	"github.com/coinbase-samples/prime-cli/cmd/portfolios"
 	"github.com/coinbase-samples/prime-cli/cmd/positions"
 	"github.com/coinbase-samples/prime-cli/cmd/products"
+	"github.com/coinbase-samples/prime-cli/cmd/reports"
+	"github.com/coinbase-samples/prime-cli/cmd/scheduler"
+	"github.com/coinbase-samples/prime-cli/cmd/snapshot"
 	"github.com/coinbase-samples/prime-cli/cmd/staking"
+	"github.com/coinbase-samples/prime-cli/cmd/stream"
 	"github.com/coinbase-samples/prime-cli/cmd/transactions"
+	"github.com/coinbase-samples/prime-cli/cmd/tui"
 	"github.com/coinbase-samples/prime-cli/cmd/users"
+	"github.com/coinbase-samples/prime-cli/cmd/wait"
 	"github.com/coinbase-samples/prime-cli/cmd/wallets"
 	"github.com/coinbase-samples/prime-cli/utils"
 
@@ -60,8 +71,11 @@ func init() {
 
 	rootCmd.PersistentFlags().Bool("help", false, "Show help for command")
 	rootCmd.PersistentFlags().Bool(utils.FormatFlag, false, "Set to include formatted JSON. Default is false")
+	utils.AddTimezoneFlag(rootCmd)
+	addWatchFlags(rootCmd)
 	rootCmd.AddCommand(activities.Cmd)
 	rootCmd.AddCommand(addressbook.Cmd)
+	rootCmd.AddCommand(aliases.Cmd)
 	rootCmd.AddCommand(mcpcmd.Cmd)
 	rootCmd.AddCommand(advancedtransfers.Cmd)
 	rootCmd.AddCommand(allocations.Cmd)
@@ -81,4 +95,17 @@ func init() {
 	rootCmd.AddCommand(users.Cmd)
 	rootCmd.AddCommand(wallets.Cmd)
 	rootCmd.AddCommand(staking.Cmd)
+	rootCmd.AddCommand(wait.Cmd)
+	rootCmd.AddCommand(stream.Cmd)
+	rootCmd.AddCommand(tui.Cmd)
+	rootCmd.AddCommand(snapshot.Cmd)
+	rootCmd.AddCommand(reports.Cmd)
+	rootCmd.AddCommand(localdb.SyncCmd)
+	rootCmd.AddCommand(localdb.SqlCmd)
+	rootCmd.AddCommand(exporter.Cmd)
+	rootCmd.AddCommand(alerts.Cmd)
+	rootCmd.AddCommand(scheduler.Cmd)
+	rootCmd.AddCommand(algo.Cmd)
+
+	enableWatch(rootCmd)
 }
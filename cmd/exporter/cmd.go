@@ -0,0 +1,183 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package exporter implements the exporter command, which serves Prime
+// metrics for Prometheus.
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/exporter"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	listenFlag                 = "listen"
+	collectorsFlag             = "collectors"
+	intervalsFlag              = "intervals"
+	buyingPowerPairsFlag       = "buying-power-pairs"
+	withdrawalPowerSymbolsFlag = "withdrawal-power-symbols"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "exporter",
+	Short: "Serve balances, margin, financing and open order metrics for Prometheus",
+	Long: `Serve Prime metrics in the Prometheus text format on /metrics.
+
+Each collector caches its result for its interval, so scrapes arriving more
+often than that are served from memory. Set intervals per collector with
+--intervals, for example --intervals open_orders=10s,financing=5m.
+
+Collectors: ` + strings.Join(exporter.Collectors, ", "),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		// The futures collector is disabled rather than rejected when no
+		// entity ID is available.
+		entityId, err := cmd.Flags().GetString(utils.EntityIdFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.EntityIdFlag, err)
+		}
+		if entityId == "" && client.Credentials() != nil {
+			entityId = client.Credentials().EntityId
+		}
+
+		listen, err := cmd.Flags().GetString(listenFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", listenFlag, err)
+		}
+
+		names, err := cmd.Flags().GetStringSlice(collectorsFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", collectorsFlag, err)
+		}
+
+		intervals, err := getIntervals(cmd)
+		if err != nil {
+			return err
+		}
+
+		pairs, err := cmd.Flags().GetStringSlice(buyingPowerPairsFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", buyingPowerPairsFlag, err)
+		}
+
+		symbols, err := cmd.Flags().GetStringSlice(withdrawalPowerSymbolsFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", withdrawalPowerSymbolsFlag, err)
+		}
+
+		collectors, warnings, err := exporter.NewCollectors(client, exporter.Config{
+			PortfolioId:            portfolioId,
+			EntityId:               entityId,
+			BuyingPowerPairs:       pairs,
+			WithdrawalPowerSymbols: symbols,
+			Intervals:              intervals,
+		}, names)
+		if err != nil {
+			return err
+		}
+		for _, warning := range warnings {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", warning)
+		}
+
+		cmd.SilenceUsage = true
+
+		e := &exporter.Exporter{
+			Collectors: collectors,
+			NewContext: utils.GetContextWithTimeout,
+			ErrorLog: func(collector string, err error) {
+				fmt.Fprintf(os.Stderr, "%s: %s collector failed: %v\n", time.Now().Format(time.RFC3339), collector, err)
+			},
+		}
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", e)
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+			fmt.Fprintln(w, "primectl exporter: metrics are served on /metrics")
+		})
+
+		server := &http.Server{Addr: listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Fprintf(os.Stderr, "exporter listening on %s, metrics at /metrics\n", listen)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("exporter server failed: %w", err)
+		}
+		return nil
+	},
+}
+
+func getIntervals(cmd *cobra.Command) (map[string]time.Duration, error) {
+	raw, err := cmd.Flags().GetStringToString(intervalsFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", intervalsFlag, err)
+	}
+
+	intervals := map[string]time.Duration{}
+	for name, value := range raw {
+		if err := exporter.ValidateCollectors([]string{name}); err != nil {
+			return nil, fmt.Errorf("invalid --%s: %w", intervalsFlag, err)
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s value for %s: %w", intervalsFlag, name, err)
+		}
+		if interval < 0 {
+			return nil, fmt.Errorf("--%s value for %s cannot be negative", intervalsFlag, name)
+		}
+		intervals[name] = interval
+	}
+	return intervals, nil
+}
+
+func init() {
+	Cmd.Flags().String(listenFlag, ":9108", "Address to serve metrics on")
+	Cmd.Flags().StringSlice(collectorsFlag, exporter.Collectors, "Collectors to enable")
+	Cmd.Flags().StringToString(intervalsFlag, nil, "Per-collector cache intervals, e.g. open_orders=10s,financing=5m")
+	Cmd.Flags().StringSlice(buyingPowerPairsFlag, nil, "Currency pairs to report financing buying power for, e.g. BTC-USD")
+	Cmd.Flags().StringSlice(withdrawalPowerSymbolsFlag, nil, "Assets to report financing withdrawal power for, e.g. USD,BTC")
+	utils.AddPortfolioIdFlag(Cmd)
+	utils.AddEntityIdFlag(Cmd)
+}
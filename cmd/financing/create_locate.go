@@ -50,7 +50,7 @@ var createLocateCmd = &cobra.Command{
 			return err
 		}
 
-		locateDate, err := cmd.Flags().GetString("date")
+		locateDate, err := utils.GetDateFlag(cmd, utils.LocateDateFlag)
 		if err != nil {
 			return err
 		}
@@ -103,7 +103,7 @@ func init() {
 	createLocateCmd.Flags().String("amount", "", "The locate amount")
 	createLocateCmd.MarkFlagRequired("amount")
 
-	createLocateCmd.Flags().String("date", "", "The target date of the locate (YYYY-MM-DD)")
+	createLocateCmd.Flags().String("date", "", "The target date of the locate: YYYY-MM-DD, today, tomorrow or a relative day such as +1d")
 	createLocateCmd.MarkFlagRequired("date")
 
 	utils.AddPortfolioIdFlag(createLocateCmd)
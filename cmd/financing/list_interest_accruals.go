@@ -45,12 +45,12 @@ var listInterestAccrualsCmd = &cobra.Command{
 			return err
 		}
 
-		startDate, err := cmd.Flags().GetString("start-date")
+		startDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.StartDateFlag)
 		if err != nil {
 			return err
 		}
 
-		endDate, err := cmd.Flags().GetString("end-date")
+		endDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.EndDateFlag)
 		if err != nil {
 			return err
 		}
@@ -98,9 +98,9 @@ func init() {
 
 	listInterestAccrualsCmd.Flags().String("portfolio-id", "", "Portfolio ID")
 
-	listInterestAccrualsCmd.Flags().String("start-date", "", "Start date in RFC3339 format")
+	listInterestAccrualsCmd.Flags().String("start-date", "", "Start date: "+utils.TimeExpressionHelp)
 	listInterestAccrualsCmd.MarkFlagRequired("start-date")
 
-	listInterestAccrualsCmd.Flags().String("end-date", "", "End date in RFC3339 format")
+	listInterestAccrualsCmd.Flags().String("end-date", "", "End date: "+utils.TimeExpressionHelp)
 	listInterestAccrualsCmd.MarkFlagRequired("end-date")
 }
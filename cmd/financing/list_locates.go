@@ -40,7 +40,7 @@ var listLocatesCmd = &cobra.Command{
 			return err
 		}
 
-		locateDate, err := cmd.Flags().GetString("date")
+		locateDate, err := utils.GetDateFlag(cmd, utils.LocateDateFlag)
 		if err != nil {
 			return err
 		}
@@ -89,7 +89,7 @@ func init() {
 	Cmd.AddCommand(listLocatesCmd)
 
 	listLocatesCmd.Flags().StringSlice("locate-ids", []string{}, "The IDs of specific locates to filter for")
-	listLocatesCmd.Flags().String("date", "", "The date of the locates in YYYY-MM-DD format")
+	listLocatesCmd.Flags().String("date", "", "The date of the locates: YYYY-MM-DD, today, yesterday or a relative day such as -1d")
 
 	utils.AddPortfolioIdFlag(listLocatesCmd)
 }
@@ -40,12 +40,12 @@ var listMarginCallSummariesCmd = &cobra.Command{
 			return err
 		}
 
-		startDate, err := cmd.Flags().GetString("start-date")
+		startDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.StartDateFlag)
 		if err != nil {
 			return err
 		}
 
-		endDate, err := cmd.Flags().GetString("end-date")
+		endDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.EndDateFlag)
 		if err != nil {
 			return err
 		}
@@ -91,6 +91,6 @@ func init() {
 	listMarginCallSummariesCmd.Flags().String("entity-id", "", "Entity ID")
 	listMarginCallSummariesCmd.MarkFlagRequired("entity-id")
 
-	listMarginCallSummariesCmd.Flags().String("start-date", "", "Start date in RFC3339 format")
-	listMarginCallSummariesCmd.Flags().String("end-date", "", "End date in RFC3339 format")
+	listMarginCallSummariesCmd.Flags().String("start-date", "", "Start date: "+utils.TimeExpressionHelp)
+	listMarginCallSummariesCmd.Flags().String("end-date", "", "End date: "+utils.TimeExpressionHelp)
 }
@@ -40,12 +40,12 @@ var listMarginConversionsCmd = &cobra.Command{
 			return err
 		}
 
-		startDate, err := cmd.Flags().GetString("start-date")
+		startDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.StartDateFlag)
 		if err != nil {
 			return err
 		}
 
-		endDate, err := cmd.Flags().GetString("end-date")
+		endDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.EndDateFlag)
 		if err != nil {
 			return err
 		}
@@ -92,6 +92,6 @@ func init() {
 
 	listMarginConversionsCmd.Flags().String("portfolio-id", "", "Portfolio ID")
 
-	listMarginConversionsCmd.Flags().String("start-date", "", "Start date in RFC3339 format")
-	listMarginConversionsCmd.Flags().String("end-date", "", "End date in RFC3339 format")
+	listMarginConversionsCmd.Flags().String("start-date", "", "Start date: "+utils.TimeExpressionHelp)
+	listMarginConversionsCmd.Flags().String("end-date", "", "End date: "+utils.TimeExpressionHelp)
 }
@@ -40,12 +40,12 @@ var listPortfolioInterestAccrualsCmd = &cobra.Command{
 			return err
 		}
 
-		startDate, err := cmd.Flags().GetString("start-date")
+		startDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.StartDateFlag)
 		if err != nil {
 			return err
 		}
 
-		endDate, err := cmd.Flags().GetString("end-date")
+		endDate, err := utils.GetTimeFlagAsRFC3339(cmd, utils.EndDateFlag)
 		if err != nil {
 			return err
 		}
@@ -93,9 +93,9 @@ func init() {
 
 	utils.AddPortfolioIdFlag(listPortfolioInterestAccrualsCmd)
 
-	listPortfolioInterestAccrualsCmd.Flags().String("start-date", "", "Start date in RFC3339 format")
+	listPortfolioInterestAccrualsCmd.Flags().String("start-date", "", "Start date: "+utils.TimeExpressionHelp)
 	listPortfolioInterestAccrualsCmd.MarkFlagRequired("start-date")
 
-	listPortfolioInterestAccrualsCmd.Flags().String("end-date", "", "End date in RFC3339 format")
+	listPortfolioInterestAccrualsCmd.Flags().String("end-date", "", "End date: "+utils.TimeExpressionHelp)
 	listPortfolioInterestAccrualsCmd.MarkFlagRequired("end-date")
 }
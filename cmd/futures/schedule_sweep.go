@@ -76,4 +76,8 @@ func init() {
 	scheduleSweepCmd.Flags().String(currencyFlag, "", "Currency to sweep (Required)")
 	scheduleSweepCmd.MarkFlagRequired(utils.AmountFlag)
 	scheduleSweepCmd.MarkFlagRequired(currencyFlag)
+
+	scheduleSweepCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
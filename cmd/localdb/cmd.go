@@ -0,0 +1,42 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package localdb holds the sync and sql commands, which share the local
+// SQLite mirror.
+package localdb
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/localdb"
+	"github.com/spf13/cobra"
+)
+
+const dbFlag = "db"
+
+func addDbFlag(cmd *cobra.Command) {
+	cmd.Flags().String(dbFlag, "", "SQLite database file. Uses primeCliDb or primectl.db in the config directory if blank")
+}
+
+func getDbPath(cmd *cobra.Command) (string, error) {
+	path, err := cmd.Flags().GetString(dbFlag)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve %s: %w", dbFlag, err)
+	}
+	if path != "" {
+		return path, nil
+	}
+	return localdb.DefaultPath()
+}
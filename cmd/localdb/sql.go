@@ -0,0 +1,95 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package localdb
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/localdb"
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFlag = "output"
+	outputJson = "json"
+)
+
+var SqlCmd = &cobra.Command{
+	Use:   "sql <query>",
+	Short: "Run a read-only SQL query against the database written by sync",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := getDbPath(cmd)
+		if err != nil {
+			return err
+		}
+
+		output, err := cmd.Flags().GetString(outputFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", outputFlag, err)
+		}
+		output = strings.ToLower(output)
+		outputs := append([]string{outputJson}, reports.Outputs...)
+		if !contains(outputs, output) {
+			return fmt.Errorf("--%s must be one of: %s", outputFlag, strings.Join(outputs, ", "))
+		}
+
+		cmd.SilenceUsage = true
+		db, err := localdb.OpenReadOnly(path)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		columns, rows, err := localdb.Query(db, args[0])
+		if err != nil {
+			return fmt.Errorf("query failed: %w", err)
+		}
+
+		if output != outputJson {
+			table := &reports.Table{Headers: columns, Rows: rows}
+			return table.Write(os.Stdout, output)
+		}
+
+		docs := make([]map[string]string, 0, len(rows))
+		for _, r := range rows {
+			doc := make(map[string]string, len(columns))
+			for i, column := range columns {
+				doc[column] = r[i]
+			}
+			docs = append(docs, doc)
+		}
+		return utils.PrintJsonDocs(cmd, docs)
+	},
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	addDbFlag(SqlCmd)
+	SqlCmd.Flags().String(outputFlag, reports.OutputTable, "Output format: json, table, csv")
+}
@@ -0,0 +1,110 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package localdb
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/localdb"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	resourcesFlag = "resources"
+	sinceFlag     = "since"
+	overlapFlag   = "overlap"
+)
+
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Incrementally mirror orders, fills, transactions, activities, allocations and balances into SQLite",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		path, err := getDbPath(cmd)
+		if err != nil {
+			return err
+		}
+
+		resources, err := cmd.Flags().GetStringSlice(resourcesFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", resourcesFlag, err)
+		}
+		if err := localdb.ValidateResources(resources); err != nil {
+			return err
+		}
+
+		since, err := utils.GetTimeFlag(cmd, sinceFlag)
+		if err != nil {
+			return err
+		}
+
+		overlap, err := cmd.Flags().GetDuration(overlapFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", overlapFlag, err)
+		}
+		if overlap < 0 {
+			return fmt.Errorf("--%s cannot be negative", overlapFlag)
+		}
+
+		cmd.SilenceUsage = true
+		db, err := localdb.Open(path)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		results := localdb.Sync(client, db, localdb.Options{
+			PortfolioId: portfolioId,
+			Resources:   resources,
+			Since:       since,
+			Overlap:     overlap,
+			NewContext:  utils.GetContextWithTimeout,
+			Progress:    os.Stderr,
+		})
+
+		if err := utils.PrintJsonDocs(cmd, results); err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			if r.Error != "" {
+				return errors.New("one or more resources failed to sync")
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	utils.AddPortfolioIdFlag(SyncCmd)
+	addDbFlag(SyncCmd)
+	SyncCmd.Flags().StringSlice(resourcesFlag, []string{}, "Resources to sync (default all): orders, fills, transactions, activities, allocations, balances")
+	SyncCmd.Flags().String(sinceFlag, "-365d", "Where to start a resource that has never been synced: "+utils.TimeExpressionHelp)
+	SyncCmd.Flags().Duration(overlapFlag, time.Hour, "How far behind each high-water mark to re-fetch")
+}
@@ -26,16 +26,41 @@ import (
 	"strings"
 	"time"
 
+	"github.com/coinbase-samples/prime-cli/resolver"
+	"github.com/coinbase-samples/prime-cli/utils"
 	"github.com/coinbase/prime-sdk-go/client"
 	"github.com/coinbase/prime-sdk-go/model"
 	mcplib "github.com/mark3labs/mcp-go/mcp"
 )
 
+// timeLocation is the location date-only and keyword times are read in. It
+// is set from --timezone or primeCliTimezone when the server starts, so tools
+// and CLI commands agree on what "today" or "2026-01-01" means.
+var timeLocation = time.UTC
+
+// timeParam describes a start or end parameter parsed by utils.ParseDateRange.
+func timeParam(label string) string {
+	return label + ": " + utils.TimeExpressionHelp + ". Dates and keywords use the server's timezone (--timezone or primeCliTimezone, default UTC)"
+}
+
+// resolvePortfolioId accepts the same portfolio references as --portfolio-id:
+// a UUID, an alias or a portfolio name.
 func resolvePortfolioId(c client.RestClient, req mcplib.CallToolRequest) (string, error) {
 	id := req.GetString("portfolio_id", "")
-	if id != "" {
+	if resolver.IsId(id) {
 		return id, nil
 	}
+	if id != "" {
+		r, err := resolver.New(c)
+		if err != nil {
+			return "", err
+		}
+
+		ctx, cancel := mcpCtx(context.Background())
+		defer cancel()
+
+		return r.PortfolioId(ctx, id)
+	}
 	creds := c.Credentials()
 	if creds == nil {
 		return "", errors.New("client credentials are nil")
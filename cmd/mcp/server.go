@@ -17,11 +17,18 @@
 package mcp
 
 import (
+	"github.com/coinbase-samples/prime-cli/utils"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
 )
 
-func runMCPServer(_ *cobra.Command, _ []string) error {
+func runMCPServer(cmd *cobra.Command, _ []string) error {
+	loc, err := utils.GetLocation(cmd)
+	if err != nil {
+		return err
+	}
+	timeLocation = loc
+
 	s := server.NewMCPServer(
 		"coinbase-prime",
 		"0.4.2",
@@ -33,7 +33,7 @@ func registerActivityTools(s *server.MCPServer) {
 			mcplib.Description("Activity ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetActivity)
 
@@ -58,10 +58,10 @@ func registerActivityTools(s *server.MCPServer) {
 			mcplib.Description("Filter by status: CANCELLED, PROCESSING, COMPLETED, EXPIRED, REJECTED, FAILED"),
 		),
 		mcplib.WithString("start",
-			mcplib.Description("Start time in RFC3339 format"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -81,14 +81,14 @@ func registerActivityTools(s *server.MCPServer) {
 			mcplib.Description("Activity ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetEntityActivity)
 
 	s.AddTool(mcplib.NewTool("list_activities",
 		mcplib.WithDescription("List portfolio activities (orders, transfers, staking, etc.) meeting filter criteria"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("symbols",
 			mcplib.Description("Filter by asset symbols"),
@@ -103,10 +103,10 @@ func registerActivityTools(s *server.MCPServer) {
 			mcplib.WithStringItems(),
 		),
 		mcplib.WithString("start",
-			mcplib.Description("Start time in RFC3339 format"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -131,7 +131,7 @@ func handleListActivities(ctx context.Context, req mcplib.CallToolRequest) (*mcp
 		return toolErr("%s", err), nil
 	}
 
-	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
@@ -203,7 +203,7 @@ func handleListEntityActivities(ctx context.Context, req mcplib.CallToolRequest)
 		return toolErr("%s", err), nil
 	}
 
-	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
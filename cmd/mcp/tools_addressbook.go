@@ -29,7 +29,7 @@ func registerAddressBookTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_address_book",
 		mcplib.WithDescription("List address book entries for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("symbol",
 			mcplib.Description("Filter by asset symbol (e.g. BTC, ETH)"),
@@ -51,7 +51,7 @@ func registerAddressBookTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_address_book_entry",
 		mcplib.WithDescription("Create a new address book entry for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("address",
 			mcplib.Required(),
@@ -30,7 +30,7 @@ func registerAdvancedTransferTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_advanced_transfers",
 		mcplib.WithDescription("List advanced transfers for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("states",
 			mcplib.Description("Filter by states: ADVANCED_TRANSFER_STATE_CREATED, PROCESSING, DONE, CANCELLED, FAILED, EXPIRED"),
@@ -53,7 +53,7 @@ func registerAdvancedTransferTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_advanced_transfer",
 		mcplib.WithDescription("Create a new advanced transfer"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("transfer_type",
 			mcplib.Required(),
@@ -102,7 +102,7 @@ func registerAdvancedTransferTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("cancel_advanced_transfer",
 		mcplib.WithDescription("Cancel an advanced transfer"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("advanced_transfer_id",
 			mcplib.Required(),
@@ -113,7 +113,7 @@ func registerAdvancedTransferTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_advanced_transfer_transactions",
 		mcplib.WithDescription("List transactions for an advanced transfer"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("advanced_transfer_id",
 			mcplib.Required(),
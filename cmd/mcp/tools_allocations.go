@@ -31,7 +31,7 @@ func registerAllocationTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_allocations",
 		mcplib.WithDescription("List historical allocations for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("product_ids",
 			mcplib.WithStringItems(),
@@ -42,10 +42,10 @@ func registerAllocationTools(s *server.MCPServer) {
 		),
 		mcplib.WithString("start",
 			mcplib.Required(),
-			mcplib.Description("Start date RFC3339"),
+			mcplib.Description(timeParam("Start date")),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End date RFC3339"),
+			mcplib.Description(timeParam("End date")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -61,7 +61,7 @@ func registerAllocationTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_allocation",
 		mcplib.WithDescription("Get an allocation by ID"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("allocation_id",
 			mcplib.Required(),
@@ -72,7 +72,7 @@ func registerAllocationTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_allocation",
 		mcplib.WithDescription("Create a portfolio allocation"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("allocation_id",
 			mcplib.Required(),
@@ -107,7 +107,7 @@ func registerAllocationTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_net_allocation",
 		mcplib.WithDescription("Get a net allocation by netting ID"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("netting_id",
 			mcplib.Required(),
@@ -118,7 +118,7 @@ func registerAllocationTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_net_allocation",
 		mcplib.WithDescription("Create a net portfolio allocation"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("netting_id",
 			mcplib.Description("Netting ID for the allocation"),
@@ -167,7 +167,7 @@ func handleListAllocations(ctx context.Context, req mcplib.CallToolRequest) (*mc
 
 	startStr := req.GetString("start", "")
 	endStr := req.GetString("end", "")
-	start, end, err := utils.ParseDateRange(startStr, endStr)
+	start, end, err := utils.ParseDateRange(startStr, endStr, timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
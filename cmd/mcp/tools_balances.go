@@ -30,7 +30,7 @@ func registerBalanceTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_portfolio_balances",
 		mcplib.WithDescription("List all asset balances for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("type",
 			mcplib.Description("Balance type filter: TRADING_BALANCES, VAULT_BALANCES, TOTAL_BALANCES, PRIME_CUSTODY_BALANCES, or UNIFIED_TOTAL_BALANCES"),
@@ -48,14 +48,14 @@ func registerBalanceTools(s *server.MCPServer) {
 			mcplib.Description("Wallet ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetWalletBalance)
 
 	s.AddTool(mcplib.NewTool("list_onchain_balances",
 		mcplib.WithDescription("List onchain balances for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Filter by wallet ID"),
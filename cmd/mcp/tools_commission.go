@@ -29,7 +29,7 @@ func registerCommissionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_commission",
 		mcplib.WithDescription("Get commission rates and fee tiers for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetCommission)
 }
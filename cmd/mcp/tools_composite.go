@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/coinbase-samples/prime-cli/resolver"
 	"github.com/coinbase-samples/prime-cli/utils"
 	"github.com/coinbase/prime-sdk-go/assets"
 	"github.com/coinbase/prime-sdk-go/model"
@@ -32,7 +33,7 @@ import (
 
 func registerCompositeTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("send_to_blockchain_address",
-		mcplib.WithDescription("Send crypto to an external blockchain address in a single step. Automatically finds the source wallet and creates the withdrawal. WARNING: executes a real financial transaction.\n\nNETWORK: Only ETH and USDC support multiple networks. All other assets use a single default network and network_id is not needed.\n  ETH networks:  ethereum-mainnet, base-mainnet\n  USDC networks: ethereum-mainnet, base-mainnet, solana-mainnet, arbitrum-mainnet,\n                 monad-mainnet, optimism-mainnet, avalanche-mainnet\n\nWALLET SELECTION (when wallet_type is omitted):\n  1. Looks for a TRADING wallet holding the asset first.\n  2. Falls back to a QC wallet if no TRADING wallet is found.\n  To send from a VAULT wallet, you must explicitly set wallet_type=VAULT.\n\nEXAMPLE — send 1 USDC on Base from the trading wallet:\n  send_to_blockchain_address(symbol=\"USDC\", amount=\"1\",\n    to_address=\"0x836fa72D2aF55d698e8767acBE88c042b8201036\",\n    network_id=\"base-mainnet\")"),
+		mcplib.WithDescription("Send crypto to an external blockchain address in a single step. Automatically finds the source wallet and creates the withdrawal. WARNING: executes a real financial transaction.\n\nNETWORK: Only ETH and USDC support multiple networks. All other assets use a single default network and network_id is not needed.\n  ETH networks:  ethereum-mainnet, base-mainnet\n  USDC networks: ethereum-mainnet, base-mainnet, solana-mainnet, arbitrum-mainnet,\n                 monad-mainnet, optimism-mainnet, avalanche-mainnet\n\nWALLET SELECTION (when wallet_type is omitted):\n  1. Looks for a TRADING wallet holding the asset first.\n  2. Falls back to a QC wallet if no TRADING wallet is found.\n  To send from a VAULT wallet, you must explicitly set wallet_type=VAULT.\n\nEXAMPLE — send 1 USDC on Base from the trading wallet:\n  send_to_blockchain_address(symbol=\"USDC\", amount=\"1\",\n    to_address=\"0x836fa72D2aF55d698e8767acBE88c042b8201036\",\n    network_id=\"base-mainnet\")\n\nDESTINATION VERIFICATION: set strict=true to only send to addresses in the address book or onchain address groups, or pass to=<entry name> instead of to_address to choose the destination by name."),
 		mcplib.WithString("symbol",
 			mcplib.Required(),
 			mcplib.Description("Asset symbol to send (e.g. USDC, ETH, BTC, SOL)"),
@@ -42,8 +43,13 @@ func registerCompositeTools(s *server.MCPServer) {
 			mcplib.Description("Amount to send as a decimal string (e.g. \"1\", \"0.5\")"),
 		),
 		mcplib.WithString("to_address",
-			mcplib.Required(),
-			mcplib.Description("Destination blockchain address"),
+			mcplib.Description("Destination blockchain address. Required unless to is set."),
+		),
+		mcplib.WithString("to",
+			mcplib.Description("Name of an address book or onchain address group entry to send to, e.g. \"Cold storage Fireblocks\". Use instead of to_address; the entry's address and account identifier are used."),
+		),
+		mcplib.WithBoolean("strict",
+			mcplib.Description("Refuse to_address unless it is in the address book or an onchain address group. The matched entry is returned with the withdrawal."),
 		),
 		mcplib.WithString("network_id",
 			mcplib.Description("Blockchain network for the withdrawal. Only relevant for ETH and USDC. ETH: ethereum-mainnet, base-mainnet. USDC: ethereum-mainnet, base-mainnet, solana-mainnet, arbitrum-mainnet, monad-mainnet, optimism-mainnet, avalanche-mainnet."),
@@ -55,7 +61,7 @@ func registerCompositeTools(s *server.MCPServer) {
 			mcplib.Description("Filter source wallet by name substring (case-insensitive). Use when multiple wallets match to select the correct one."),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted."),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted."),
 		),
 		mcplib.WithString("idempotency_key",
 			mcplib.Description("Idempotency key (UUID). Auto-generated if omitted."),
@@ -75,11 +81,18 @@ func registerCompositeTools(s *server.MCPServer) {
 			mcplib.Description("Filter wallet by name substring (case-insensitive). Use when multiple wallets match to select the correct one."),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted."),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted."),
 		),
 	), handleGetDepositAddress)
 }
 
+// verifiedWithdrawal pairs a withdrawal with the address book or onchain
+// group entry its destination was matched against.
+type verifiedWithdrawal struct {
+	Destination *resolver.Destination                        `json:"destination"`
+	Withdrawal  *transactions.CreateWalletWithdrawalResponse `json:"withdrawal"`
+}
+
 func handleSendToBlockchainAddress(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.CallToolResult, error) {
 	client, err := utils.GetClientFromEnv()
 	if err != nil {
@@ -101,44 +114,38 @@ func handleSendToBlockchainAddress(ctx context.Context, req mcplib.CallToolReque
 		return toolErr("amount is required"), nil
 	}
 
-	toAddress, err := req.RequireString("to_address")
-	if err != nil {
-		return toolErr("to_address is required"), nil
+	toAddress := req.GetString("to_address", "")
+	toName := req.GetString("to", "")
+	strict := req.GetBool("strict", false)
+	switch {
+	case toAddress == "" && toName == "":
+		return toolErr("to_address or to is required"), nil
+	case toAddress != "" && toName != "":
+		return toolErr("to_address and to cannot be combined"), nil
 	}
 
 	networkId := req.GetString("network_id", "")
 	walletTypeOverride := req.GetString("wallet_type", "")
 	nameContains := req.GetString("wallet_name_contains", "")
 
-	walletSvc := wallets.NewWalletsService(client)
+	r, err := resolver.New(client)
+	if err != nil {
+		return toolErr("%s", err), nil
+	}
+
 	ctx2, cancel2 := fetchAllCtx(ctx)
 	defer cancel2()
 
-	var matched []*model.Wallet
-	var effectiveType string
-
-	if walletTypeOverride != "" {
-		// User explicitly chose a wallet type — search only that type, no fallback.
-		matched, err = findWalletsForSend(ctx2, walletSvc, portfolioId, walletTypeOverride, symbol, networkId, nameContains)
-		if err != nil {
-			return toolErr("failed to list %s wallets: %s", walletTypeOverride, err), nil
-		}
-		effectiveType = walletTypeOverride
-	} else {
-		// Default: try TRADING first, fall back to QC.
-		matched, err = findWalletsForSend(ctx2, walletSvc, portfolioId, model.WalletTypeTrading, symbol, networkId, nameContains)
-		if err != nil {
-			return toolErr("failed to list TRADING wallets: %s", err), nil
-		}
-		if len(matched) > 0 {
-			effectiveType = model.WalletTypeTrading
-		} else {
-			matched, err = findWalletsForSend(ctx2, walletSvc, portfolioId, "QC", symbol, networkId, nameContains)
-			if err != nil {
-				return toolErr("failed to list QC wallets: %s", err), nil
-			}
-			effectiveType = "TRADING or QC"
-		}
+	// An explicit wallet type searches only that type; otherwise TRADING is
+	// tried first with a fallback to QC.
+	matched, effectiveType, err := r.FindWallets(ctx2, portfolioId, resolver.WalletQuery{
+		Symbol:       symbol,
+		Type:         walletTypeOverride,
+		NetworkId:    networkId,
+		NameContains: nameContains,
+	})
+	if err != nil {
+		return toolErr("%s", err), nil
 	}
 
 	switch len(matched) {
@@ -156,22 +163,29 @@ func handleSendToBlockchainAddress(ctx context.Context, req mcplib.CallToolReque
 		// proceed to withdrawal below
 
 	default:
-		var descriptions []string
-		for _, w := range matched {
-			networkSuffix := ""
-			if w.Network != nil && w.Network.Id != "" {
-				networkSuffix = " (network=" + w.Network.Id + ")"
-			}
-			descriptions = append(descriptions, fmt.Sprintf("%q [id=%s%s]", w.Name, w.Id, networkSuffix))
-		}
 		return toolErr(
 			"multiple %s wallets match symbol %s; disambiguate using wallet_name_contains or network_id. Matches: %s",
-			effectiveType, symbol, strings.Join(descriptions, ", "),
+			effectiveType, symbol, strings.Join(resolver.DescribeWallets(matched), ", "),
 		), nil
 	}
 
 	sourceWallet := matched[0]
 
+	var destination *resolver.Destination
+	var accountIdentifier string
+	if toName != "" {
+		destination, err = r.DestinationByName(ctx2, portfolioId, symbol, toName)
+	} else if strict {
+		destination, err = r.VerifyDestination(ctx2, portfolioId, symbol, toAddress, "")
+	}
+	if err != nil {
+		return toolErr("refusing withdrawal: %s", err), nil
+	}
+	if destination != nil {
+		toAddress = destination.Address
+		accountIdentifier = destination.AccountIdentifier
+	}
+
 	idempotencyKey := req.GetString("idempotency_key", "")
 	if idempotencyKey == "" {
 		idempotencyKey = utils.NewUuidStr()
@@ -189,14 +203,18 @@ func handleSendToBlockchainAddress(ctx context.Context, req mcplib.CallToolReque
 		Amount:          amount,
 		IdempotencyKey:  idempotencyKey,
 		BlockchainAddress: &model.BlockchainAddress{
-			Address: toAddress,
-			Network: networkDetailsFor(networkId),
+			Address:           toAddress,
+			AccountIdentifier: accountIdentifier,
+			Network:           networkDetailsFor(networkId),
 		},
 	})
 	if err != nil {
 		return toolErr("cannot create withdrawal from wallet %s (%s): %s", sourceWallet.Name, sourceWallet.Id, err), nil
 	}
 
+	if destination != nil {
+		return marshalResult(verifiedWithdrawal{Destination: destination, Withdrawal: response})
+	}
 	return marshalResult(response)
 }
 
@@ -285,21 +303,20 @@ func handleGetDepositAddress(ctx context.Context, req mcplib.CallToolRequest) (*
 
 	// Step 3: find wallet (TRADING first, then QC). Don't filter by network —
 	// TRADING/QC wallets have null network; apiNetworkId goes to the API call.
-	walletSvc := wallets.NewWalletsService(client)
+	r, err := resolver.New(client)
+	if err != nil {
+		return toolErr("%s", err), nil
+	}
+
 	ctx2, cancel2 := fetchAllCtx(ctx)
 	defer cancel2()
 
-	matched, err := findWalletsForSend(ctx2, walletSvc, portfolioId, model.WalletTypeTrading, symbol, "", nameContains)
+	matched, effectiveType, err := r.FindWallets(ctx2, portfolioId, resolver.WalletQuery{
+		Symbol:       symbol,
+		NameContains: nameContains,
+	})
 	if err != nil {
-		return toolErr("failed to list TRADING wallets: %s", err), nil
-	}
-	effectiveType := model.WalletTypeTrading
-	if len(matched) == 0 {
-		matched, err = findWalletsForSend(ctx2, walletSvc, portfolioId, "QC", symbol, "", nameContains)
-		if err != nil {
-			return toolErr("failed to list QC wallets: %s", err), nil
-		}
-		effectiveType = "TRADING or QC"
+		return toolErr("%s", err), nil
 	}
 
 	switch len(matched) {
@@ -314,13 +331,9 @@ func handleGetDepositAddress(ctx context.Context, req mcplib.CallToolRequest) (*
 		// proceed below
 
 	default:
-		var descriptions []string
-		for _, w := range matched {
-			descriptions = append(descriptions, fmt.Sprintf("%q [id=%s]", w.Name, w.Id))
-		}
 		return toolErr(
 			"multiple %s wallets match symbol %s; disambiguate using wallet_name_contains. Matches: %s",
-			effectiveType, symbol, strings.Join(descriptions, ", "),
+			effectiveType, symbol, strings.Join(resolver.DescribeWallets(matched), ", "),
 		), nil
 	}
 
@@ -330,6 +343,7 @@ func handleGetDepositAddress(ctx context.Context, req mcplib.CallToolRequest) (*
 	ctx3, cancel3 := mcpCtx(ctx)
 	defer cancel3()
 
+	walletSvc := wallets.NewWalletsService(client)
 	response, err := walletSvc.CreateWalletAddress(ctx3, &wallets.CreateWalletAddressRequest{
 		PortfolioId: portfolioId,
 		WalletId:    wallet.Id,
@@ -341,31 +355,3 @@ func handleGetDepositAddress(ctx context.Context, req mcplib.CallToolRequest) (*
 
 	return marshalResult(response)
 }
-
-func findWalletsForSend(ctx context.Context, svc wallets.WalletsService, portfolioId, walletType, symbol, networkId, nameContains string) ([]*model.Wallet, error) {
-	resp, err := svc.ListWallets(ctx, &wallets.ListWalletsRequest{
-		PortfolioId: portfolioId,
-		Type:        walletType,
-		Symbols:     []string{symbol},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	all, err := resp.Iterator().FetchAll(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	var matched []*model.Wallet
-	for _, w := range all {
-		if networkId != "" && w.Network != nil && w.Network.Id != networkId {
-			continue
-		}
-		if nameContains != "" && !strings.Contains(strings.ToLower(w.Name), strings.ToLower(nameContains)) {
-			continue
-		}
-		matched = append(matched, w)
-	}
-	return matched, nil
-}
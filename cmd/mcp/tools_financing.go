@@ -29,7 +29,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_buying_power",
 		mcplib.WithDescription("Get buying power for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("base_currency",
 			mcplib.Required(),
@@ -44,7 +44,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_portfolio_credit_info",
 		mcplib.WithDescription("Get post-trade credit information for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("base_currency",
 			mcplib.Required(),
@@ -87,7 +87,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_withdrawal_power",
 		mcplib.WithDescription("Get withdrawal power for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("symbol",
 			mcplib.Required(),
@@ -98,7 +98,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_locate",
 		mcplib.WithDescription("Create a new locate for a portfolio and asset"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("symbol",
 			mcplib.Required(),
@@ -124,7 +124,7 @@ func registerFinancingTools(s *server.MCPServer) {
 			mcplib.Description("Uses credentials default if omitted"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias to filter by"),
 		),
 		mcplib.WithString("start_date",
 			mcplib.Required(),
@@ -139,7 +139,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_locates",
 		mcplib.WithDescription("List existing locates for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("locate_ids",
 			mcplib.WithStringItems(),
@@ -166,7 +166,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_margin_conversions",
 		mcplib.WithDescription("List margin conversions for a portfolio (deprecated)"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("start_date",
 			mcplib.Description("Start date in RFC3339 format"),
@@ -179,7 +179,7 @@ func registerFinancingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_portfolio_interest_accruals",
 		mcplib.WithDescription("List interest accruals for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("start_date",
 			mcplib.Required(),
@@ -429,13 +429,21 @@ func handleListInterestAccruals(ctx context.Context, req mcplib.CallToolRequest)
 		return toolErr("%s", err), nil
 	}
 
+	var portfolioId string
+	if req.GetString("portfolio_id", "") != "" {
+		portfolioId, err = resolvePortfolioId(client, req)
+		if err != nil {
+			return toolErr("%s", err), nil
+		}
+	}
+
 	svc := prime.NewFinancingService(client)
 	ctx2, cancel := mcpCtx(ctx)
 	defer cancel()
 
 	response, err := svc.ListInterestAccruals(ctx2, &prime.ListInterestAccrualsRequest{
 		EntityId:    entityId,
-		PortfolioId: req.GetString("portfolio_id", ""),
+		PortfolioId: portfolioId,
 		StartDate:   req.GetString("start_date", ""),
 		EndDate:     req.GetString("end_date", ""),
 	})
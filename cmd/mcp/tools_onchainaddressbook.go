@@ -30,14 +30,14 @@ func registerOnchainAddressBookTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_onchain_address_groups",
 		mcplib.WithDescription("List onchain address book groups for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleListOnchainAddressGroups)
 
 	s.AddTool(mcplib.NewTool("create_onchain_address_group",
 		mcplib.WithDescription("Create an onchain address book group entry"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("id",
 			mcplib.Required(),
@@ -59,7 +59,7 @@ func registerOnchainAddressBookTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("update_onchain_address_group",
 		mcplib.WithDescription("Update an onchain address book group entry"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("id",
 			mcplib.Required(),
@@ -81,7 +81,7 @@ func registerOnchainAddressBookTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("delete_onchain_address_group",
 		mcplib.WithDescription("Delete an onchain address book group entry"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("id",
 			mcplib.Required(),
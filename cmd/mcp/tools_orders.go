@@ -18,8 +18,11 @@ package mcp
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/coinbase-samples/prime-cli/pretrade"
 	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
 	"github.com/coinbase/prime-sdk-go/model"
 	"github.com/coinbase/prime-sdk-go/orders"
 	mcplib "github.com/mark3labs/mcp-go/mcp"
@@ -31,13 +34,13 @@ func registerOrderTools(s *server.MCPServer) {
 		mcplib.WithDescription("List orders for a portfolio with optional filters"),
 		mcplib.WithString("start",
 			mcplib.Required(),
-			mcplib.Description("Start time in RFC3339 format (e.g. 2024-01-01T00:00:00Z)"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithArray("product_ids",
 			mcplib.Description("Filter by product IDs (e.g. [\"BTC-USD\"])"),
@@ -67,7 +70,7 @@ func registerOrderTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_open_orders",
 		mcplib.WithDescription("List currently open (active) orders for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("product_ids",
 			mcplib.Description("Filter by product IDs"),
@@ -80,10 +83,10 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Filter by side: BUY or SELL"),
 		),
 		mcplib.WithString("start",
-			mcplib.Description("Start time in RFC3339 format"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -100,7 +103,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Order ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetOrder)
 
@@ -111,7 +114,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Order ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -139,7 +142,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Product ID (e.g. BTC-USD). Use list_products to discover valid values."),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("base_quantity",
 			mcplib.Description("Order size in base asset units (e.g. 0.5 for 0.5 BTC)"),
@@ -155,6 +158,37 @@ func registerOrderTools(s *server.MCPServer) {
 		),
 	), handlePreviewOrder)
 
+	s.AddTool(mcplib.NewTool("pre_trade_check",
+		mcplib.WithDescription("Check a proposed order before submitting it. Runs an order preview and looks up the balance, buying power and commission rate concurrently, then returns a verdict (sufficient_funds, sufficient_credit, insufficient or unknown) with the expected fees, estimated total and any warnings. Does not create an order."),
+		mcplib.WithString("side",
+			mcplib.Required(),
+			mcplib.Description("Order side: BUY or SELL"),
+		),
+		mcplib.WithString("type",
+			mcplib.Required(),
+			mcplib.Description("Order type: MARKET, LIMIT, TWAP, BLOCK, VWAP, STOP_LIMIT, RFQ, or PEG"),
+		),
+		mcplib.WithString("product_id",
+			mcplib.Required(),
+			mcplib.Description("Product ID (e.g. BTC-USD). Use list_products to discover valid values."),
+		),
+		mcplib.WithString("portfolio_id",
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
+		),
+		mcplib.WithString("base_quantity",
+			mcplib.Description("Order size in base asset units (e.g. 0.5 for 0.5 BTC)"),
+		),
+		mcplib.WithString("quote_value",
+			mcplib.Description("Order size in quote asset units (e.g. 10000 for $10,000 USD)"),
+		),
+		mcplib.WithString("limit_price",
+			mcplib.Description("Limit price (required for LIMIT orders)"),
+		),
+		mcplib.WithString("time_in_force",
+			mcplib.Description("Time in force: GTC (Good-Till-Cancelled), GTD (Good-Till-Date), IOC (Immediate-or-Cancel), or FOK (Fill-or-Kill)"),
+		),
+	), handlePreTradeCheck)
+
 	s.AddTool(mcplib.NewTool("create_order",
 		mcplib.WithDescription("Submit a new order. WARNING: This executes a real financial transaction on Coinbase Prime. Use preview_order first to verify parameters."),
 		mcplib.WithString("side",
@@ -170,7 +204,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Product ID (e.g. BTC-USD). Use list_products to discover valid values."),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("base_quantity",
 			mcplib.Description("Order size in base asset units (e.g. 0.5 for 0.5 BTC). Specify either base_quantity or quote_value, not both."),
@@ -202,7 +236,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Order ID to cancel"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleCancelOrder)
 
@@ -213,7 +247,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Order ID to edit"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("new_base_quantity",
 			mcplib.Description("Updated order size in base asset units"),
@@ -236,7 +270,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Order ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetOrderEditHistory)
 
@@ -247,7 +281,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Order side: BUY or SELL"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("product_id",
 			mcplib.Description("Product ID (e.g. BTC-USD)"),
@@ -280,7 +314,7 @@ func registerOrderTools(s *server.MCPServer) {
 			mcplib.Description("Quote ID to accept"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("product_id",
 			mcplib.Description("Product ID (e.g. BTC-USD)"),
@@ -294,13 +328,13 @@ func registerOrderTools(s *server.MCPServer) {
 		mcplib.WithDescription("List all fills for a portfolio"),
 		mcplib.WithString("start",
 			mcplib.Required(),
-			mcplib.Description("Start time in RFC3339 format (e.g. 2024-01-01T00:00:00Z)"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -330,7 +364,7 @@ func handleListOrders(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.
 		return toolErr("start is required"), nil
 	}
 
-	start, end, err := utils.ParseDateRange(startStr, req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(startStr, req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
@@ -377,7 +411,7 @@ func handleListOpenOrders(ctx context.Context, req mcplib.CallToolRequest) (*mcp
 		return toolErr("%s", err), nil
 	}
 
-	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
@@ -524,6 +558,51 @@ func handlePreviewOrder(ctx context.Context, req mcplib.CallToolRequest) (*mcpli
 	return marshalResult(response)
 }
 
+func handlePreTradeCheck(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.CallToolResult, error) {
+	client, err := utils.GetClientFromEnv()
+	if err != nil {
+		return toolErr("failed to initialize client: %s", err), nil
+	}
+
+	portfolioId, err := resolvePortfolioId(client, req)
+	if err != nil {
+		return toolErr("%s", err), nil
+	}
+
+	side, reqErr := req.RequireString("side")
+	if reqErr != nil {
+		return toolErr("side is required (BUY or SELL)"), nil
+	}
+	orderType, reqErr := req.RequireString("type")
+	if reqErr != nil {
+		return toolErr("type is required"), nil
+	}
+	productId, reqErr := req.RequireString("product_id")
+	if reqErr != nil {
+		return toolErr("product_id is required"), nil
+	}
+
+	order := &model.Order{
+		PortfolioId:  portfolioId,
+		Side:         side,
+		Type:         orderType,
+		ProductId:    productId,
+		BaseQuantity: req.GetString("base_quantity", ""),
+		QuoteValue:   req.GetString("quote_value", ""),
+		LimitPrice:   req.GetString("limit_price", ""),
+		TimeInForce:  req.GetString("time_in_force", ""),
+	}
+
+	report, err := pretrade.Check(client, order, func() (context.Context, context.CancelFunc) {
+		return mcpCtx(ctx)
+	})
+	if err != nil {
+		return toolErr("%s", err), nil
+	}
+
+	return marshalResult(report)
+}
+
 func handleCreateOrder(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.CallToolResult, error) {
 	client, err := utils.GetClientFromEnv()
 	if err != nil {
@@ -567,6 +646,14 @@ func handleCreateOrder(ctx context.Context, req mcplib.CallToolRequest) (*mcplib
 		ExpiryTime:    req.GetString("expiry_time", ""),
 	}
 
+	product, err := utils.GetProduct(client, portfolioId, productId)
+	if err != nil {
+		return toolErr("%s", err), nil
+	}
+	if err := utils.ValidateProductAmounts(product, order.BaseQuantity, order.QuoteValue, order.LimitPrice); err != nil {
+		return toolErr("%s", err), nil
+	}
+
 	svc := orders.NewOrdersService(client)
 	ctx2, cancel := mcpCtx(ctx)
 	defer cancel()
@@ -626,18 +713,23 @@ func handleEditOrder(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.C
 		return toolErr("order_id is required"), nil
 	}
 
-	svc := orders.NewOrdersService(client)
-	ctx2, cancel := mcpCtx(ctx)
-	defer cancel()
-
-	response, err := svc.EditOrder(ctx2, &orders.EditOrderRequest{
+	edit := &orders.EditOrderRequest{
 		PortfolioId:   portfolioId,
 		OrderId:       orderId,
 		ClientOrderId: req.GetString("client_order_id", ""),
 		BaseQuantity:  req.GetString("new_base_quantity", ""),
 		QuoteValue:    req.GetString("new_quote_value", ""),
 		LimitPrice:    req.GetString("new_limit_price", ""),
-	})
+	}
+	if err := validateEditAmounts(ctx, client, edit); err != nil {
+		return toolErr("%s", err), nil
+	}
+
+	svc := orders.NewOrdersService(client)
+	ctx2, cancel := mcpCtx(ctx)
+	defer cancel()
+
+	response, err := svc.EditOrder(ctx2, edit)
 	if err != nil {
 		return toolErr("cannot edit order: %s", err), nil
 	}
@@ -645,6 +737,34 @@ func handleEditOrder(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.C
 	return marshalResult(response)
 }
 
+// validateEditAmounts checks the new amounts against the increments of the
+// order's product, as "orders edit" does.
+func validateEditAmounts(ctx context.Context, c client.RestClient, edit *orders.EditOrderRequest) error {
+	if edit.BaseQuantity == "" && edit.QuoteValue == "" && edit.LimitPrice == "" {
+		return nil
+	}
+
+	ctx2, cancel := mcpCtx(ctx)
+	defer cancel()
+
+	response, err := orders.NewOrdersService(c).GetOrder(ctx2, &orders.GetOrderRequest{
+		PortfolioId: edit.PortfolioId,
+		OrderId:     edit.OrderId,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot get order: %w", err)
+	}
+	if response.Order == nil {
+		return fmt.Errorf("order %s not found", edit.OrderId)
+	}
+
+	product, err := utils.GetProduct(c, edit.PortfolioId, response.Order.ProductId)
+	if err != nil {
+		return err
+	}
+	return utils.ValidateProductAmounts(product, edit.BaseQuantity, edit.QuoteValue, edit.LimitPrice)
+}
+
 func handleGetOrderEditHistory(ctx context.Context, req mcplib.CallToolRequest) (*mcplib.CallToolResult, error) {
 	client, err := utils.GetClientFromEnv()
 	if err != nil {
@@ -778,7 +898,7 @@ func handleListPortfolioFills(ctx context.Context, req mcplib.CallToolRequest) (
 		return toolErr("start is required"), nil
 	}
 
-	start, end, err := utils.ParseDateRange(startStr, req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(startStr, req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
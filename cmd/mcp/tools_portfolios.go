@@ -33,21 +33,21 @@ func registerPortfolioTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_portfolio",
 		mcplib.WithDescription("Get details of a specific portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetPortfolio)
 
 	s.AddTool(mcplib.NewTool("get_portfolio_counterparty",
 		mcplib.WithDescription("Get counterparty ID for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetPortfolioCounterparty)
 
 	s.AddTool(mcplib.NewTool("get_portfolio_credit",
 		mcplib.WithDescription("Get credit and buying power information for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetPortfolioCredit)
 }
@@ -30,7 +30,7 @@ func registerProductTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_product_candles",
 		mcplib.WithDescription("Get candlestick data for a product"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("product_id",
 			mcplib.Required(),
@@ -42,18 +42,18 @@ func registerProductTools(s *server.MCPServer) {
 		),
 		mcplib.WithString("start",
 			mcplib.Required(),
-			mcplib.Description("Start time RFC3339"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("end",
 			mcplib.Required(),
-			mcplib.Description("End time RFC3339"),
+			mcplib.Description(timeParam("End time")),
 		),
 	), handleGetProductCandles)
 
 	s.AddTool(mcplib.NewTool("list_products",
 		mcplib.WithDescription("List all tradeable products (trading pairs) available in a portfolio. Use this to discover valid product_id values for order tools."),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -78,7 +78,7 @@ func handleGetProductCandles(ctx context.Context, req mcplib.CallToolRequest) (*
 		return toolErr("%s", err), nil
 	}
 
-	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
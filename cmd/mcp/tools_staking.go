@@ -29,7 +29,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("stake",
 		mcplib.WithDescription("Create a stake or delegate request for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID to stake from"),
@@ -45,7 +45,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("unstake",
 		mcplib.WithDescription("Create an unstake request for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID to unstake from"),
@@ -61,7 +61,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_staking_status",
 		mcplib.WithDescription("Get staking status for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID to query staking status for"),
@@ -71,7 +71,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("claim_staking_rewards",
 		mcplib.WithDescription("Claim staking rewards for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID to claim rewards from"),
@@ -87,7 +87,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_unstaking_status",
 		mcplib.WithDescription("Get the status of an unstake operation"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID to query unstaking status for"),
@@ -97,7 +97,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("preview_unstake",
 		mcplib.WithDescription("Preview an unstake operation for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID to preview unstake for"),
@@ -110,7 +110,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("portfolio_stake_initiate",
 		mcplib.WithDescription("Initiate a portfolio-level stake request"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("symbol",
 			mcplib.Description("Currency symbol to stake (e.g. ETH)"),
@@ -129,7 +129,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("portfolio_unstake",
 		mcplib.WithDescription("Initiate a portfolio-level unstake request"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("symbol",
 			mcplib.Description("Currency symbol to unstake (e.g. ETH)"),
@@ -148,7 +148,7 @@ func registerStakingTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("query_validators",
 		mcplib.WithDescription("Query transaction validators for a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("transaction_ids",
 			mcplib.WithStringItems(),
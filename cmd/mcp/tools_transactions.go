@@ -32,7 +32,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_portfolio_transactions",
 		mcplib.WithDescription("List transactions for a portfolio with optional filters"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("types",
 			mcplib.Description("Filter by transaction types (e.g. [\"CONVERSION\", \"DEPOSIT\"])"),
@@ -42,10 +42,10 @@ func registerTransactionTools(s *server.MCPServer) {
 			mcplib.Description("Filter by asset symbol (e.g. \"BTC\")"),
 		),
 		mcplib.WithString("start",
-			mcplib.Description("Start time in RFC3339 format (e.g. 2024-01-01T00:00:00Z)"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -65,14 +65,14 @@ func registerTransactionTools(s *server.MCPServer) {
 			mcplib.Description("Transaction ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetTransaction)
 
 	s.AddTool(mcplib.NewTool("list_wallet_transactions",
 		mcplib.WithDescription("List transactions for a specific wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID"),
@@ -85,10 +85,10 @@ func registerTransactionTools(s *server.MCPServer) {
 			mcplib.Description("Filter by asset symbol (e.g. \"BTC\")"),
 		),
 		mcplib.WithString("start",
-			mcplib.Description("Start time in RFC3339 format (e.g. 2024-01-01T00:00:00Z)"),
+			mcplib.Description(timeParam("Start time")),
 		),
 		mcplib.WithString("end",
-			mcplib.Description("End time in RFC3339 format"),
+			mcplib.Description(timeParam("End time")),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
@@ -104,7 +104,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_transfer",
 		mcplib.WithDescription("Create an internal transfer between wallets. WARNING: executes a real financial transaction."),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("source_wallet_id",
 			mcplib.Required(),
@@ -130,7 +130,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_withdrawal",
 		mcplib.WithDescription("Send funds from a portfolio wallet to an external destination. WARNING: executes a real financial transaction.\n\nDESTINATION TYPES and required fields:\n  DESTINATION_BLOCKCHAIN — send to an onchain address. Requires: blockchain_address.\n    ETH networks:  ethereum-mainnet, base-mainnet.\n    USDC networks: ethereum-mainnet, base-mainnet, solana-mainnet, arbitrum-mainnet, monad-mainnet, optimism-mainnet, avalanche-mainnet.\n    All other assets use a single default network; omit network_id.\n  DESTINATION_PAYMENT_METHOD — withdraw to a linked bank/payment account. Requires: payment_method_id (use list_payment_methods to find IDs).\n  DESTINATION_COUNTERPARTY — transfer to a registered counterparty.\n\nWORKFLOW:\n  1. Call list_wallets (with type=TRADING and symbols=[symbol]) to find source_wallet_id.\n  2. Call this tool with source_wallet_id, symbol, amount, destination_type, and destination fields.\n\nEXAMPLE — send 1 USDC on Base to an external address:\n  Step 1: list_wallets(type=\"TRADING\", symbols=[\"USDC\"])  -> note the wallet id\n  Step 2: create_withdrawal(source_wallet_id=<id>, symbol=\"USDC\", amount=\"1\", destination_type=\"DESTINATION_BLOCKCHAIN\", blockchain_address=\"0xABC...\", network_id=\"base-mainnet\")\n\nTIP: Use send_to_blockchain_address to perform both steps automatically."),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("source_wallet_id",
 			mcplib.Required(),
@@ -168,7 +168,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_conversion",
 		mcplib.WithDescription("Convert between fiat and stablecoins. WARNING: executes a real financial transaction."),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("source_wallet_id",
 			mcplib.Required(),
@@ -198,7 +198,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("create_onchain_transaction",
 		mcplib.WithDescription("Create an onchain transaction. WARNING: executes a real blockchain transaction."),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Required(),
@@ -228,7 +228,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("get_travel_rule_data",
 		mcplib.WithDescription("Get travel rule data for a transaction"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("transaction_id",
 			mcplib.Required(),
@@ -239,7 +239,7 @@ func registerTransactionTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("submit_deposit_travel_rule_data",
 		mcplib.WithDescription("Submit travel rule data for a deposit transaction"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("transaction_id",
 			mcplib.Required(),
@@ -273,7 +273,7 @@ func handleListPortfolioTransactions(ctx context.Context, req mcplib.CallToolReq
 
 	var start, end time.Time
 	if s := req.GetString("start", ""); s != "" {
-		start, end, err = utils.ParseDateRange(s, req.GetString("end", ""))
+		start, end, err = utils.ParseDateRange(s, req.GetString("end", ""), timeLocation)
 		if err != nil {
 			return toolErr("invalid date range: %s", err), nil
 		}
@@ -350,7 +350,7 @@ func handleListWalletTransactions(ctx context.Context, req mcplib.CallToolReques
 		return toolErr("%s", err), nil
 	}
 
-	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""))
+	start, end, err := utils.ParseDateRange(req.GetString("start", ""), req.GetString("end", ""), timeLocation)
 	if err != nil {
 		return toolErr("invalid date range: %s", err), nil
 	}
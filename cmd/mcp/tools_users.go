@@ -30,7 +30,7 @@ func registerUserTools(s *server.MCPServer) {
 	s.AddTool(mcplib.NewTool("list_portfolio_users",
 		mcplib.WithDescription("List users associated with a portfolio"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("cursor",
 			mcplib.Description("Pagination cursor from a previous response"),
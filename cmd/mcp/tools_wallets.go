@@ -37,7 +37,7 @@ func registerWalletTools(s *server.MCPServer) {
 			mcplib.Description("Filter wallets whose name contains this substring (case-insensitive). Use fetch_all=true to search across all pages."),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithArray("symbols",
 			mcplib.Description("Filter by asset symbols (e.g. [\"USDC\", \"ETH\"])"),
@@ -61,7 +61,7 @@ func registerWalletTools(s *server.MCPServer) {
 			mcplib.Description("Wallet ID"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetWallet)
 
@@ -76,7 +76,7 @@ func registerWalletTools(s *server.MCPServer) {
 			mcplib.Description("Wallet type: VAULT, ONCHAIN, TRADING, or QC"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("symbol",
 			mcplib.Description("Asset symbol (e.g. BTC, ETH)"),
@@ -102,7 +102,7 @@ func registerWalletTools(s *server.MCPServer) {
 			mcplib.Description("Wallet ID to create a deposit address for"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("network_id",
 			mcplib.Description("Network ID for the deposit address. ETH: ethereum-mainnet, base-mainnet. USDC: ethereum-mainnet, base-mainnet, solana-mainnet, arbitrum-mainnet, monad-mainnet, optimism-mainnet, avalanche-mainnet."),
@@ -120,14 +120,14 @@ func registerWalletTools(s *server.MCPServer) {
 			mcplib.Description("Deposit type required by the wallet"),
 		),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 	), handleGetWalletDepositInstructions)
 
 	s.AddTool(mcplib.NewTool("list_wallet_addresses",
 		mcplib.WithDescription("List addresses for a wallet"),
 		mcplib.WithString("portfolio_id",
-			mcplib.Description("Portfolio ID. Uses credentials default if omitted"),
+			mcplib.Description("Portfolio ID, name or alias. Uses credentials default if omitted"),
 		),
 		mcplib.WithString("wallet_id",
 			mcplib.Description("Wallet ID"),
@@ -0,0 +1,243 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/bracket"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bracketStateFlag          = "state"
+	bracketEntryPriceFlag     = "entry-price"
+	bracketEntryTimeoutFlag   = "entry-timeout"
+	bracketSkipEntryFlag      = "skip-entry"
+	bracketTakeProfitFlag     = "take-profit-price"
+	bracketStopPriceFlag      = "stop-price"
+	bracketStopLimitPriceFlag = "stop-limit-price"
+	bracketPollIntervalFlag   = "poll-interval"
+	bracketCancelFlag         = "cancel"
+)
+
+// bracketOrderFlags describe a new bracket and are rejected when resuming.
+var bracketOrderFlags = []string{
+	utils.ProductIdFlag,
+	utils.SideFlag,
+	utils.BaseQuantityFlag,
+	bracketEntryPriceFlag,
+	bracketEntryTimeoutFlag,
+	bracketSkipEntryFlag,
+	bracketTakeProfitFlag,
+	bracketStopPriceFlag,
+	bracketStopLimitPriceFlag,
+}
+
+var bracketOrderCmd = &cobra.Command{
+	Use:   "bracket",
+	Short: "Supervise an entry order with take-profit and stop-loss exits",
+	Long: `Place an entry order, then protect the filled quantity with a take-profit
+limit order and a stop that is watched on the client side. When the take
+profit fills the stop is dropped; when the last price reaches the stop, the
+take profit is cancelled and the stop is sent as a market order, or as a limit
+at --stop-limit-price, for whatever the take profit did not fill.
+
+--side is the entry side: BUY opens a long that the exits sell, SELL opens a
+short that the exits buy back. Use --skip-entry to protect a position already
+held; with both exit prices this is a one-cancels-other pair, and with only
+--stop-price a plain stop loss.
+
+The stop is checked against the close of the latest one-minute candle every
+--poll-interval and only while this process runs. Progress is saved to --state
+and legs use client order IDs derived from the bracket, so rerun with only
+--state to resume after a restart, or add --cancel to cancel the working legs.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		path, err := cmd.Flags().GetString(bracketStateFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", bracketStateFlag, err)
+		}
+
+		pollInterval, err := cmd.Flags().GetDuration(bracketPollIntervalFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", bracketPollIntervalFlag, err)
+		}
+
+		cancelBracket, err := cmd.Flags().GetBool(bracketCancelFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", bracketCancelFlag, err)
+		}
+
+		// The lock is taken before the state is read, so two first runs on
+		// the same --state cannot both place an entry.
+		release, err := bracket.Lock(path)
+		if err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+		defer release()
+
+		state, err := bracket.Load(path)
+		resuming := err == nil
+		switch {
+		case resuming:
+			for _, name := range bracketOrderFlags {
+				if cmd.Flags().Changed(name) {
+					return fmt.Errorf("%s already holds bracket %s; rerun with only --%s to resume it", path, state.Id, bracketStateFlag)
+				}
+			}
+		case errors.Is(err, os.ErrNotExist):
+			if cancelBracket {
+				return fmt.Errorf("no bracket to cancel at %s", path)
+			}
+			params, err := getBracketParams(cmd, client)
+			if err != nil {
+				return err
+			}
+			state = bracket.NewState(*params, time.Now())
+		default:
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		if state.Status != bracket.StatusRunning {
+			return fmt.Errorf("bracket %s is already %s", state.Id, state.Status)
+		}
+
+		product, err := utils.GetProduct(client, state.Params.PortfolioId, state.Params.ProductId)
+		if err != nil {
+			return err
+		}
+		if !resuming {
+			p := state.Params
+			if err := utils.ValidateProductAmounts(product, p.Quantity, "", p.EntryPrice); err != nil {
+				return err
+			}
+			for _, price := range []string{p.TakeProfitPrice, p.StopPrice, p.StopLimitPrice} {
+				if err := utils.ValidateProductAmounts(product, "", "", price); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := bracket.Save(path, state); err != nil {
+			return err
+		}
+
+		supervisor := bracket.NewSupervisor(client, path, state, product, utils.GetContextWithTimeout)
+		supervisor.PollInterval = pollInterval
+		supervisor.Log = func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		var runErr error
+		if cancelBracket {
+			runErr = supervisor.Cancel(ctx)
+		} else {
+			fmt.Fprintf(os.Stderr, "bracket %s: %s %s %s, state in %s\n",
+				state.Id, state.Params.Side, state.Params.Quantity, state.Params.ProductId, path)
+			runErr = supervisor.Run(ctx)
+		}
+		if errors.Is(runErr, context.Canceled) {
+			fmt.Fprintf(os.Stderr, "interrupted; working legs were left in place, rerun with --%s %s to resume\n", bracketStateFlag, path)
+			runErr = nil
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, supervisor.State())
+		if err != nil {
+			return errors.Join(runErr, err)
+		}
+		fmt.Println(jsonResponse)
+		return runErr
+	},
+}
+
+func getBracketParams(cmd *cobra.Command, c client.RestClient) (*bracket.Params, error) {
+	portfolioId, err := utils.GetPortfolioId(cmd, c)
+	if err != nil {
+		return nil, err
+	}
+
+	skipEntry, err := cmd.Flags().GetBool(bracketSkipEntryFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", bracketSkipEntryFlag, err)
+	}
+
+	entryTimeout, err := cmd.Flags().GetDuration(bracketEntryTimeoutFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", bracketEntryTimeoutFlag, err)
+	}
+
+	params := &bracket.Params{
+		PortfolioId:     portfolioId,
+		ProductId:       strings.ToUpper(utils.GetFlagStringValue(cmd, utils.ProductIdFlag)),
+		Side:            strings.ToUpper(utils.GetFlagStringValue(cmd, utils.SideFlag)),
+		Quantity:        utils.GetFlagStringValue(cmd, utils.BaseQuantityFlag),
+		SkipEntry:       skipEntry,
+		EntryPrice:      utils.GetFlagStringValue(cmd, bracketEntryPriceFlag),
+		EntryTimeout:    entryTimeout,
+		TakeProfitPrice: utils.GetFlagStringValue(cmd, bracketTakeProfitFlag),
+		StopPrice:       utils.GetFlagStringValue(cmd, bracketStopPriceFlag),
+		StopLimitPrice:  utils.GetFlagStringValue(cmd, bracketStopLimitPriceFlag),
+	}
+	if params.ProductId == "" {
+		return nil, fmt.Errorf("--%s is required for a new bracket", utils.ProductIdFlag)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+func init() {
+	Cmd.AddCommand(bracketOrderCmd)
+
+	bracketOrderCmd.Flags().String(bracketStateFlag, "", "Bracket state file (Required)")
+	bracketOrderCmd.Flags().String(utils.ProductIdFlag, "", "ID of the product")
+	bracketOrderCmd.Flags().String(utils.SideFlag, "", "Entry side: BUY for a long, SELL for a short")
+	bracketOrderCmd.Flags().String(utils.BaseQuantityFlag, "", "Position size in base asset units")
+	bracketOrderCmd.Flags().String(bracketEntryPriceFlag, "", "Limit price for the entry. The entry is a market order if blank")
+	bracketOrderCmd.Flags().Duration(bracketEntryTimeoutFlag, 0, "Cancel the unfilled part of the entry after this long and protect what filled")
+	bracketOrderCmd.Flags().Bool(bracketSkipEntryFlag, false, "Protect a position already held instead of opening one")
+	bracketOrderCmd.Flags().String(bracketTakeProfitFlag, "", "Limit price of the take-profit exit")
+	bracketOrderCmd.Flags().String(bracketStopPriceFlag, "", "Last price that triggers the stop-loss exit")
+	bracketOrderCmd.Flags().String(bracketStopLimitPriceFlag, "", "Send the triggered stop as a limit at this price instead of a market order")
+	bracketOrderCmd.Flags().Duration(bracketPollIntervalFlag, 5*time.Second, "How often orders and the last price are checked")
+	bracketOrderCmd.Flags().Bool(bracketCancelFlag, false, "Cancel the working legs of the bracket in --state and stop supervising it")
+	utils.AddPortfolioIdFlag(bracketOrderCmd)
+
+	bracketOrderCmd.MarkFlagRequired(bracketStateFlag)
+
+	bracketOrderCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, bracketEntryPriceFlag, bracketTakeProfitFlag, bracketStopPriceFlag, bracketStopLimitPriceFlag)
+	}
+}
@@ -0,0 +1,242 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/bulkorders"
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	bulkFileFlag        = "file"
+	bulkOutFlag         = "out"
+	bulkBatchIdFlag     = "batch-id"
+	bulkYesFlag         = "yes"
+	bulkPreviewOnlyFlag = "preview-only"
+)
+
+var bulkCreateOrdersCmd = &cobra.Command{
+	Use:   "bulk-create",
+	Short: "Validate, preview and submit orders from a CSV file",
+	Long: `Read orders from a CSV file with a header row. Columns: ` + strings.Join(bulkorders.Columns, ", ") + `.
+product_id, side and type are required, and each row needs one of base_quantity
+or quote_value.
+
+Every row is validated as "orders create" would and previewed before anything
+is sent. The aggregate notional and fees are printed for one confirmation, then
+the orders are submitted and each outcome is written to --out.
+
+Rows without a client_order_id get one derived from --batch-id and the row's
+values, so rerunning the same batch reuses the same IDs. Rows that --out
+already records as submitted are skipped, which makes a rerun after an
+interruption or a fixed row safe.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		file := utils.GetFlagStringValue(cmd, bulkFileFlag)
+		out := utils.GetFlagStringValue(cmd, bulkOutFlag)
+		if out == "" {
+			out = strings.TrimSuffix(file, filepath.Ext(file)) + ".results.csv"
+		}
+		batchId := utils.GetFlagStringValue(cmd, bulkBatchIdFlag)
+		if batchId == "" {
+			batchId = filepath.Base(file)
+		}
+
+		yes, err := cmd.Flags().GetBool(bulkYesFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", bulkYesFlag, err)
+		}
+		previewOnly, err := cmd.Flags().GetBool(bulkPreviewOnlyFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", bulkPreviewOnlyFlag, err)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("cannot read orders file: %w", err)
+		}
+
+		cmd.SilenceUsage = true
+		rows, err := bulkorders.Parse(data, portfolioId, batchId)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		if err := reportRowErrors(file, bulkorders.Validate(rows)); err != nil {
+			return err
+		}
+		if err := reportRowErrors(file, bulkorders.CheckProducts(client, portfolioId, rows)); err != nil {
+			return err
+		}
+
+		submitted, err := bulkorders.LoadSubmitted(out)
+		if err != nil {
+			return err
+		}
+		var pending []*bulkorders.Row
+		for _, row := range rows {
+			if _, ok := submitted[row.Order.ClientOrderId]; !ok {
+				pending = append(pending, row)
+			}
+		}
+		if skipped := len(rows) - len(pending); skipped > 0 {
+			fmt.Fprintf(os.Stderr, "%d of %d orders are already recorded as submitted in %s and will be skipped\n", skipped, len(rows), out)
+		}
+		if len(pending) == 0 {
+			fmt.Fprintln(os.Stderr, "nothing to submit")
+			return nil
+		}
+
+		if err := reportRowErrors(file, bulkorders.Preview(client, pending, utils.GetContextWithTimeout)); err != nil {
+			return err
+		}
+
+		summary := bulkorders.Summarize(pending)
+		if previewOnly {
+			jsonResponse, err := utils.FormatResponseAsJson(cmd, summary)
+			if err != nil {
+				return err
+			}
+			fmt.Println(jsonResponse)
+			return nil
+		}
+
+		if err := writeBulkSummary(summary); err != nil {
+			return err
+		}
+		if !yes {
+			confirmed, err := utils.Confirm(fmt.Sprintf("Submit %d orders?", len(pending)))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return errors.New("aborted; no orders were submitted")
+			}
+		}
+
+		results, err := bulkorders.CreateResults(out)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		submitErr := bulkorders.Submit(ctx, client, rows, submitted, results, utils.GetContextWithTimeout)
+		if err := results.Close(); err != nil && submitErr == nil {
+			submitErr = fmt.Errorf("cannot write results: %w", err)
+		}
+
+		counts := map[string]int{}
+		for _, row := range rows {
+			if row.Result != nil {
+				counts[row.Result.Status]++
+			}
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, map[string]any{
+			"submitted":         counts[bulkorders.StatusSubmitted],
+			"failed":            counts[bulkorders.StatusFailed],
+			"already_submitted": counts[bulkorders.StatusAlreadySubmitted],
+			"results":           out,
+		})
+		if err != nil {
+			return errors.Join(submitErr, err)
+		}
+		fmt.Println(jsonResponse)
+
+		if errors.Is(submitErr, context.Canceled) {
+			return fmt.Errorf("interrupted; rerun the same command to submit the remaining orders")
+		}
+		if submitErr != nil {
+			return submitErr
+		}
+		if failed := counts[bulkorders.StatusFailed]; failed > 0 {
+			return fmt.Errorf("%d of %d orders failed; see %s", failed, len(rows), out)
+		}
+		return nil
+	},
+}
+
+// reportRowErrors prints every row problem to stderr and returns one error
+// naming how many were found.
+func reportRowErrors(file string, errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+	}
+	return fmt.Errorf("%d problems found in %s; no orders were submitted", len(errs), file)
+}
+
+func writeBulkSummary(summary *bulkorders.Summary) error {
+	products := &reports.Table{
+		Title:   fmt.Sprintf("%d orders", summary.Orders),
+		Headers: []string{"PRODUCT", "ORDERS", "BUY QTY", "SELL QTY", "NOTIONAL", "FEES", "UNPRICED"},
+	}
+	for _, p := range summary.Products {
+		products.Rows = append(products.Rows, []string{
+			p.ProductId, strconv.Itoa(p.Orders), p.BuyQuantity, p.SellQuantity, p.Notional, p.Fees, strconv.Itoa(p.Unpriced),
+		})
+	}
+
+	currencies := &reports.Table{
+		Title:   "Totals by quote currency",
+		Headers: []string{"CURRENCY", "NOTIONAL", "FEES"},
+	}
+	for _, c := range summary.Currencies {
+		currencies.Rows = append(currencies.Rows, []string{c.Currency, c.Notional, c.Fees})
+	}
+
+	if err := reports.WriteTables(os.Stderr, reports.OutputTable, products, currencies); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr)
+	return nil
+}
+
+func init() {
+	Cmd.AddCommand(bulkCreateOrdersCmd)
+
+	bulkCreateOrdersCmd.Flags().StringP(bulkFileFlag, "f", "", "CSV file of orders (Required)")
+	bulkCreateOrdersCmd.Flags().String(bulkOutFlag, "", "Results CSV. Defaults to the orders file name with a .results.csv suffix")
+	bulkCreateOrdersCmd.Flags().String(bulkBatchIdFlag, "", "Seed for generated client order IDs. Defaults to the orders file name")
+	bulkCreateOrdersCmd.Flags().Bool(bulkYesFlag, false, "Submit without asking for confirmation")
+	bulkCreateOrdersCmd.Flags().Bool(bulkPreviewOnlyFlag, false, "Validate and preview every order, print the summary and stop")
+	utils.AddPortfolioIdFlag(bulkCreateOrdersCmd)
+
+	bulkCreateOrdersCmd.MarkFlagRequired(bulkFileFlag)
+}
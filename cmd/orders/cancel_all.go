@@ -0,0 +1,347 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/spf13/cobra"
+)
+
+const (
+	cancelAllOlderThanFlag    = "older-than"
+	cancelAllClientPrefixFlag = "client-order-id-prefix"
+	cancelAllDryRunFlag       = "dry-run"
+	cancelAllConcurrencyFlag  = "concurrency"
+	cancelAllRateFlag         = "rate"
+	cancelAllYesFlag          = "yes"
+
+	cancelOutcomeCancelled   = "cancel_requested"
+	cancelOutcomeFailed      = "failed"
+	cancelOutcomeWouldCancel = "would_cancel"
+)
+
+// cancelOutcome reports what happened to one matched order.
+type cancelOutcome struct {
+	OrderId       string `json:"order_id"`
+	ClientOrderId string `json:"client_order_id,omitempty"`
+	ProductId     string `json:"product_id"`
+	Side          string `json:"side"`
+	Type          string `json:"type"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	Outcome       string `json:"outcome"`
+	Error         string `json:"error,omitempty"`
+}
+
+type cancelFilter struct {
+	productIds   []string
+	side         string
+	orderType    string
+	olderThan    time.Duration
+	clientPrefix string
+}
+
+// empty reports whether no filter was given, so every open order matches.
+func (f *cancelFilter) empty() bool {
+	return len(f.productIds) == 0 && f.side == "" && f.orderType == "" && f.olderThan == 0 && f.clientPrefix == ""
+}
+
+func (f *cancelFilter) matches(o *model.Order, now time.Time) bool {
+	if len(f.productIds) > 0 && !containsFold(f.productIds, o.ProductId) {
+		return false
+	}
+	if f.side != "" && !strings.EqualFold(o.Side, f.side) {
+		return false
+	}
+	if f.orderType != "" && !strings.EqualFold(o.Type, f.orderType) {
+		return false
+	}
+	if f.clientPrefix != "" && !strings.HasPrefix(o.ClientOrderId, f.clientPrefix) {
+		return false
+	}
+	if f.olderThan > 0 {
+		// An order whose age cannot be read is never treated as old.
+		created, err := time.Parse(time.RFC3339Nano, o.Created)
+		if err != nil || now.Sub(created) < f.olderThan {
+			return false
+		}
+	}
+	return true
+}
+
+var cancelAllOrdersCmd = &cobra.Command{
+	Use:   "cancel-all",
+	Short: "Cancel every open order matching the given filters",
+	Long: `Page through the portfolio's open orders and cancel those matching every
+filter given. With no filters all open orders are cancelled after a
+confirmation prompt, which --yes skips. Run with --dry-run first to list what
+would be cancelled.
+
+Cancels are sent by --concurrency workers at no more than --rate requests per
+second. One JSON document is printed per matched order with its outcome.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		productIds, err := cmd.Flags().GetStringSlice(utils.ProductIdsFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.ProductIdsFlag, err)
+		}
+
+		olderThan, err := cmd.Flags().GetDuration(cancelAllOlderThanFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllOlderThanFlag, err)
+		}
+
+		dryRun, err := cmd.Flags().GetBool(cancelAllDryRunFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllDryRunFlag, err)
+		}
+
+		concurrency, err := cmd.Flags().GetInt(cancelAllConcurrencyFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllConcurrencyFlag, err)
+		}
+
+		rate, err := cmd.Flags().GetFloat64(cancelAllRateFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllRateFlag, err)
+		}
+
+		yes, err := cmd.Flags().GetBool(cancelAllYesFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllYesFlag, err)
+		}
+
+		filter := &cancelFilter{
+			productIds:   productIds,
+			side:         strings.ToUpper(utils.GetFlagStringValue(cmd, utils.OrderSideFlag)),
+			orderType:    strings.ToUpper(utils.GetFlagStringValue(cmd, utils.OrderTypeFlag)),
+			olderThan:    olderThan,
+			clientPrefix: utils.GetFlagStringValue(cmd, cancelAllClientPrefixFlag),
+		}
+
+		cmd.SilenceUsage = true
+		matched, err := listMatchingOpenOrders(client, portfolioId, filter)
+		if err != nil {
+			return err
+		}
+
+		outcomes := make([]*cancelOutcome, len(matched))
+		for i, o := range matched {
+			outcomes[i] = &cancelOutcome{
+				OrderId:       o.Id,
+				ClientOrderId: o.ClientOrderId,
+				ProductId:     o.ProductId,
+				Side:          o.Side,
+				Type:          o.Type,
+				CreatedAt:     o.Created,
+				Outcome:       cancelOutcomeWouldCancel,
+			}
+		}
+
+		if !dryRun && !yes && filter.empty() && len(outcomes) > 0 {
+			confirmed, err := utils.Confirm(fmt.Sprintf("No filters given. Cancel all %d open orders in portfolio %s?", len(outcomes), portfolioId))
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return errors.New("aborted; no orders were cancelled")
+			}
+		}
+
+		if !dryRun {
+			cancelOrders(client, portfolioId, outcomes, concurrency, rate)
+		}
+
+		if err := utils.PrintJsonDocs(cmd, outcomes); err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, o := range outcomes {
+			if o.Outcome == cancelOutcomeFailed {
+				failed++
+			}
+		}
+		switch {
+		case dryRun:
+			fmt.Fprintf(os.Stderr, "%d open orders would be cancelled\n", len(outcomes))
+		case failed > 0:
+			return fmt.Errorf("%d of %d cancels failed", failed, len(outcomes))
+		default:
+			fmt.Fprintf(os.Stderr, "cancel requested for %d open orders\n", len(outcomes))
+		}
+		return nil
+	},
+}
+
+// listMatchingOpenOrders pages through the open orders, narrowing by product,
+// side and type on the server and applying every filter again locally.
+func listMatchingOpenOrders(c client.RestClient, portfolioId string, filter *cancelFilter) ([]*model.Order, error) {
+	svc := orders.NewOrdersService(c)
+	request := &orders.ListOpenOrdersRequest{
+		PortfolioId: portfolioId,
+		ProductIds:  filter.productIds,
+		OrderType:   filter.orderType,
+		OrderSide:   filter.side,
+		Pagination:  &model.PaginationParams{Limit: 1000},
+	}
+
+	now := time.Now()
+	seen := map[string]bool{}
+	var matched []*model.Order
+	for {
+		response, err := listOpenOrders(svc, request)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range response.Orders {
+			if !seen[o.Id] && filter.matches(o, now) {
+				seen[o.Id] = true
+				matched = append(matched, o)
+			}
+		}
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		request.Pagination = &model.PaginationParams{Limit: request.Pagination.Limit, Cursor: response.Pagination.NextCursor}
+	}
+	return matched, nil
+}
+
+// cancelOrders sends the cancels from a pool of workers that share one rate
+// limit, recording each result on its outcome.
+func cancelOrders(c client.RestClient, portfolioId string, outcomes []*cancelOutcome, concurrency int, rate float64) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var limiter <-chan time.Time
+	if rate > 0 {
+		// A very high rate rounds down to a zero interval, which NewTicker
+		// rejects, so the interval is at least a nanosecond.
+		interval := max(time.Duration(float64(time.Second)/rate), time.Nanosecond)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	jobs := make(chan *cancelOutcome)
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc := orders.NewOrdersService(c)
+			for outcome := range jobs {
+				if limiter != nil {
+					<-limiter
+				}
+
+				ctx, cancel := utils.GetContextWithTimeout()
+				_, err := svc.CancelOrder(ctx, &orders.CancelOrderRequest{
+					PortfolioId: portfolioId,
+					OrderId:     outcome.OrderId,
+				})
+				cancel()
+
+				if err != nil {
+					outcome.Outcome = cancelOutcomeFailed
+					outcome.Error = err.Error()
+				} else {
+					outcome.Outcome = cancelOutcomeCancelled
+				}
+			}
+		}()
+	}
+	for _, outcome := range outcomes {
+		jobs <- outcome
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	Cmd.AddCommand(cancelAllOrdersCmd)
+
+	utils.AddPortfolioIdFlag(cancelAllOrdersCmd)
+	utils.AddProductIdsFlag(cancelAllOrdersCmd)
+	cancelAllOrdersCmd.Flags().String(utils.OrderSideFlag, "", "Only cancel orders on this side: BUY or SELL")
+	cancelAllOrdersCmd.Flags().String(utils.OrderTypeFlag, "", "Only cancel orders of this type, e.g. LIMIT")
+	cancelAllOrdersCmd.Flags().Duration(cancelAllOlderThanFlag, 0, "Only cancel orders created at least this long ago, e.g. 15m")
+	cancelAllOrdersCmd.Flags().String(cancelAllClientPrefixFlag, "", "Only cancel orders whose client order ID starts with this prefix")
+	cancelAllOrdersCmd.Flags().Bool(cancelAllDryRunFlag, false, "List the orders that would be cancelled without cancelling them")
+	cancelAllOrdersCmd.Flags().Int(cancelAllConcurrencyFlag, 5, "Number of cancels in flight at once")
+	cancelAllOrdersCmd.Flags().Float64(cancelAllRateFlag, 10, "Maximum cancel requests per second; 0 for no limit")
+	cancelAllOrdersCmd.Flags().Bool(cancelAllYesFlag, false, "Cancel every open order without asking when no filter is given")
+
+	cancelAllOrdersCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if side := utils.GetFlagStringValue(cmd, utils.OrderSideFlag); side != "" {
+			if err := utils.ValidateSideValue(strings.ToUpper(side)); err != nil {
+				return err
+			}
+		}
+		if err := utils.ValidateOrderTypeFilter(strings.ToUpper(utils.GetFlagStringValue(cmd, utils.OrderTypeFlag))); err != nil {
+			return err
+		}
+
+		concurrency, err := cmd.Flags().GetInt(cancelAllConcurrencyFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllConcurrencyFlag, err)
+		}
+		if concurrency < 1 {
+			return fmt.Errorf("--%s must be at least 1", cancelAllConcurrencyFlag)
+		}
+
+		rate, err := cmd.Flags().GetFloat64(cancelAllRateFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", cancelAllRateFlag, err)
+		}
+		if rate < 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			return fmt.Errorf("--%s must be a finite number of requests per second, or 0 for no limit", cancelAllRateFlag)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package orders
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/pretrade"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/spf13/cobra"
+)
+
+var checkOrderCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check buying power, balances, fees and the estimated total for a proposed order",
+	Long: `Run an order preview and look up the balance, buying power and commission
+rate for a proposed order at the same time, then report a single verdict:
+sufficient_funds, sufficient_credit, insufficient or unknown. The command
+exits with an error when the verdict is insufficient, so it can gate a
+following orders create.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		if err := utils.ValidateOrderAmounts(cmd, client, portfolioId, utils.GetFlagStringValue(cmd, utils.ProductIdFlag)); err != nil {
+			return err
+		}
+
+		order := &model.Order{
+			PortfolioId:  portfolioId,
+			Side:         utils.GetFlagStringValue(cmd, utils.SideFlag),
+			Type:         utils.GetFlagStringValue(cmd, utils.TypeFlag),
+			ProductId:    utils.GetFlagStringValue(cmd, utils.ProductIdFlag),
+			BaseQuantity: utils.GetFlagStringValue(cmd, utils.BaseQuantityFlag),
+			QuoteValue:   utils.GetFlagStringValue(cmd, utils.QuoteValueFlag),
+			LimitPrice:   utils.GetFlagStringValue(cmd, utils.LimitPriceFlag),
+			StartTime:    utils.GetFlagStringValue(cmd, utils.StartTimeFlag),
+			ExpiryTime:   utils.GetFlagStringValue(cmd, utils.ExpiryTimeFlag),
+			TimeInForce:  utils.GetFlagStringValue(cmd, utils.TimeInForceFlag),
+		}
+
+		cmd.SilenceUsage = true
+		report, err := pretrade.Check(client, order, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, report)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+
+		if report.Verdict == pretrade.VerdictInsufficient {
+			return fmt.Errorf("insufficient %s: %s required, %s available, %s buying power", report.SpendCurrency, report.Required, report.Available, report.BuyingPower)
+		}
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(checkOrderCmd)
+
+	utils.AddOrderSideFlag(checkOrderCmd)
+	utils.AddProductIdFlag(checkOrderCmd)
+	checkOrderCmd.Flags().String(utils.TypeFlag, "", "Type of the order (Required)")
+	utils.AddBaseQuantityFlag(checkOrderCmd)
+	utils.AddQuoteValueFlag(checkOrderCmd)
+	checkOrderCmd.Flags().String(utils.TimeInForceFlag, "", "Determine order fill strategy")
+	utils.AddLimitPriceFlag(checkOrderCmd)
+	checkOrderCmd.Flags().String(utils.StartTimeFlag, "", "Start time of the order in UTC (TWAP only)")
+	checkOrderCmd.Flags().String(utils.ExpiryTimeFlag, "", "Expiry time of the order in UTC (TWAP and limit GTDT only)")
+	utils.AddPortfolioIdFlag(checkOrderCmd)
+
+	checkOrderCmd.MarkFlagRequired(utils.SideFlag)
+	checkOrderCmd.MarkFlagRequired(utils.ProductIdFlag)
+	checkOrderCmd.MarkFlagRequired(utils.TypeFlag)
+
+	checkOrderCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := utils.ValidateSide(cmd); err != nil {
+			return err
+		}
+		if err := utils.ValidateOrderTypeAndLimitPrice(cmd); err != nil {
+			return err
+		}
+		if err := utils.ValidateTimeInForce(cmd); err != nil {
+			return err
+		}
+		if err := utils.ValidateQuantities(cmd); err != nil {
+			return err
+		}
+		if err := utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, utils.QuoteValueFlag, utils.LimitPriceFlag); err != nil {
+			return err
+		}
+		return nil
+	}
+}
@@ -46,6 +46,10 @@ var createOrderCmd = &cobra.Command{
 			return err
 		}
 
+		if err := utils.ValidateOrderAmounts(cmd, client, portfolioId, utils.GetFlagStringValue(cmd, utils.ProductIdFlag)); err != nil {
+			return err
+		}
+
 		order := &model.Order{
 			PortfolioId:   portfolioId,
 			Side:          utils.GetFlagStringValue(cmd, utils.SideFlag),
@@ -79,7 +83,7 @@ var createOrderCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindOrder, portfolioId, response.OrderId)
 	},
 }
 
@@ -96,13 +100,18 @@ func init() {
 	createOrderCmd.Flags().String(utils.StartTimeFlag, "", "The start time of the order in UTC (TWAP only)")
 	createOrderCmd.Flags().String(utils.ExpiryTimeFlag, "", "The expiry time of the order in UTC (TWAP and limit GTD only)")
 	utils.AddPortfolioIdFlag(createOrderCmd)
+	utils.AddOrderWaitFlags(createOrderCmd)
 	utils.AddClientOrderId(createOrderCmd)
+	createOrderCmd.Flags().String(templateFlag, "", "Order template supplying defaults for any flag not given; see \"orders template\"")
 
 	createOrderCmd.MarkFlagRequired(utils.SideFlag)
 	createOrderCmd.MarkFlagRequired(utils.ProductIdFlag)
 	createOrderCmd.MarkFlagRequired(utils.TypeFlag)
 
 	createOrderCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyOrderTemplate(cmd); err != nil {
+			return err
+		}
 		if err := utils.ValidateSide(cmd); err != nil {
 			return err
 		}
@@ -115,6 +124,9 @@ func init() {
 		if err := utils.ValidateQuantities(cmd); err != nil {
 			return err
 		}
+		if err := utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, utils.QuoteValueFlag, utils.LimitPriceFlag); err != nil {
+			return err
+		}
 		return nil
 	}
 }
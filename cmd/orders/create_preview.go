@@ -41,6 +41,10 @@ var createOrderPreviewCmd = &cobra.Command{
 			return err
 		}
 
+		if err := utils.ValidateOrderAmounts(cmd, client, portfolioId, utils.GetFlagStringValue(cmd, utils.ProductIdFlag)); err != nil {
+			return err
+		}
+
 		order := &model.Order{
 			PortfolioId:  portfolioId,
 			Side:         utils.GetFlagStringValue(cmd, utils.SideFlag),
@@ -108,6 +112,9 @@ func init() {
 		if err := utils.ValidateQuantities(cmd); err != nil {
 			return err
 		}
+		if err := utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, utils.QuoteValueFlag, utils.LimitPriceFlag); err != nil {
+			return err
+		}
 		return nil
 	}
 }
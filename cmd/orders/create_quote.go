@@ -46,6 +46,10 @@ var createQuoteCmd = &cobra.Command{
 			return err
 		}
 
+		if err := utils.ValidateOrderAmounts(cmd, client, portfolioId, utils.GetFlagStringValue(cmd, utils.ProductIdFlag)); err != nil {
+			return err
+		}
+
 		request := &orders.CreateQuoteRequest{
 			PortfolioId:    portfolioId,
 			ProductId:      utils.GetFlagStringValue(cmd, utils.ProductIdFlag),
@@ -90,4 +94,8 @@ func init() {
 
 	createQuoteCmd.MarkFlagRequired(utils.SideFlag)
 	createQuoteCmd.MarkFlagRequired(utils.ProductIdFlag)
+
+	createQuoteCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, utils.QuoteValueFlag, utils.LimitPriceFlag)
+	}
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
 	"github.com/coinbase/prime-sdk-go/orders"
 
 	"github.com/spf13/cobra"
@@ -41,6 +42,10 @@ var editOrderCmd = &cobra.Command{
 			return err
 		}
 
+		if err := validateEditAmounts(cmd, client, portfolioId); err != nil {
+			return err
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
@@ -68,6 +73,39 @@ var editOrderCmd = &cobra.Command{
 	},
 }
 
+// validateEditAmounts checks the new size and price against the increments
+// and limits of the order's product, which is read from the order itself.
+func validateEditAmounts(cmd *cobra.Command, c client.RestClient, portfolioId string) error {
+	baseQuantity := utils.GetFlagStringValue(cmd, utils.NewBaseQuantityFlag)
+	quoteValue := utils.GetFlagStringValue(cmd, utils.NewQuoteValueFlag)
+	limitPrice := utils.GetFlagStringValue(cmd, utils.NewLimitPriceFlag)
+	if baseQuantity == "" && quoteValue == "" && limitPrice == "" {
+		return nil
+	}
+
+	orderId := utils.GetFlagStringValue(cmd, utils.OrderIdFlag)
+
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(c).GetOrder(ctx, &orders.GetOrderRequest{
+		PortfolioId: portfolioId,
+		OrderId:     orderId,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot get order: %w", err)
+	}
+	if response.Order == nil {
+		return fmt.Errorf("order %s not found", orderId)
+	}
+
+	product, err := utils.GetProduct(c, portfolioId, response.Order.ProductId)
+	if err != nil {
+		return err
+	}
+	return utils.ValidateProductAmounts(product, baseQuantity, quoteValue, limitPrice)
+}
+
 func init() {
 	Cmd.AddCommand(editOrderCmd)
 
@@ -82,4 +120,8 @@ func init() {
 	editOrderCmd.Flags().String(utils.NewLimitPriceFlag, "", "Updated limit price")
 
 	utils.AddPortfolioIdFlag(editOrderCmd)
+
+	editOrderCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.NewBaseQuantityFlag, utils.NewQuoteValueFlag, utils.NewLimitPriceFlag)
+	}
 }
@@ -61,17 +61,7 @@ var listOrdersCmd = &cobra.Command{
 			return err
 		}
 
-		startStr, err := cmd.Flags().GetString(utils.StartFlag)
-		if err != nil {
-			return err
-		}
-
-		endStr, err := cmd.Flags().GetString(utils.EndFlag)
-		if err != nil {
-			return err
-		}
-
-		start, end, err := utils.ParseDateRange(startStr, endStr)
+		start, end, err := utils.GetStartEndFlagsAsTime(cmd)
 		if err != nil {
 			return err
 		}
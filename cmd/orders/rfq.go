@@ -0,0 +1,435 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package orders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	rfqMaxPriceFlag = "max-price"
+	rfqRequoteFlag  = "requote"
+)
+
+type rfqDecision int
+
+const (
+	rfqAccept rfqDecision = iota
+	rfqQuit
+	rfqExpired
+)
+
+type rfqResult struct {
+	Quote   *orders.CreateQuoteResponse `json:"quote"`
+	Preview *model.Order                `json:"preview,omitempty"`
+	OrderId string                      `json:"order_id"`
+}
+
+type rfqSession struct {
+	client      client.RestClient
+	portfolioId string
+	productId   string
+	side        string
+	base        string
+	quoteValue  string
+	limitPrice  string
+	settle      string
+	maxPrice    decimal.Decimal
+	hasMaxPrice bool
+	interactive bool
+	keys        chan byte
+	out         io.Writer
+	eol         string
+}
+
+var rfqCmd = &cobra.Command{
+	Use:   "rfq",
+	Short: "Request a quote, review it against an order preview and accept it before it expires",
+	Long: `Request a quote and show its price, implied notional and a countdown to
+expiry next to a market order preview of the same size. Press a or enter to
+accept the quote and q to walk away. With --max-price a quote at or better
+than the bound (at or below for BUY, at or above for SELL) is accepted
+without asking. --requote requests a new quote when one expires, up to the
+given number of times.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		s := &rfqSession{
+			client:      client,
+			portfolioId: portfolioId,
+			productId:   utils.GetFlagStringValue(cmd, utils.ProductIdFlag),
+			side:        utils.GetFlagStringValue(cmd, utils.SideFlag),
+			base:        utils.GetFlagStringValue(cmd, utils.BaseQuantityFlag),
+			quoteValue:  utils.GetFlagStringValue(cmd, utils.QuoteValueFlag),
+			limitPrice:  utils.GetFlagStringValue(cmd, utils.LimitPriceFlag),
+			settle:      utils.GetFlagStringValue(cmd, utils.SettleCurrencyFlag),
+			interactive: term.IsTerminal(int(os.Stdin.Fd())),
+			out:         os.Stderr,
+			eol:         "\n",
+		}
+
+		if value := utils.GetFlagStringValue(cmd, rfqMaxPriceFlag); value != "" {
+			if s.maxPrice, err = utils.ParseAmount(rfqMaxPriceFlag, value); err != nil {
+				return err
+			}
+			s.hasMaxPrice = true
+		}
+
+		requotes, err := cmd.Flags().GetInt(rfqRequoteFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", rfqRequoteFlag, err)
+		}
+		if requotes < 0 {
+			return fmt.Errorf("--%s cannot be negative", rfqRequoteFlag)
+		}
+
+		if !s.interactive && !s.hasMaxPrice {
+			return fmt.Errorf("--%s is required when stdin is not a terminal", rfqMaxPriceFlag)
+		}
+
+		if err := utils.ValidateOrderAmounts(cmd, client, portfolioId, s.productId); err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		restore := func() {}
+		if s.interactive {
+			// Raw mode delivers single key presses without waiting for enter.
+			fd := int(os.Stdin.Fd())
+			state, err := term.MakeRaw(fd)
+			if err != nil {
+				return fmt.Errorf("cannot switch terminal to raw mode: %w", err)
+			}
+			restore = func() { term.Restore(fd, state) }
+			defer restore()
+
+			s.eol = "\r\n"
+			s.keys = make(chan byte)
+			go readRfqKeys(os.Stdin, s.keys)
+		}
+
+		result, err := s.run(ctx, requotes)
+		// Leave raw mode before the result or error is printed; raw mode does
+		// not return the cursor to the start of each line.
+		restore()
+		if err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, result)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func (s *rfqSession) run(ctx context.Context, requotes int) (*rfqResult, error) {
+	for attempt := 0; ; attempt++ {
+		quote, err := s.requestQuote()
+		if err != nil {
+			return nil, err
+		}
+
+		expires, err := time.Parse(time.RFC3339Nano, quote.ExpirationTime)
+		if err != nil {
+			return nil, fmt.Errorf("quote %s has an unreadable expiration time %q: %w", quote.QuoteId, quote.ExpirationTime, err)
+		}
+
+		bestPrice, err := decimal.NewFromString(quote.BestPrice)
+		if err != nil {
+			return nil, fmt.Errorf("quote %s has an unreadable best price %q: %w", quote.QuoteId, quote.BestPrice, err)
+		}
+
+		preview, err := s.preview()
+		if err != nil {
+			s.printf("warning: order preview unavailable: %v", err)
+		}
+
+		s.describe(quote, bestPrice, preview, expires)
+
+		var decision rfqDecision
+		if s.hasMaxPrice && s.withinBound(bestPrice) {
+			s.printf("best price %s is within --%s %s, accepting", quote.BestPrice, rfqMaxPriceFlag, s.maxPrice)
+			decision = rfqAccept
+		} else {
+			if s.hasMaxPrice {
+				s.printf("best price %s is outside --%s %s", quote.BestPrice, rfqMaxPriceFlag, s.maxPrice)
+			}
+			if decision, err = s.wait(ctx, expires); err != nil {
+				return nil, err
+			}
+		}
+
+		switch decision {
+		case rfqAccept:
+			orderId, err := s.accept(quote)
+			if err != nil {
+				return nil, err
+			}
+			return &rfqResult{Quote: quote, Preview: preview, OrderId: orderId}, nil
+		case rfqQuit:
+			return nil, fmt.Errorf("quote %s was not accepted", quote.QuoteId)
+		}
+
+		if attempt >= requotes {
+			return nil, fmt.Errorf("quote %s expired", quote.QuoteId)
+		}
+		s.printf("quote %s expired, requesting a new quote (%d of %d)", quote.QuoteId, attempt+1, requotes)
+	}
+}
+
+func (s *rfqSession) requestQuote() (*orders.CreateQuoteResponse, error) {
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(s.client).CreateQuoteRequest(ctx, &orders.CreateQuoteRequest{
+		PortfolioId:    s.portfolioId,
+		ProductId:      s.productId,
+		ClientQuoteId:  utils.NewUuidStr(),
+		Side:           model.OrderSide(s.side),
+		BaseQuantity:   s.base,
+		QuoteValue:     s.quoteValue,
+		LimitPrice:     s.limitPrice,
+		SettleCurrency: s.settle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create quote request: %w", err)
+	}
+	return response, nil
+}
+
+// preview prices a market order of the same size for comparison.
+func (s *rfqSession) preview() (*model.Order, error) {
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(s.client).CreateOrderPreview(ctx, &orders.CreateOrderRequest{
+		Order: &model.Order{
+			PortfolioId:  s.portfolioId,
+			ProductId:    s.productId,
+			Side:         s.side,
+			Type:         utils.OrderTypeMarket,
+			BaseQuantity: s.base,
+			QuoteValue:   s.quoteValue,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Order, nil
+}
+
+func (s *rfqSession) accept(quote *orders.CreateQuoteResponse) (string, error) {
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	response, err := orders.NewOrdersService(s.client).AcceptQuote(ctx, &orders.AcceptQuoteRequest{
+		PortfolioId:   s.portfolioId,
+		ProductId:     s.productId,
+		Side:          s.side,
+		ClientOrderId: utils.NewUuidStr(),
+		QuoteId:       quote.QuoteId,
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot accept quote %s: %w", quote.QuoteId, err)
+	}
+	return response.OrderId, nil
+}
+
+func (s *rfqSession) withinBound(price decimal.Decimal) bool {
+	if s.side == utils.OrderSideBuy {
+		return price.LessThanOrEqual(s.maxPrice)
+	}
+	return price.GreaterThanOrEqual(s.maxPrice)
+}
+
+func (s *rfqSession) describe(quote *orders.CreateQuoteResponse, bestPrice decimal.Decimal, preview *model.Order, expires time.Time) {
+	s.printf("quote %s: %s %s", quote.QuoteId, s.side, s.productId)
+	s.printf("  %-17s %s", "best price:", quote.BestPrice)
+	if s.base != "" {
+		if base, err := decimal.NewFromString(s.base); err == nil {
+			s.printf("  %-17s %s", "implied notional:", bestPrice.Mul(base).String())
+		}
+	} else {
+		s.printf("  %-17s %s", "implied notional:", s.quoteValue)
+	}
+	if quote.OrderTotal != "" {
+		s.printf("  %-17s %s", "order total:", quote.OrderTotal)
+	}
+	if quote.PriceInclusiveOfFees != "" {
+		s.printf("  %-17s %s", "price with fees:", quote.PriceInclusiveOfFees)
+	}
+
+	if preview != nil && preview.AverageFilledPrice != "" {
+		s.printf("  %-17s %s%s", "preview price:", preview.AverageFilledPrice, compareToPreview(s.side, bestPrice, preview.AverageFilledPrice))
+	}
+	s.printf("  %-17s %s", "expires at:", expires.Format(time.RFC3339))
+}
+
+// compareToPreview describes how the quote price compares with the preview
+// average price in basis points from the taker's point of view.
+func compareToPreview(side string, bestPrice decimal.Decimal, previewPrice string) string {
+	average, err := decimal.NewFromString(previewPrice)
+	if err != nil || !average.IsPositive() {
+		return ""
+	}
+
+	bps := bestPrice.Sub(average).Div(average).Mul(decimal.NewFromInt(10000))
+	if side != utils.OrderSideBuy {
+		bps = bps.Neg()
+	}
+
+	switch {
+	case bps.IsPositive():
+		return fmt.Sprintf(" (quote is %s bps worse)", bps.StringFixed(1))
+	case bps.IsNegative():
+		return fmt.Sprintf(" (quote is %s bps better)", bps.Neg().StringFixed(1))
+	default:
+		return " (same as quote)"
+	}
+}
+
+// wait shows a countdown until the quote expires. Without a terminal there
+// is nothing to wait for but the expiry.
+func (s *rfqSession) wait(ctx context.Context, expires time.Time) (rfqDecision, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	timer := time.NewTimer(time.Until(expires))
+	defer timer.Stop()
+
+	for {
+		if s.interactive {
+			remaining := time.Until(expires).Round(time.Second)
+			if remaining < 0 {
+				remaining = 0
+			}
+			fmt.Fprintf(s.out, "\r\033[Kexpires in %s  [a/enter] accept  [q] quit", remaining)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.endLine()
+			return rfqQuit, ctx.Err()
+		case <-timer.C:
+			s.endLine()
+			return rfqExpired, nil
+		case <-ticker.C:
+		case key, ok := <-s.keys:
+			if !ok {
+				s.keys = nil
+				continue
+			}
+			switch key {
+			case 'a', 'A', 'y', 'Y', '\r', '\n':
+				s.endLine()
+				if !time.Now().Before(expires) {
+					return rfqExpired, nil
+				}
+				return rfqAccept, nil
+			case 'q', 'Q', 'n', 'N', 3, 27:
+				// 3 is ctrl-c, which raw mode delivers as a key press.
+				s.endLine()
+				return rfqQuit, nil
+			}
+		}
+	}
+}
+
+func (s *rfqSession) endLine() {
+	if s.interactive {
+		fmt.Fprint(s.out, "\r\033[K")
+	}
+}
+
+func (s *rfqSession) printf(format string, args ...any) {
+	fmt.Fprintf(s.out, format+s.eol, args...)
+}
+
+func readRfqKeys(r io.Reader, keys chan<- byte) {
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				fmt.Fprintf(os.Stderr, "unable to read from terminal: %v\r\n", err)
+			}
+			close(keys)
+			return
+		}
+		if n == 1 {
+			keys <- buf[0]
+		}
+	}
+}
+
+func init() {
+	Cmd.AddCommand(rfqCmd)
+	utils.AddPortfolioIdFlag(rfqCmd)
+	utils.AddProductIdFlag(rfqCmd)
+	utils.AddOrderSideFlag(rfqCmd)
+	utils.AddLimitPriceFlag(rfqCmd)
+	utils.AddQuoteValueFlag(rfqCmd)
+	utils.AddBaseQuantityFlag(rfqCmd)
+
+	rfqCmd.Flags().String(utils.SettleCurrencyFlag, "", "The settle currency flag")
+	rfqCmd.Flags().String(rfqMaxPriceFlag, "", "Accept without asking when the best price is at or better than this price")
+	rfqCmd.Flags().Int(rfqRequoteFlag, 0, "Request a new quote when the quote expires, up to this many times")
+
+	rfqCmd.MarkFlagRequired(utils.SideFlag)
+	rfqCmd.MarkFlagRequired(utils.ProductIdFlag)
+	rfqCmd.MarkFlagRequired(utils.LimitPriceFlag)
+
+	rfqCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := utils.ValidateSide(cmd); err != nil {
+			return err
+		}
+		if err := utils.ValidateQuantities(cmd); err != nil {
+			return err
+		}
+		return utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, utils.QuoteValueFlag, utils.LimitPriceFlag, rfqMaxPriceFlag)
+	}
+}
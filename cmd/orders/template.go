@@ -0,0 +1,91 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package orders
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const templateFlag = "template"
+
+// templateFlags are the "orders create" flags a template may set. Client
+// order IDs and wait options are per order and never stored.
+var templateFlags = []string{
+	utils.ProductIdFlag,
+	utils.SideFlag,
+	utils.TypeFlag,
+	utils.BaseQuantityFlag,
+	utils.QuoteValueFlag,
+	utils.LimitPriceFlag,
+	utils.TimeInForceFlag,
+	utils.StartTimeFlag,
+	utils.ExpiryTimeFlag,
+	utils.PortfolioIdFlag,
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage named order templates stored in the primectl config",
+}
+
+// applyOrderTemplate fills every flag the user did not set from the template
+// named by --template, so explicit flags win and the usual validators then
+// check the combined order.
+func applyOrderTemplate(cmd *cobra.Command) error {
+	name := utils.GetFlagStringValue(cmd, templateFlag)
+	if name == "" {
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	template, ok := cfg.Templates[name]
+	if !ok {
+		return fmt.Errorf("no order template named %q", name)
+	}
+
+	flags := make([]string, 0, len(template))
+	for flag := range template {
+		flags = append(flags, flag)
+	}
+	sort.Strings(flags)
+
+	for _, flag := range flags {
+		if cmd.Flags().Lookup(flag) == nil {
+			return fmt.Errorf("order template %q sets unknown flag %s", name, flag)
+		}
+		if cmd.Flags().Changed(flag) {
+			continue
+		}
+		if err := cmd.Flags().Set(flag, template[flag]); err != nil {
+			return fmt.Errorf("order template %q has an invalid %s: %w", name, flag, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	Cmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,61 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package orders
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var deleteTemplateCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an order template",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		name := utils.GetFlagStringValue(cmd, utils.NameFlag)
+		if _, ok := cfg.Templates[name]; !ok {
+			return fmt.Errorf("no order template named %q", name)
+		}
+		delete(cfg.Templates, name)
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, cfg.Templates)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(deleteTemplateCmd)
+
+	deleteTemplateCmd.Flags().String(utils.NameFlag, "", "Template name (Required)")
+	deleteTemplateCmd.MarkFlagRequired(utils.NameFlag)
+}
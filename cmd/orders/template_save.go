@@ -0,0 +1,111 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package orders
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var saveTemplateCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Create or replace an order template from the given order flags",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		template := config.OrderTemplate{}
+		for _, flag := range templateFlags {
+			if cmd.Flags().Changed(flag) {
+				template[flag] = utils.GetFlagStringValue(cmd, flag)
+			}
+		}
+		if len(template) == 0 {
+			return errors.New("a template needs at least one order flag")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+		if cfg.Templates == nil {
+			cfg.Templates = map[string]config.OrderTemplate{}
+		}
+
+		name := utils.GetFlagStringValue(cmd, utils.NameFlag)
+		cfg.Templates[name] = template
+
+		if err := config.Save(cfg); err != nil {
+			return err
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, map[string]config.OrderTemplate{name: template})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	templateCmd.AddCommand(saveTemplateCmd)
+
+	saveTemplateCmd.Flags().String(utils.NameFlag, "", "Template name (Required)")
+	saveTemplateCmd.Flags().String(utils.ProductIdFlag, "", "ID of the product")
+	saveTemplateCmd.Flags().String(utils.SideFlag, "", "Order side, e.g. BUY")
+	saveTemplateCmd.Flags().String(utils.TypeFlag, "", "Order type: MARKET, LIMIT, TWAP, or VWAP")
+	utils.AddBaseQuantityFlag(saveTemplateCmd)
+	utils.AddQuoteValueFlag(saveTemplateCmd)
+	utils.AddLimitPriceFlag(saveTemplateCmd)
+	saveTemplateCmd.Flags().String(utils.TimeInForceFlag, "", "Time in force, e.g. GOOD_UNTIL_CANCELLED")
+	saveTemplateCmd.Flags().String(utils.StartTimeFlag, "", "The start time of the order in UTC (TWAP only)")
+	saveTemplateCmd.Flags().String(utils.ExpiryTimeFlag, "", "The expiry time of the order in UTC (TWAP and limit GTD only)")
+	utils.AddPortfolioIdFlag(saveTemplateCmd)
+
+	saveTemplateCmd.MarkFlagRequired(utils.NameFlag)
+
+	// A template may leave out any flag, so only the values it does set are
+	// checked here; "orders create" validates the complete order.
+	saveTemplateCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if strings.TrimSpace(utils.GetFlagStringValue(cmd, utils.NameFlag)) == "" {
+			return fmt.Errorf("--%s must not be empty", utils.NameFlag)
+		}
+		if cmd.Flags().Changed(utils.TypeFlag) {
+			if err := utils.ValidateOrderTypeName(utils.GetFlagStringValue(cmd, utils.TypeFlag)); err != nil {
+				return err
+			}
+		}
+		if cmd.Flags().Changed(utils.SideFlag) {
+			if err := utils.ValidateSide(cmd); err != nil {
+				return err
+			}
+		}
+		if err := utils.ValidateTimeInForce(cmd); err != nil {
+			return err
+		}
+		if cmd.Flags().Changed(utils.BaseQuantityFlag) && cmd.Flags().Changed(utils.QuoteValueFlag) {
+			if err := utils.ValidateQuantities(cmd); err != nil {
+				return err
+			}
+		}
+		return utils.ValidateAmountFlags(cmd, utils.BaseQuantityFlag, utils.QuoteValueFlag, utils.LimitPriceFlag)
+	}
+}
@@ -0,0 +1,52 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package reports
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputFlag = "output"
+	methodFlag = "method"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "reports",
+	Short: "Trading reports computed from portfolio fills",
+}
+
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.Flags().String(outputFlag, reports.OutputTable, "Output format: "+strings.Join(reports.Outputs, ", "))
+}
+
+func getOutput(cmd *cobra.Command) (string, error) {
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve %s: %w", outputFlag, err)
+	}
+	output = strings.ToLower(output)
+	for _, o := range reports.Outputs {
+		if o == output {
+			return output, nil
+		}
+	}
+	return "", fmt.Errorf("--%s must be one of: %s", outputFlag, strings.Join(reports.Outputs, ", "))
+}
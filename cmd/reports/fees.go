@@ -0,0 +1,166 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package reports
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/commission"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+const (
+	monthFlag     = "month"
+	toleranceFlag = "tolerance"
+)
+
+var feesCmd = &cobra.Command{
+	Use:   "fees",
+	Short: "Commission by product, month and order type, reconciled against the commission rate and invoices",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		entityId, err := utils.GetEntityId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		start, end, err := getFeesPeriod(cmd)
+		if err != nil {
+			return err
+		}
+
+		toleranceStr, err := cmd.Flags().GetString(toleranceFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", toleranceFlag, err)
+		}
+		tolerance, err := decimal.NewFromString(toleranceStr)
+		if err != nil || tolerance.IsNegative() {
+			return fmt.Errorf("--%s must be a non-negative decimal such as 0.01, got %q", toleranceFlag, toleranceStr)
+		}
+
+		output, err := getOutput(cmd)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		ctx, cancel := utils.GetContextWithTimeout()
+		rateResponse, err := commission.NewCommissionService(client).GetPortfolioCommission(ctx, &commission.GetPortfolioCommissionRequest{
+			PortfolioId: portfolioId,
+		})
+		cancel()
+		if err != nil {
+			return fmt.Errorf("cannot get portfolio commission: %w", err)
+		}
+		if rateResponse.Commission == nil {
+			return fmt.Errorf("no commission returned for portfolio %s", portfolioId)
+		}
+		rate, err := rateResponse.Commission.RateNum()
+		if err != nil {
+			return err
+		}
+
+		fills, err := reports.FetchFills(client, portfolioId, start, end, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		orderTypes, failed := reports.FetchOrderTypes(client, portfolioId, reports.OrderIds(fills), utils.GetContextWithTimeout)
+		for orderId, err := range failed {
+			fmt.Fprintf(os.Stderr, "warning: cannot get order %s, its fills are grouped as UNKNOWN: %v\n", orderId, err)
+		}
+
+		groups, err := reports.AggregateFees(fills, orderTypes)
+		if err != nil {
+			return err
+		}
+
+		// Invoices are billed per entity and per whole month, so they are only
+		// compared when --month selects a full billing month.
+		var invoices map[string][]*model.Invoice
+		if utils.GetFlagStringValue(cmd, monthFlag) != "" {
+			invoices, err = reports.FetchInvoices(client, entityId, reports.Months(groups), utils.GetContextWithTimeout)
+			if err != nil {
+				return err
+			}
+		}
+
+		return reports.WriteTables(
+			os.Stdout,
+			output,
+			reports.FeesTable(groups, rate),
+			reports.ReconciliationTable(reports.Reconcile(groups, rate, invoices, tolerance)),
+		)
+	},
+}
+
+// getFeesPeriod uses --month when set and otherwise --start and --end.
+func getFeesPeriod(cmd *cobra.Command) (time.Time, time.Time, error) {
+	month, err := cmd.Flags().GetString(monthFlag)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("could not retrieve %s: %w", monthFlag, err)
+	}
+
+	if month != "" {
+		if cmd.Flags().Changed(utils.StartFlag) || cmd.Flags().Changed(utils.EndFlag) {
+			return time.Time{}, time.Time{}, fmt.Errorf("--%s cannot be combined with --%s or --%s", monthFlag, utils.StartFlag, utils.EndFlag)
+		}
+		start, err := time.Parse("2006-01", month)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("--%s must be a billing month such as 2026-01, got %q", monthFlag, month)
+		}
+		return start, start.AddDate(0, 1, 0), nil
+	}
+
+	start, end, err := utils.GetStartEndFlagsAsTime(cmd)
+	if err != nil {
+		return start, end, err
+	}
+	if start.IsZero() {
+		return start, end, fmt.Errorf("either --%s or --%s is required", monthFlag, utils.StartFlag)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return start, end, nil
+}
+
+func init() {
+	Cmd.AddCommand(feesCmd)
+
+	utils.AddPortfolioIdFlag(feesCmd)
+	utils.AddEntityIdFlag(feesCmd)
+	utils.AddStartEndFlags(feesCmd)
+	addOutputFlag(feesCmd)
+	feesCmd.Flags().String(monthFlag, "", "Billing month, e.g. 2026-01. Replaces --start and --end")
+	feesCmd.Flags().String(toleranceFlag, "0.01", "Relative difference allowed before a total is flagged, e.g. 0.01 for 1%")
+}
@@ -0,0 +1,110 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package reports
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/reports"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var pnlCmd = &cobra.Command{
+	Use:   "pnl",
+	Short: "Cost basis, realized and unrealized PnL per product from portfolio fills",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		start, end, err := utils.GetStartEndFlagsAsTime(cmd)
+		if err != nil {
+			return err
+		}
+		if end.IsZero() {
+			end = time.Now()
+		}
+
+		method, err := cmd.Flags().GetString(methodFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", methodFlag, err)
+		}
+		method = strings.ToLower(method)
+		if err := reports.ValidateMethod(method); err != nil {
+			return err
+		}
+
+		output, err := getOutput(cmd)
+		if err != nil {
+			return err
+		}
+
+		productIds, err := cmd.Flags().GetStringSlice(utils.ProductIdsFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.ProductIdsFlag, err)
+		}
+
+		cmd.SilenceUsage = true
+		fills, err := reports.FetchFills(client, portfolioId, start, end, utils.GetContextWithTimeout)
+		if err != nil {
+			return err
+		}
+
+		results, err := reports.ComputePnl(reports.FilterProducts(fills, productIds), method)
+		if err != nil {
+			return err
+		}
+
+		// Marks are taken from the last hourly close at the end of the period.
+		for _, p := range results {
+			if p.Position.IsZero() {
+				continue
+			}
+			ctx, cancel := utils.GetContextWithTimeout()
+			mark, err := reports.LatestClose(ctx, client, portfolioId, p.ProductId, end)
+			cancel()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: cannot mark %s: %v\n", p.ProductId, err)
+				continue
+			}
+			p.MarkToMarket(mark)
+		}
+
+		return reports.PnlTable(results).Write(os.Stdout, output)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(pnlCmd)
+
+	utils.AddPortfolioIdFlag(pnlCmd)
+	utils.AddStartEndFlags(pnlCmd)
+	utils.AddProductIdsFlag(pnlCmd)
+	addOutputFlag(pnlCmd)
+	pnlCmd.Flags().String(methodFlag, reports.MethodFifo, "Cost basis method: "+strings.Join(reports.Methods, ", "))
+
+	pnlCmd.MarkFlagRequired(utils.StartFlag)
+}
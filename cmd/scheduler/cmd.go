@@ -0,0 +1,81 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/scheduler"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	configFlag   = "config"
+	stateDirFlag = "state-dir"
+	jobFlag      = "job"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "Run primectl commands on cron schedules",
+}
+
+func addConfigFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP(configFlag, "c", "", "Jobs file (required)")
+	cmd.MarkFlagRequired(configFlag)
+}
+
+func addStateDirFlag(cmd *cobra.Command) {
+	cmd.Flags().String(stateDirFlag, "", "Directory for run history, state and locks. Uses the jobs file's state_dir or the config directory if blank")
+}
+
+func loadConfig(cmd *cobra.Command) (*scheduler.Config, error) {
+	path, err := cmd.Flags().GetString(configFlag)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve %s: %w", configFlag, err)
+	}
+	return scheduler.LoadConfig(path)
+}
+
+// getStateDir prefers the flag, then the jobs file, then the default.
+func getStateDir(cmd *cobra.Command, cfg *scheduler.Config) (string, error) {
+	dir, err := cmd.Flags().GetString(stateDirFlag)
+	if err != nil {
+		return "", fmt.Errorf("could not retrieve %s: %w", stateDirFlag, err)
+	}
+	if dir != "" {
+		return dir, nil
+	}
+	if cfg != nil && cfg.StateDir != "" {
+		return cfg.StateDir, nil
+	}
+	return scheduler.DefaultStateDir()
+}
+
+// getLocation prefers the jobs file's timezone over --timezone and
+// primeCliTimezone.
+func getLocation(cmd *cobra.Command, cfg *scheduler.Config) (*time.Location, error) {
+	if cfg.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", cfg.Timezone, err)
+		}
+		return loc, nil
+	}
+	return utils.GetLocation(cmd)
+}
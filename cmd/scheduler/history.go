@@ -0,0 +1,71 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/scheduler"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show recorded job runs, oldest first",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cfg *scheduler.Config
+		if path := utils.GetFlagStringValue(cmd, configFlag); path != "" {
+			loaded, err := scheduler.LoadConfig(path)
+			if err != nil {
+				return err
+			}
+			cfg = loaded
+		}
+
+		dir, err := getStateDir(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		job, err := cmd.Flags().GetString(jobFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", jobFlag, err)
+		}
+
+		limit, err := cmd.Flags().GetInt(utils.LimitFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.LimitFlag, err)
+		}
+
+		cmd.SilenceUsage = true
+		runs, err := scheduler.History(dir, job, limit)
+		if err != nil {
+			return err
+		}
+
+		return utils.PrintJsonDocs(cmd, runs)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringP(configFlag, "c", "", "Jobs file, used to find its state_dir")
+	addStateDirFlag(historyCmd)
+	historyCmd.Flags().String(jobFlag, "", "Only show runs of this job")
+	historyCmd.Flags().Int(utils.LimitFlag, 20, "Maximum number of runs to show, 0 for all")
+}
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/scheduler"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+type jobSummary struct {
+	Name          string     `json:"name"`
+	Schedule      string     `json:"schedule"`
+	Command       string     `json:"command"`
+	CatchUp       string     `json:"catch_up"`
+	NextRun       time.Time  `json:"next_run"`
+	LastScheduled *time.Time `json:"last_scheduled,omitempty"`
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show each job with its next and last schedule times",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		dir, err := getStateDir(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		loc, err := getLocation(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+		store, err := scheduler.OpenStore(dir)
+		if err != nil {
+			return err
+		}
+
+		next := scheduler.New(cfg, store, loc, "").NextRuns(time.Now())
+
+		summaries := make([]jobSummary, 0, len(cfg.Jobs))
+		for _, job := range cfg.Jobs {
+			summary := jobSummary{
+				Name:     job.Name,
+				Schedule: job.Schedule,
+				Command:  job.Command,
+				CatchUp:  job.CatchUp,
+				NextRun:  next[job.Name],
+			}
+			if last := store.LastScheduled(job.Name); !last.IsZero() {
+				last = last.In(loc)
+				summary.LastScheduled = &last
+			}
+			summaries = append(summaries, summary)
+		}
+
+		return utils.PrintJsonDocs(cmd, summaries)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(listCmd)
+
+	addConfigFlag(listCmd)
+	addStateDirFlag(listCmd)
+}
@@ -0,0 +1,101 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/scheduler"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run jobs on their cron schedules until interrupted",
+	Long: `Run each job in a jobs file as a primectl subprocess on its cron schedule.
+
+A job is skipped if its previous run, from this or another scheduler sharing
+the state directory, is still going. Runs missed while the scheduler was
+stopped are handled by the job's catch_up policy: skip (default), once or all.
+Every run is appended to the history shown by "scheduler history".
+
+Example jobs.yaml:
+
+  timezone: America/New_York
+  jobs:
+    - name: claim-rewards
+      schedule: "0 6 * * *"
+      command: staking claim-rewards --wallet-id 00000000-0000-0000-0000-000000000000
+      jitter: 5m
+      catch_up: once
+    - name: daily-snapshot
+      schedule: "@daily"
+      command: snapshot create --out /var/lib/primectl/snapshots
+      timeout: 10m
+    - name: weekday-sweep
+      schedule: "30 16 * * mon-fri"
+      command: futures schedule-sweep --currency USD --amount 1000`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig(cmd)
+		if err != nil {
+			return err
+		}
+
+		dir, err := getStateDir(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		loc, err := getLocation(cmd, cfg)
+		if err != nil {
+			return err
+		}
+
+		executable, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("cannot locate the primectl executable: %w", err)
+		}
+
+		cmd.SilenceUsage = true
+		store, err := scheduler.OpenStore(dir)
+		if err != nil {
+			return err
+		}
+
+		s := scheduler.New(cfg, store, loc, executable)
+		s.Log = func(format string, args ...any) {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", time.Now().In(loc).Format(time.RFC3339), fmt.Sprintf(format, args...))
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		fmt.Fprintf(os.Stderr, "scheduling %d jobs, state in %s\n", len(cfg.Jobs), dir)
+		s.Run(ctx)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(runCmd)
+
+	addConfigFlag(runCmd)
+	addStateDirFlag(runCmd)
+}
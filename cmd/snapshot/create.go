@@ -0,0 +1,82 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/snapshot"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Capture a timestamped, checksummed snapshot bundle",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		outDir, err := cmd.Flags().GetString(outFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", outFlag, err)
+		}
+
+		// Entity sections are recorded as failed rather than rejected when no
+		// entity ID is available, so the rest of the bundle is still captured.
+		entityId, err := cmd.Flags().GetString(utils.EntityIdFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.EntityIdFlag, err)
+		}
+		if entityId == "" && client.Credentials() != nil {
+			entityId = client.Credentials().EntityId
+		}
+
+		cmd.SilenceUsage = true
+		bundle, err := snapshot.Capture(client, portfolioId, entityId, outDir, utils.GetContextWithTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot: %w", err)
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, map[string]any{
+			"dir":      bundle.Dir,
+			"manifest": bundle.Manifest,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(createCmd)
+
+	createCmd.Flags().String(outFlag, "", "Directory to write the snapshot bundle under (Required)")
+	utils.AddPortfolioIdFlag(createCmd)
+	utils.AddEntityIdFlag(createCmd)
+
+	createCmd.MarkFlagRequired(outFlag)
+}
@@ -0,0 +1,60 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package snapshot
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/snapshot"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Report balance, wallet, position and order changes between two snapshots",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		a, err := snapshot.Load(args[0])
+		if err != nil {
+			return err
+		}
+
+		b, err := snapshot.Load(args[1])
+		if err != nil {
+			return err
+		}
+
+		report, err := snapshot.Diff(a, b)
+		if err != nil {
+			return fmt.Errorf("failed to diff snapshots: %w", err)
+		}
+
+		jsonResponse, err := utils.FormatResponseAsJson(cmd, report)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(jsonResponse)
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(diffCmd)
+}
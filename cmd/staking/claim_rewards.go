@@ -45,9 +45,14 @@ var claimRewardsCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		request := &primeStaking.ClaimStakingRewardsRequest{
 			PortfolioId:    portfolioId,
-			WalletId:       utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			WalletId:       walletId,
 			IdempotencyKey: idempotencyKey,
 		}
 
@@ -71,7 +76,7 @@ var claimRewardsCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
@@ -80,6 +85,11 @@ func init() {
 	utils.AddPortfolioIdFlag(claimRewardsCmd)
 	utils.AddWalletIdFlag(claimRewardsCmd)
 	utils.AddIdempotencyKeyFlag(claimRewardsCmd)
+	utils.AddWaitFlags(claimRewardsCmd)
 
 	claimRewardsCmd.Flags().String(utils.AmountFlag, "", "Optional amount of rewards to claim. If omitted, the full available reward amount is claimed")
+
+	claimRewardsCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
@@ -40,9 +40,14 @@ var getUnstakingStatusCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		request := &primeStaking.GetUnstakingStatusRequest{
 			PortfolioId: portfolioId,
-			WalletId:    utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			WalletId:    walletId,
 		}
 
 		ctx, cancel := utils.GetContextWithTimeout()
@@ -67,7 +67,7 @@ var portfolioStakeInitiateCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
@@ -75,8 +75,13 @@ func init() {
 	Cmd.AddCommand(portfolioStakeInitiateCmd)
 	utils.AddPortfolioIdFlag(portfolioStakeInitiateCmd)
 	utils.AddIdempotencyKeyFlag(portfolioStakeInitiateCmd)
+	utils.AddWaitFlags(portfolioStakeInitiateCmd)
 
 	portfolioStakeInitiateCmd.Flags().String(utils.SymbolFlag, "", "Currency symbol to stake (e.g. ETH)")
 	portfolioStakeInitiateCmd.Flags().String(utils.AmountFlag, "", "Amount to stake")
 	portfolioStakeInitiateCmd.Flags().String(utils.StakeProtocolFlag, "", "Optional staking protocol identifier")
+
+	portfolioStakeInitiateCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
@@ -67,7 +67,7 @@ var portfolioUnstakeCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindUnstake, portfolioId, response.TransactionId)
 	},
 }
 
@@ -75,8 +75,13 @@ func init() {
 	Cmd.AddCommand(portfolioUnstakeCmd)
 	utils.AddPortfolioIdFlag(portfolioUnstakeCmd)
 	utils.AddIdempotencyKeyFlag(portfolioUnstakeCmd)
+	utils.AddWaitFlags(portfolioUnstakeCmd)
 
 	portfolioUnstakeCmd.Flags().String(utils.SymbolFlag, "", "Currency symbol to unstake (e.g. ETH)")
 	portfolioUnstakeCmd.Flags().String(utils.AmountFlag, "", "Amount to unstake")
 	portfolioUnstakeCmd.Flags().String(utils.StakeProtocolFlag, "", "Optional staking protocol identifier")
+
+	portfolioUnstakeCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
@@ -40,9 +40,14 @@ var previewUnstakeCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		request := &primeStaking.PreviewUnstakeRequest{
 			PortfolioId: portfolioId,
-			WalletId:    utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			WalletId:    walletId,
 			Amount:      utils.GetFlagStringValue(cmd, utils.AmountFlag),
 		}
 
@@ -71,4 +76,8 @@ func init() {
 	utils.AddWalletIdFlag(previewUnstakeCmd)
 
 	previewUnstakeCmd.Flags().String(utils.AmountFlag, "", "Amount to preview unstaking")
+
+	previewUnstakeCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
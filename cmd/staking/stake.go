@@ -45,9 +45,14 @@ var createStakeCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		request := &primeStaking.CreateStakeRequest{
 			PortfolioId:    portfolioId,
-			WalletId:       utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			WalletId:       walletId,
 			IdempotencyKey: idempotencyKey,
 		}
 
@@ -71,7 +76,7 @@ var createStakeCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
@@ -80,6 +85,11 @@ func init() {
 	utils.AddPortfolioIdFlag(createStakeCmd)
 	utils.AddWalletIdFlag(createStakeCmd)
 	utils.AddIdempotencyKeyFlag(createStakeCmd)
+	utils.AddWaitFlags(createStakeCmd)
 
 	createStakeCmd.Flags().String(utils.AmountFlag, "", "Optional amount to stake. If omitted, the wallet will stake or unstake the maximum amount available")
+
+	createStakeCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
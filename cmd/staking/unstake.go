@@ -45,9 +45,14 @@ var createUnstakeCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		request := &primeStaking.CreateUnstakeRequest{
 			PortfolioId:    portfolioId,
-			WalletId:       utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			WalletId:       walletId,
 			IdempotencyKey: idempotencyKey,
 		}
 
@@ -71,7 +76,7 @@ var createUnstakeCmd = &cobra.Command{
 
 		fmt.Println(jsonResponse)
 
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindUnstake, portfolioId, response.TransactionId)
 	},
 }
 
@@ -80,6 +85,11 @@ func init() {
 	utils.AddPortfolioIdFlag(createUnstakeCmd)
 	utils.AddWalletIdFlag(createUnstakeCmd)
 	utils.AddIdempotencyKeyFlag(createUnstakeCmd)
+	utils.AddWaitFlags(createUnstakeCmd)
 
 	createUnstakeCmd.Flags().String(utils.AmountFlag, "", "Optional amount to stake. If omitted, the wallet will stake or unstake the maximum amount available")
+
+	createUnstakeCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
@@ -0,0 +1,87 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/coinbase-samples/prime-cli/stream"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	maxMessagesFlag   = "max-messages"
+	maxReconnectsFlag = "max-reconnects"
+
+	// websocketUrlEnv overrides the feed URL.
+	websocketUrlEnv = "primeCliWebsocketUrl"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "stream",
+	Short: "Stream live data from the Prime WebSocket feed as NDJSON",
+}
+
+func addStreamFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(maxMessagesFlag, 0, "Stop after this many messages. Streams until interrupted if zero")
+	cmd.Flags().Int(maxReconnectsFlag, 0, "Give up after this many consecutive reconnect attempts. Retries forever if zero")
+}
+
+// runStream subscribes to channel and writes feed messages to stdout and
+// connection notices to stderr.
+func runStream(cmd *cobra.Command, channel, portfolioId string) error {
+	client, err := utils.GetClientFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	productIds, err := cmd.Flags().GetStringSlice(utils.ProductIdsFlag)
+	if err != nil {
+		return fmt.Errorf("could not retrieve %s: %w", utils.ProductIdsFlag, err)
+	}
+
+	maxMessages, err := cmd.Flags().GetInt(maxMessagesFlag)
+	if err != nil {
+		return fmt.Errorf("could not retrieve %s: %w", maxMessagesFlag, err)
+	}
+
+	maxReconnects, err := cmd.Flags().GetInt(maxReconnectsFlag)
+	if err != nil {
+		return fmt.Errorf("could not retrieve %s: %w", maxReconnectsFlag, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cmd.SilenceUsage = true
+	return stream.Run(ctx, stream.Options{
+		Url:           os.Getenv(websocketUrlEnv),
+		Credentials:   client.Credentials(),
+		Out:           os.Stdout,
+		Status:        os.Stderr,
+		MaxMessages:   maxMessages,
+		MaxReconnects: maxReconnects,
+	}, []stream.Subscription{{
+		Channel:     channel,
+		ProductIds:  productIds,
+		PortfolioId: portfolioId,
+	}})
+}
@@ -0,0 +1,38 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"github.com/coinbase-samples/prime-cli/stream"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var heartbeatsCmd = &cobra.Command{
+	Use:   "heartbeats",
+	Short: "Stream heartbeats to check feed connectivity",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStream(cmd, stream.ChannelHeartbeats, "")
+	},
+}
+
+func init() {
+	Cmd.AddCommand(heartbeatsCmd)
+
+	utils.AddProductIdsFlag(heartbeatsCmd)
+	addStreamFlags(heartbeatsCmd)
+}
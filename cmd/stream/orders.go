@@ -0,0 +1,51 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/stream"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var ordersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Stream order updates and fills for a portfolio",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		return runStream(cmd, stream.ChannelOrders, portfolioId)
+	},
+}
+
+func init() {
+	Cmd.AddCommand(ordersCmd)
+
+	utils.AddProductIdsFlag(ordersCmd)
+	utils.AddPortfolioIdFlag(ordersCmd)
+	addStreamFlags(ordersCmd)
+}
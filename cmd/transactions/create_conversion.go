@@ -45,14 +45,28 @@ var createConversionCmd = &cobra.Command{
 			return err
 		}
 
+		sourceWalletId, err := utils.GetSourceWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
+		destinationWalletId, err := utils.GetDestinationWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
+		if err := utils.ValidateAssetAmount(cmd, client, utils.AmountFlag, utils.GetFlagStringValue(cmd, utils.SourceSymbolFlag)); err != nil {
+			return err
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
 		request := &transactions.CreateConversionRequest{
 			PortfolioId:         portfolioId,
-			SourceWalletId:      utils.GetFlagStringValue(cmd, utils.SourceWalletIdFlag),
+			SourceWalletId:      sourceWalletId,
 			SourceSymbol:        utils.GetFlagStringValue(cmd, utils.SourceSymbolFlag),
-			DestinationWalletId: utils.GetFlagStringValue(cmd, utils.DestinationWalletIdFlag),
+			DestinationWalletId: destinationWalletId,
 			DestinationSymbol:   utils.GetFlagStringValue(cmd, utils.DestinationSymbolFlag),
 			IdempotencyKey:      idempotencyKey,
 			Amount:              utils.GetFlagStringValue(cmd, utils.AmountFlag),
@@ -69,32 +83,34 @@ var createConversionCmd = &cobra.Command{
 		}
 
 		fmt.Println(jsonResponse)
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
 func init() {
 	Cmd.AddCommand(createConversionCmd)
 
-	createConversionCmd.Flags().String(utils.SourceWalletIdFlag, "", "ID of the source wallet (Required)")
+	utils.AddSourceWalletIdFlag(createConversionCmd)
 	createConversionCmd.Flags().String(utils.SourceSymbolFlag, "", "Symbol of the source wallet (Required)")
-	createConversionCmd.Flags().String(utils.DestinationWalletIdFlag, "", "ID of the destination wallet (Required)")
+	utils.AddDestinationWalletIdFlag(createConversionCmd)
 	createConversionCmd.Flags().String(utils.DestinationSymbolFlag, "", "Symbol of the destination wallet (Required)")
 	createConversionCmd.Flags().String(utils.AmountFlag, "", "Conversion size (Required)")
 	utils.AddPortfolioIdFlag(createConversionCmd)
 	utils.AddIdempotencyKeyFlag(createConversionCmd)
+	utils.AddWaitFlags(createConversionCmd)
 
-	createConversionCmd.MarkFlagRequired(utils.SourceWalletIdFlag)
 	createConversionCmd.MarkFlagRequired(utils.SourceSymbolFlag)
-	createConversionCmd.MarkFlagRequired(utils.DestinationWalletIdFlag)
 	createConversionCmd.MarkFlagRequired(utils.DestinationSymbolFlag)
 	createConversionCmd.MarkFlagRequired(utils.AmountFlag)
 
 	createConversionCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
-		if err := utils.ValidateUUIDFlag(cmd, utils.SourceWalletIdFlag); err != nil {
+		if err := utils.ValidateOptionalUUIDFlag(cmd, utils.SourceWalletIdFlag); err != nil {
+			return err
+		}
+		if err := utils.ValidateOptionalUUIDFlag(cmd, utils.DestinationWalletIdFlag); err != nil {
 			return err
 		}
-		if err := utils.ValidateUUIDFlag(cmd, utils.DestinationWalletIdFlag); err != nil {
+		if err := utils.ValidateAmountFlags(cmd, utils.AmountFlag); err != nil {
 			return err
 		}
 		return nil
@@ -41,9 +41,9 @@ var createOnchainTransactionCmd = &cobra.Command{
 			return err
 		}
 
-		walletId := utils.GetFlagStringValue(cmd, utils.WalletIdFlag)
-		if walletId == "" {
-			return fmt.Errorf("wallet ID is required")
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
 		}
 
 		rawTxn := utils.GetFlagStringValue(cmd, utils.RawUnsignedTransactionFlag)
@@ -84,7 +84,7 @@ var createOnchainTransactionCmd = &cobra.Command{
 		}
 
 		fmt.Println(jsonResponse)
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
@@ -95,11 +95,11 @@ func init() {
 	createOnchainTransactionCmd.Flags().String(utils.UrlFlag, "", "RPC URL")
 	createOnchainTransactionCmd.Flags().Bool(utils.SkipBroadcastFlag, false, "Skip broadcast")
 	createOnchainTransactionCmd.Flags().String(utils.ChainIdFlag, "", "Chain ID")
-	createOnchainTransactionCmd.Flags().String(utils.WalletIdFlag, "", "Wallet ID (Required)")
+	utils.AddWalletIdFlag(createOnchainTransactionCmd)
 	utils.AddPortfolioIdFlag(createOnchainTransactionCmd)
+	utils.AddWaitFlags(createOnchainTransactionCmd)
 	createOnchainTransactionCmd.Flags().Bool(utils.DisableDynamicGasFlag, false, "Disable dynamic gas")
 	createOnchainTransactionCmd.Flags().String(utils.ReplacedTransactionIdFlag, "", "Replaced transaction ID")
 
 	createOnchainTransactionCmd.MarkFlagRequired(utils.RawUnsignedTransactionFlag)
-	createOnchainTransactionCmd.MarkFlagRequired(utils.WalletIdFlag)
 }
@@ -45,14 +45,28 @@ var createTransferCmd = &cobra.Command{
 			return err
 		}
 
+		sourceWalletId, err := utils.GetSourceWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
+		destinationWalletId, err := utils.GetDestinationWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
+		if err := utils.ValidateAssetAmount(cmd, client, utils.AmountFlag, utils.GetFlagStringValue(cmd, utils.SymbolFlag)); err != nil {
+			return err
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
 		request := &transactions.CreateWalletTransferRequest{
 			PortfolioId:         portfolioId,
-			SourceWalletId:      utils.GetFlagStringValue(cmd, utils.SourceWalletIdFlag),
+			SourceWalletId:      sourceWalletId,
 			Symbol:              utils.GetFlagStringValue(cmd, utils.SymbolFlag),
-			DestinationWalletId: utils.GetFlagStringValue(cmd, utils.DestinationWalletIdFlag),
+			DestinationWalletId: destinationWalletId,
 			IdempotencyKey:      idempotencyKey,
 			Amount:              utils.GetFlagStringValue(cmd, utils.AmountFlag),
 		}
@@ -68,30 +82,32 @@ var createTransferCmd = &cobra.Command{
 		}
 
 		fmt.Println(jsonResponse)
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
 func init() {
 	Cmd.AddCommand(createTransferCmd)
 
-	createTransferCmd.Flags().String(utils.SourceWalletIdFlag, "", "ID of the source wallet (Required)")
+	utils.AddSourceWalletIdFlag(createTransferCmd)
 	createTransferCmd.Flags().String(utils.SymbolFlag, "", "Symbol of the asset to be transferred (Required)")
-	createTransferCmd.Flags().String(utils.DestinationWalletIdFlag, "", "ID of the destination wallet (Required)")
+	utils.AddDestinationWalletIdFlag(createTransferCmd)
 	createTransferCmd.Flags().String(utils.AmountFlag, "", "Conversion size (Required)")
 	utils.AddPortfolioIdFlag(createTransferCmd)
 	utils.AddIdempotencyKeyFlag(createTransferCmd)
+	utils.AddWaitFlags(createTransferCmd)
 
-	createTransferCmd.MarkFlagRequired(utils.SourceWalletIdFlag)
 	createTransferCmd.MarkFlagRequired(utils.SymbolFlag)
-	createTransferCmd.MarkFlagRequired(utils.DestinationWalletIdFlag)
 	createTransferCmd.MarkFlagRequired(utils.AmountFlag)
 
 	createTransferCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
-		if err := utils.ValidateUUIDFlag(cmd, utils.SourceWalletIdFlag); err != nil {
+		if err := utils.ValidateOptionalUUIDFlag(cmd, utils.SourceWalletIdFlag); err != nil {
+			return err
+		}
+		if err := utils.ValidateOptionalUUIDFlag(cmd, utils.DestinationWalletIdFlag); err != nil {
 			return err
 		}
-		if err := utils.ValidateUUIDFlag(cmd, utils.DestinationWalletIdFlag); err != nil {
+		if err := utils.ValidateAmountFlags(cmd, utils.AmountFlag); err != nil {
 			return err
 		}
 		return nil
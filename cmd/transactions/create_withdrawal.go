@@ -18,13 +18,18 @@ package transactions
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/coinbase-samples/prime-cli/resolver"
 	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/client"
 	"github.com/coinbase/prime-sdk-go/model"
 	"github.com/coinbase/prime-sdk-go/transactions"
 	"github.com/spf13/cobra"
 )
 
+const destinationBlockchain = "DESTINATION_BLOCKCHAIN"
+
 var createWithdrawalCmd = &cobra.Command{
 	Use:   "create-withdrawal",
 	Short: "Create an external withdrawal.",
@@ -60,12 +65,31 @@ var createWithdrawalCmd = &cobra.Command{
 			return err
 		}
 
+		sourceWalletId, err := utils.GetSourceWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
+		if err := utils.ValidateAssetAmount(cmd, client, utils.AmountFlag, utils.GetFlagStringValue(cmd, utils.SymbolFlag)); err != nil {
+			return err
+		}
+
+		destination, err := resolveDestination(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+		if destination != nil {
+			address = destination.Address
+			accountIdentifier = destination.AccountIdentifier
+			fmt.Fprintf(os.Stderr, "destination: %s\n", destination.Describe())
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
 		request := &transactions.CreateWalletWithdrawalRequest{
 			PortfolioId:       portfolioId,
-			SourceWalletId:    utils.GetFlagStringValue(cmd, utils.SourceWalletIdFlag),
+			SourceWalletId:    sourceWalletId,
 			Symbol:            utils.GetFlagStringValue(cmd, utils.SymbolFlag),
 			DestinationType:   utils.GetFlagStringValue(cmd, utils.DestinationTypeFlag),
 			IdempotencyKey:    idempotencyKey,
@@ -84,25 +108,83 @@ var createWithdrawalCmd = &cobra.Command{
 		}
 
 		fmt.Println(jsonResponse)
-		return nil
+		return utils.WaitIfRequested(cmd, client, utils.WaitKindTransaction, portfolioId, response.TransactionId)
 	},
 }
 
+// resolveDestination looks up the withdrawal destination when --to or
+// --strict is set. It returns nil when neither flag is used.
+func resolveDestination(cmd *cobra.Command, c client.RestClient, portfolioId string) (*resolver.Destination, error) {
+	name := utils.GetFlagStringValue(cmd, utils.ToFlag)
+	strict := utils.GetFlagBoolValue(cmd, utils.StrictFlag)
+	if name == "" && !strict {
+		return nil, nil
+	}
+
+	r, err := resolver.New(c)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	symbol := utils.GetFlagStringValue(cmd, utils.SymbolFlag)
+	if name != "" {
+		destination, err := r.DestinationByName(ctx, portfolioId, symbol, name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve --%s: %w", utils.ToFlag, err)
+		}
+		return destination, nil
+	}
+
+	destination, err := r.VerifyDestination(ctx, portfolioId, symbol,
+		utils.GetFlagStringValue(cmd, utils.BlockchainAddressFlag),
+		utils.GetFlagStringValue(cmd, utils.AccountIdentifierFlag))
+	if err != nil {
+		return nil, fmt.Errorf("refusing withdrawal: %w", err)
+	}
+	return destination, nil
+}
+
 func init() {
 	Cmd.AddCommand(createWithdrawalCmd)
 
-	createWithdrawalCmd.Flags().String(utils.SourceWalletIdFlag, "", "ID of the source wallet (Required)")
+	utils.AddSourceWalletIdFlag(createWithdrawalCmd)
 	createWithdrawalCmd.Flags().String(utils.SymbolFlag, "", "Symbol of the currency (Required)")
 	createWithdrawalCmd.Flags().String(utils.DestinationTypeFlag, "", "Destination type: DESTINATION_BLOCKCHAIN, DESTINATION_PAYMENT_METHOD, DESTINATION_WALLET, or DESTINATION_COUNTERPARTY (Required)")
 	createWithdrawalCmd.Flags().String(utils.AmountFlag, "", "Amount to withdraw (Required)")
 	createWithdrawalCmd.Flags().String(utils.PaymentMethodIdFlag, "", "ID of the payment method")
 	createWithdrawalCmd.Flags().String(utils.BlockchainAddressFlag, "", "Blockchain address")
 	createWithdrawalCmd.Flags().String(utils.AccountIdentifierFlag, "", "Account identifier")
+	createWithdrawalCmd.Flags().String(utils.ToFlag, "", "Address book or onchain address group entry name to send to; implies DESTINATION_BLOCKCHAIN")
+	createWithdrawalCmd.Flags().Bool(utils.StrictFlag, false, "Refuse addresses that are not in the address book or an onchain address group")
 	utils.AddPortfolioIdFlag(createWithdrawalCmd)
 	utils.AddIdempotencyKeyFlag(createWithdrawalCmd)
+	utils.AddWaitFlags(createWithdrawalCmd)
 
-	createWithdrawalCmd.MarkFlagRequired(utils.SourceWalletIdFlag)
 	createWithdrawalCmd.MarkFlagRequired(utils.SymbolFlag)
 	createWithdrawalCmd.MarkFlagRequired(utils.DestinationTypeFlag)
 	createWithdrawalCmd.MarkFlagRequired(utils.AmountFlag)
+	createWithdrawalCmd.MarkFlagsMutuallyExclusive(utils.ToFlag, utils.BlockchainAddressFlag)
+	createWithdrawalCmd.MarkFlagsMutuallyExclusive(utils.ToFlag, utils.AccountIdentifierFlag)
+
+	createWithdrawalCmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed(utils.ToFlag) && !cmd.Flags().Changed(utils.DestinationTypeFlag) {
+			if err := cmd.Flags().Set(utils.DestinationTypeFlag, destinationBlockchain); err != nil {
+				return err
+			}
+		}
+
+		if cmd.Flags().Changed(utils.ToFlag) || utils.GetFlagBoolValue(cmd, utils.StrictFlag) {
+			if destinationType := utils.GetFlagStringValue(cmd, utils.DestinationTypeFlag); destinationType != destinationBlockchain {
+				return fmt.Errorf("--%s and --%s require --%s %s, got %q", utils.ToFlag, utils.StrictFlag, utils.DestinationTypeFlag, destinationBlockchain, destinationType)
+			}
+		}
+		if utils.GetFlagBoolValue(cmd, utils.StrictFlag) && !cmd.Flags().Changed(utils.ToFlag) && utils.GetFlagStringValue(cmd, utils.BlockchainAddressFlag) == "" {
+			return fmt.Errorf("--%s requires --%s or --%s", utils.StrictFlag, utils.BlockchainAddressFlag, utils.ToFlag)
+		}
+
+		return utils.ValidateAmountFlags(cmd, utils.AmountFlag)
+	}
 }
@@ -42,7 +42,7 @@ var listWalletTransactionsCmd = &cobra.Command{
 			return err
 		}
 
-		walletId, err := cmd.Flags().GetString(utils.WalletIdFlag)
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
 		if err != nil {
 			return err
 		}
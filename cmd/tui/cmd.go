@@ -0,0 +1,60 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/tui"
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+const refreshFlag = "refresh"
+
+var Cmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Full-screen dashboard of balances, open orders, fills and pending transactions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := utils.GetClientFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+
+		portfolioId, err := utils.GetPortfolioId(cmd, client)
+		if err != nil {
+			return err
+		}
+
+		refresh, err := cmd.Flags().GetDuration(refreshFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", refreshFlag, err)
+		}
+		if refresh < time.Second {
+			return fmt.Errorf("--%s must be at least 1s", refreshFlag)
+		}
+
+		cmd.SilenceUsage = true
+		return tui.New(client, portfolioId, refresh).Run()
+	},
+}
+
+func init() {
+	utils.AddPortfolioIdFlag(Cmd)
+	Cmd.Flags().Duration(refreshFlag, 10*time.Second, "How often to refresh the panes")
+}
@@ -0,0 +1,63 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wait
+
+import (
+	"fmt"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Block until an order, transaction, unstake or advanced transfer reaches a terminal status",
+}
+
+func newWaitCmd(kind, short string) *cobra.Command {
+	waitCmd := &cobra.Command{
+		Use:   kind + " <id>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := utils.GetClientFromEnv()
+			if err != nil {
+				return fmt.Errorf("failed to initialize client: %w", err)
+			}
+
+			portfolioId, err := utils.GetPortfolioId(cmd, client)
+			if err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+			return utils.WaitForTerminalStatus(cmd, client, kind, portfolioId, args[0])
+		},
+	}
+
+	utils.AddPortfolioIdFlag(waitCmd)
+	utils.AddWaitTimeoutFlag(waitCmd)
+
+	return waitCmd
+}
+
+func init() {
+	Cmd.AddCommand(newWaitCmd(utils.WaitKindOrder, "Wait for an order to be filled, cancelled or expire"))
+	Cmd.AddCommand(newWaitCmd(utils.WaitKindTransaction, "Wait for a transaction to complete or fail"))
+	Cmd.AddCommand(newWaitCmd(utils.WaitKindUnstake, "Wait for the transaction created by an unstake request to complete or fail"))
+	Cmd.AddCommand(newWaitCmd(utils.WaitKindAdvancedTransfer, "Wait for an advanced transfer to complete, fail, be cancelled or expire"))
+}
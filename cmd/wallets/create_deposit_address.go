@@ -38,12 +38,17 @@ var createWalletDepositAddressCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
 		request := &wallets.CreateWalletAddressRequest{
 			PortfolioId: portfolioId,
-			WalletId:    utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			WalletId:    walletId,
 			NetworkId:   utils.GetFlagStringValue(cmd, utils.NetworkIdFlag),
 		}
 
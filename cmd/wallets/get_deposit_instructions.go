@@ -41,12 +41,17 @@ var getWalletDepositInstructionsCmd = &cobra.Command{
 			return err
 		}
 
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		ctx, cancel := utils.GetContextWithTimeout()
 		defer cancel()
 
 		request := &wallets.GetWalletDepositInstructionsRequest{
 			PortfolioId: portfolioId,
-			Id:          utils.GetFlagStringValue(cmd, utils.WalletIdFlag),
+			Id:          walletId,
 			Type:        utils.GetFlagStringValue(cmd, utils.DepositTypeFlag),
 		}
 
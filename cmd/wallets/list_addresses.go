@@ -42,7 +42,11 @@ var listWalletAddressesCmd = &cobra.Command{
 			return err
 		}
 
-		walletId := utils.GetFlagStringValue(cmd, utils.WalletIdFlag)
+		walletId, err := utils.GetWalletId(cmd, client, portfolioId)
+		if err != nil {
+			return err
+		}
+
 		networkId := utils.GetFlagStringValue(cmd, utils.NetworkIdFlag)
 
 		return utils.HandleListCmd(
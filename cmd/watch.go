@@ -0,0 +1,101 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase-samples/prime-cli/watch"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func addWatchFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().Duration(utils.WatchFlag, 0, "Re-run a get or list command on this interval (e.g. 5s) and highlight changed fields")
+	cmd.PersistentFlags().String(utils.UntilFlag, "", "With --watch, stop once the output matches, e.g. 'status==TRANSACTION_DONE'. Join conditions with &&")
+}
+
+// isReadCommand reports whether a command only reads data and may be watched.
+func isReadCommand(cmd *cobra.Command) bool {
+	name := cmd.Name()
+	return strings.HasPrefix(name, "get") || strings.HasPrefix(name, "list")
+}
+
+// enableWatch wraps every runnable command so that --watch re-runs read
+// commands and is rejected by commands that change state.
+func enableWatch(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		enableWatch(c)
+	}
+
+	if root.RunE == nil {
+		return
+	}
+
+	runE := root.RunE
+	root.RunE = func(cmd *cobra.Command, args []string) error {
+		interval, err := cmd.Flags().GetDuration(utils.WatchFlag)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", utils.WatchFlag, err)
+		}
+		until := utils.GetFlagStringValue(cmd, utils.UntilFlag)
+
+		if interval == 0 {
+			if until != "" {
+				return fmt.Errorf("--%s requires --%s", utils.UntilFlag, utils.WatchFlag)
+			}
+			return runE(cmd, args)
+		}
+
+		if !isReadCommand(cmd) {
+			return fmt.Errorf("--%s is only supported by get and list commands", utils.WatchFlag)
+		}
+		if interval < 0 {
+			return fmt.Errorf("--%s must be a positive interval", utils.WatchFlag)
+		}
+		if interactive, _ := cmd.Flags().GetBool(utils.InteractiveFlag); interactive {
+			return fmt.Errorf("--%s cannot be combined with --%s", utils.WatchFlag, utils.InteractiveFlag)
+		}
+
+		conditions, err := watch.ParseConditions(until)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", utils.UntilFlag, err)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		cmd.SilenceUsage = true
+		out := os.Stdout
+		return watch.Run(ctx, watch.Options{
+			Interval: interval,
+			Until:    conditions,
+			Title:    strings.Join(os.Args, " "),
+			Out:      out,
+			Redraw:   term.IsTerminal(int(out.Fd())),
+		}, func() ([]byte, error) {
+			return watch.Capture(func() error {
+				return runE(cmd, args)
+			})
+		})
+	}
+}
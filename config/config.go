@@ -0,0 +1,122 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	configEnvVar   = "primeCliConfig"
+	configDirName  = "primectl"
+	configFileName = "config.json"
+)
+
+// Config is the user-level primectl configuration, stored as JSON.
+type Config struct {
+	Aliases   Aliases                  `json:"aliases"`
+	Templates map[string]OrderTemplate `json:"templates,omitempty"`
+}
+
+// Aliases map short, user-chosen names to wallet and portfolio references.
+// Values may be UUIDs or any reference the resolver understands.
+type Aliases struct {
+	Portfolios map[string]string `json:"portfolios,omitempty"`
+	Wallets    map[string]string `json:"wallets,omitempty"`
+}
+
+// OrderTemplate holds default "orders create" flag values keyed by flag name,
+// e.g. "product-id": "ETH-USD". Each config file keeps its own templates, so
+// switching primeCliConfig switches the set in use.
+type OrderTemplate map[string]string
+
+// Dir returns the primectl configuration directory. Other local state, such
+// as run history, lives alongside the config file.
+func Dir() (string, error) {
+	if path := os.Getenv(configEnvVar); path != "" {
+		return filepath.Dir(path), nil
+	}
+
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate user config directory: %w", err)
+	}
+	return filepath.Join(base, configDirName), nil
+}
+
+// Path returns the config file location. The primeCliConfig environment
+// variable overrides the default under the user config directory.
+func Path() (string, error) {
+	if path := os.Getenv(configEnvVar); path != "" {
+		return path, nil
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, configFileName), nil
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read config %s: %w", path, err)
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Save writes the config file, creating its directory if needed.
+func Save(cfg *Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("cannot create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("cannot write config %s: %w", path, err)
+	}
+	return nil
+}
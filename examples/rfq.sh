@@ -12,3 +12,7 @@ ORDER_ID=$(primectl orders accept-quote --product-id $PRODUCT_ID --side BUY --qu
 
 echo "RFQ executed - order id: $ORDER_ID\n"
 
+
+# To review the price against an order preview before accepting, or to accept
+# automatically within a bound, use the interactive command instead:
+# primectl orders rfq --product-id $PRODUCT_ID --side BUY --base-quantity $BASE_QUANTITY --limit-price $LIMIT_PRICE --max-price $LIMIT_PRICE
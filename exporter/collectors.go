@@ -0,0 +1,363 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/balances"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/financing"
+	"github.com/coinbase/prime-sdk-go/futures"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+)
+
+const (
+	CollectorBalances       = "balances"
+	CollectorWalletBalances = "wallet_balances"
+	CollectorFutures        = "futures"
+	CollectorFinancing      = "financing"
+	CollectorOpenOrders     = "open_orders"
+)
+
+var Collectors = []string{
+	CollectorBalances,
+	CollectorWalletBalances,
+	CollectorFutures,
+	CollectorFinancing,
+	CollectorOpenOrders,
+}
+
+// DefaultIntervals are used for collectors without an explicit interval.
+var DefaultIntervals = map[string]time.Duration{
+	CollectorBalances:       time.Minute,
+	CollectorWalletBalances: time.Minute,
+	CollectorFutures:        time.Minute,
+	CollectorFinancing:      5 * time.Minute,
+	CollectorOpenOrders:     30 * time.Second,
+}
+
+var families = map[string]family{
+	"prime_balance_amount":                                    {help: "Total portfolio balance by asset."},
+	"prime_balance_holds":                                     {help: "Portfolio balance on hold by asset."},
+	"prime_balance_withdrawable":                              {help: "Withdrawable portfolio balance by asset."},
+	"prime_wallet_balance_amount":                             {help: "Portfolio balance by asset and wallet type."},
+	"prime_wallet_balance_holds":                              {help: "Portfolio balance on hold by asset and wallet type."},
+	"prime_futures_usd_balance":                               {help: "FCM CFM USD balance."},
+	"prime_futures_unrealized_pnl":                            {help: "FCM unrealized PnL."},
+	"prime_futures_daily_realized_pnl":                        {help: "FCM realized PnL for the current day."},
+	"prime_futures_excess_liquidity":                          {help: "FCM excess liquidity."},
+	"prime_futures_buying_power":                              {help: "FCM futures buying power."},
+	"prime_futures_initial_margin":                            {help: "FCM initial margin requirement."},
+	"prime_futures_maintenance_margin":                        {help: "FCM maintenance margin requirement."},
+	"prime_futures_margin_calls":                              {help: "Number of FCM margin calls by type and state."},
+	"prime_futures_margin_calls_outstanding":                  {help: "Total number of FCM margin calls."},
+	"prime_futures_margin_call_remaining_amount":              {help: "Remaining FCM margin call amount by type and state."},
+	"prime_buying_power":                                      {help: "Financing buying power by currency pair and side."},
+	"prime_withdrawal_power":                                  {help: "Financing withdrawal power by asset."},
+	"prime_open_orders":                                       {help: "Number of open orders by product and side."},
+	"prime_exporter_collector_up":                             {help: "Whether the last collection succeeded."},
+	"prime_exporter_collector_duration_seconds":               {help: "Duration of the last collection."},
+	"prime_exporter_collector_last_success_timestamp_seconds": {help: "Unix time of the last successful collection."},
+}
+
+type Config struct {
+	PortfolioId string
+	EntityId    string
+
+	// BuyingPowerPairs are BASE-QUOTE pairs such as BTC-USD.
+	BuyingPowerPairs       []string
+	WithdrawalPowerSymbols []string
+
+	Intervals map[string]time.Duration
+}
+
+// ValidateCollectors rejects unknown collector names.
+func ValidateCollectors(names []string) error {
+	for _, name := range names {
+		known := false
+		for _, c := range Collectors {
+			if name == c {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown collector %q (supported: %s)", name, strings.Join(Collectors, ", "))
+		}
+	}
+	return nil
+}
+
+// NewCollectors builds the named collectors. The futures collector requires
+// an entity ID and the financing collector requires at least one pair or
+// symbol; they are skipped with a warning otherwise.
+func NewCollectors(c client.RestClient, cfg Config, names []string) ([]*Collector, []string, error) {
+	if err := ValidateCollectors(names); err != nil {
+		return nil, nil, err
+	}
+
+	pairs := make([][2]string, 0, len(cfg.BuyingPowerPairs))
+	for _, pair := range cfg.BuyingPowerPairs {
+		base, quote, ok := strings.Cut(strings.ToUpper(pair), "-")
+		if !ok || base == "" || quote == "" {
+			return nil, nil, fmt.Errorf("invalid buying power pair %q, expected BASE-QUOTE such as BTC-USD", pair)
+		}
+		pairs = append(pairs, [2]string{base, quote})
+	}
+
+	var collectors []*Collector
+	var warnings []string
+	for _, name := range names {
+		var collect func(ctx context.Context) ([]Sample, error)
+		switch name {
+		case CollectorBalances:
+			collect = func(ctx context.Context) ([]Sample, error) {
+				return collectBalances(ctx, c, cfg.PortfolioId)
+			}
+		case CollectorWalletBalances:
+			collect = func(ctx context.Context) ([]Sample, error) {
+				return collectWalletBalances(ctx, c, cfg.PortfolioId)
+			}
+		case CollectorFutures:
+			if cfg.EntityId == "" {
+				warnings = append(warnings, "futures collector disabled: no entity ID")
+				continue
+			}
+			collect = func(ctx context.Context) ([]Sample, error) {
+				return collectFutures(ctx, c, cfg.EntityId)
+			}
+		case CollectorFinancing:
+			if len(pairs) == 0 && len(cfg.WithdrawalPowerSymbols) == 0 {
+				warnings = append(warnings, "financing collector disabled: no buying power pairs or withdrawal power symbols")
+				continue
+			}
+			collect = func(ctx context.Context) ([]Sample, error) {
+				return collectFinancing(ctx, c, cfg.PortfolioId, pairs, cfg.WithdrawalPowerSymbols)
+			}
+		case CollectorOpenOrders:
+			collect = func(ctx context.Context) ([]Sample, error) {
+				return collectOpenOrders(ctx, c, cfg.PortfolioId)
+			}
+		}
+
+		interval, ok := cfg.Intervals[name]
+		if !ok {
+			interval = DefaultIntervals[name]
+		}
+		collectors = append(collectors, &Collector{Name: name, Interval: interval, Collect: collect})
+	}
+	return collectors, warnings, nil
+}
+
+// appendGauge appends a sample when value parses as a number.
+func appendGauge(samples []Sample, name, value string, labels ...Label) []Sample {
+	if s, ok := gauge(name, value, labels...); ok {
+		samples = append(samples, s)
+	}
+	return samples
+}
+
+func collectBalances(ctx context.Context, c client.RestClient, portfolioId string) ([]Sample, error) {
+	response, err := balances.NewBalancesService(c).ListPortfolioBalances(ctx, &balances.ListPortfolioBalancesRequest{
+		PortfolioId: portfolioId,
+		Type:        model.BalanceTypeTotal,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list portfolio balances: %w", err)
+	}
+
+	var samples []Sample
+	for _, b := range response.Balances {
+		labels := []Label{label("portfolio", portfolioId), label("asset", b.Symbol)}
+		samples = appendGauge(samples, "prime_balance_amount", b.Amount, labels...)
+		samples = appendGauge(samples, "prime_balance_holds", b.Holds, labels...)
+		samples = appendGauge(samples, "prime_balance_withdrawable", b.WithdrawableAmount, labels...)
+	}
+	return samples, nil
+}
+
+// collectWalletBalances lists balances once per wallet type rather than once
+// per wallet, which keeps the call count independent of the wallet count.
+func collectWalletBalances(ctx context.Context, c client.RestClient, portfolioId string) ([]Sample, error) {
+	svc := balances.NewBalancesService(c)
+
+	var samples []Sample
+	for _, walletType := range []string{model.BalanceTypeTrading, model.BalanceTypeVault} {
+		response, err := svc.ListPortfolioBalances(ctx, &balances.ListPortfolioBalancesRequest{
+			PortfolioId: portfolioId,
+			Type:        walletType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s: %w", strings.ToLower(walletType), err)
+		}
+
+		typeLabel := strings.ToLower(strings.TrimSuffix(walletType, "_BALANCES"))
+		for _, b := range response.Balances {
+			labels := []Label{label("portfolio", portfolioId), label("asset", b.Symbol), label("wallet_type", typeLabel)}
+			samples = appendGauge(samples, "prime_wallet_balance_amount", b.Amount, labels...)
+			samples = appendGauge(samples, "prime_wallet_balance_holds", b.Holds, labels...)
+		}
+	}
+	return samples, nil
+}
+
+func collectFutures(ctx context.Context, c client.RestClient, entityId string) ([]Sample, error) {
+	svc := futures.NewFuturesService(c)
+
+	balanceResponse, err := svc.GetEntityFcmBalance(ctx, &futures.GetEntityFcmBalanceRequest{EntityId: entityId})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get futures balance: %w", err)
+	}
+
+	var samples []Sample
+	if b := balanceResponse.FcmBalance; b != nil {
+		labels := []Label{label("entity", entityId), label("portfolio", b.PortfolioId)}
+		samples = appendGauge(samples, "prime_futures_usd_balance", b.CfmUsdBalance, labels...)
+		samples = appendGauge(samples, "prime_futures_unrealized_pnl", b.UnrealizedPnl, labels...)
+		samples = appendGauge(samples, "prime_futures_daily_realized_pnl", b.DailyRealizedPnl, labels...)
+		samples = appendGauge(samples, "prime_futures_excess_liquidity", b.ExcessLiquidity, labels...)
+		samples = appendGauge(samples, "prime_futures_buying_power", b.FuturesBuyingPower, labels...)
+		samples = appendGauge(samples, "prime_futures_initial_margin", b.InitialMargin, labels...)
+		samples = appendGauge(samples, "prime_futures_maintenance_margin", b.MaintenanceMargin, labels...)
+	}
+
+	callsResponse, err := svc.GetFcmMarginCallDetails(ctx, &futures.GetFcmMarginCallDetailsRequest{EntityId: entityId})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get futures margin calls: %w", err)
+	}
+
+	type callKey struct{ callType, state string }
+	counts := map[callKey]float64{}
+	remaining := map[callKey]float64{}
+	for _, call := range callsResponse.MarginCalls {
+		key := callKey{string(call.Type), string(call.State)}
+		counts[key]++
+		if s, ok := gauge("", call.RemainingAmount); ok {
+			remaining[key] += s.Value
+		}
+	}
+
+	// Report the total even when it is zero so alerts can tell "no margin
+	// calls" apart from a missing series.
+	samples = append(samples, Sample{
+		Name:   "prime_futures_margin_calls_outstanding",
+		Labels: []Label{label("entity", entityId)},
+		Value:  float64(len(callsResponse.MarginCalls)),
+	})
+	for key, count := range counts {
+		labels := []Label{label("entity", entityId), label("type", key.callType), label("state", key.state)}
+		samples = append(samples,
+			Sample{Name: "prime_futures_margin_calls", Labels: labels, Value: count},
+			Sample{Name: "prime_futures_margin_call_remaining_amount", Labels: labels, Value: remaining[key]},
+		)
+	}
+	return samples, nil
+}
+
+func collectFinancing(ctx context.Context, c client.RestClient, portfolioId string, pairs [][2]string, symbols []string) ([]Sample, error) {
+	svc := financing.NewFinancingService(c)
+
+	var samples []Sample
+	for _, pair := range pairs {
+		response, err := svc.GetBuyingPower(ctx, &financing.GetBuyingPowerRequest{
+			PortfolioId:   portfolioId,
+			BaseCurrency:  pair[0],
+			QuoteCurrency: pair[1],
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot get buying power for %s-%s: %w", pair[0], pair[1], err)
+		}
+		if response.BuyingPower == nil {
+			continue
+		}
+		labels := func(side string) []Label {
+			return []Label{label("portfolio", portfolioId), label("base", pair[0]), label("quote", pair[1]), label("side", side)}
+		}
+		samples = appendGauge(samples, "prime_buying_power", response.BuyingPower.BaseBuyingPower, labels("base")...)
+		samples = appendGauge(samples, "prime_buying_power", response.BuyingPower.QuoteBuyingPower, labels("quote")...)
+	}
+
+	for _, symbol := range symbols {
+		symbol = strings.ToUpper(symbol)
+		response, err := svc.GetWithdrawalPower(ctx, &financing.GetWithdrawalPowerRequest{
+			PortfolioId: portfolioId,
+			Symbol:      symbol,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot get withdrawal power for %s: %w", symbol, err)
+		}
+		if response.WithdrawalPower == nil {
+			continue
+		}
+		samples = appendGauge(samples, "prime_withdrawal_power", response.WithdrawalPower.Amount,
+			label("portfolio", portfolioId), label("asset", symbol))
+	}
+	return samples, nil
+}
+
+func collectOpenOrders(ctx context.Context, c client.RestClient, portfolioId string) ([]Sample, error) {
+	svc := orders.NewOrdersService(c)
+
+	type orderKey struct{ product, side string }
+	counts := map[orderKey]float64{}
+
+	pagination := &model.PaginationParams{Limit: 1000}
+	for {
+		response, err := svc.ListOpenOrders(ctx, &orders.ListOpenOrdersRequest{
+			PortfolioId: portfolioId,
+			Pagination:  pagination,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list open orders: %w", err)
+		}
+
+		for _, o := range response.Orders {
+			counts[orderKey{o.ProductId, o.Side}]++
+		}
+
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		pagination = &model.PaginationParams{Limit: pagination.Limit, Cursor: response.Pagination.NextCursor}
+	}
+
+	keys := make([]orderKey, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].product != keys[j].product {
+			return keys[i].product < keys[j].product
+		}
+		return keys[i].side < keys[j].side
+	})
+
+	samples := []Sample{}
+	for _, key := range keys {
+		samples = append(samples, Sample{
+			Name:   "prime_open_orders",
+			Labels: []Label{label("portfolio", portfolioId), label("product", key.product), label("side", key.side)},
+			Value:  counts[key],
+		})
+	}
+	return samples, nil
+}
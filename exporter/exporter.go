@@ -0,0 +1,128 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Collector gathers one group of samples. Results are cached for Interval,
+// so scrapes arriving more often than that do not call the API again.
+type Collector struct {
+	Name     string
+	Interval time.Duration
+	Collect  func(ctx context.Context) ([]Sample, error)
+
+	mu          sync.Mutex
+	samples     []Sample
+	err         error
+	collectedAt time.Time
+	lastSuccess time.Time
+	duration    time.Duration
+}
+
+// refresh re-runs the collector when its cached result has expired. The
+// previous samples are kept on failure so a transient error does not make
+// every series disappear.
+func (c *Collector) refresh(now time.Time, newContext func() (context.Context, context.CancelFunc)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.collectedAt.IsZero() && now.Sub(c.collectedAt) < c.Interval {
+		return
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	started := time.Now()
+	samples, err := c.Collect(ctx)
+	c.duration = time.Since(started)
+	c.collectedAt = now
+	c.err = err
+	if err == nil {
+		c.samples = samples
+		c.lastSuccess = now
+	}
+}
+
+type Exporter struct {
+	Collectors []*Collector
+	NewContext func() (context.Context, context.CancelFunc)
+
+	// ErrorLog receives collector failures. It may be nil.
+	ErrorLog func(collector string, err error)
+}
+
+// Gather refreshes expired collectors concurrently and returns every cached
+// sample along with per-collector health samples.
+func (e *Exporter) Gather() []Sample {
+	now := time.Now()
+
+	var wg sync.WaitGroup
+	for _, c := range e.Collectors {
+		wg.Add(1)
+		go func(c *Collector) {
+			defer wg.Done()
+			c.refresh(now, e.NewContext)
+		}(c)
+	}
+	wg.Wait()
+
+	var samples []Sample
+	for _, c := range e.Collectors {
+		c.mu.Lock()
+		samples = append(samples, c.samples...)
+
+		up := 1.0
+		if c.err != nil {
+			up = 0
+			if e.ErrorLog != nil && c.collectedAt.Equal(now) {
+				e.ErrorLog(c.Name, c.err)
+			}
+		}
+		name := label("collector", c.Name)
+		samples = append(samples,
+			Sample{Name: "prime_exporter_collector_up", Labels: []Label{name}, Value: up},
+			Sample{Name: "prime_exporter_collector_duration_seconds", Labels: []Label{name}, Value: c.duration.Seconds()},
+		)
+		if !c.lastSuccess.IsZero() {
+			samples = append(samples, Sample{
+				Name:   "prime_exporter_collector_last_success_timestamp_seconds",
+				Labels: []Label{name},
+				Value:  float64(c.lastSuccess.UnixMilli()) / 1000,
+			})
+		}
+		c.mu.Unlock()
+	}
+	return samples
+}
+
+// ServeHTTP renders the metrics in the Prometheus text exposition format.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := write(&buf, families, e.Gather()); err != nil {
+		http.Error(w, fmt.Sprintf("cannot render metrics: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write(buf.Bytes())
+}
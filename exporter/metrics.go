@@ -0,0 +1,123 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package exporter serves Prime balances, financing and order metrics in the
+// Prometheus text exposition format.
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Label is a name/value pair. Labels keep their given order in the output.
+type Label struct {
+	Name  string
+	Value string
+}
+
+type Sample struct {
+	Name   string
+	Labels []Label
+	Value  float64
+}
+
+// family describes a metric name for the HELP and TYPE lines. Every metric
+// the exporter emits is a gauge.
+type family struct {
+	name string
+	help string
+}
+
+// gauge builds a sample, parsing value as a decimal string. Empty or
+// unparsable values yield ok=false so callers can skip them.
+func gauge(name, value string, labels ...Label) (Sample, bool) {
+	if value == "" {
+		return Sample{}, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return Sample{}, false
+	}
+	return Sample{Name: name, Labels: labels, Value: v}, true
+}
+
+func label(name, value string) Label {
+	return Label{Name: name, Value: value}
+}
+
+// write renders samples grouped by metric name, sorted for stable output.
+func write(w io.Writer, families map[string]family, samples []Sample) error {
+	byName := map[string][]Sample{}
+	for _, s := range samples {
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if f, ok := families[name]; ok {
+			if _, err := fmt.Fprintf(w, "# HELP %s %s\n", name, escapeHelp(f.help)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "# TYPE %s gauge\n", name); err != nil {
+			return err
+		}
+
+		group := byName[name]
+		sort.SliceStable(group, func(i, j int) bool { return labelString(group[i].Labels) < labelString(group[j].Labels) })
+		for _, s := range group {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", name, labelString(s.Labels), formatValue(s.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func labelString(labels []Label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = l.Name + "=\"" + escapeLabel(l.Value) + "\""
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+var helpEscaper = strings.NewReplacer("\\", "\\\\", "\n", "\\n")
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
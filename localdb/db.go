@@ -0,0 +1,258 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package localdb mirrors Prime resources into a local SQLite database so
+// they can be queried with SQL instead of repeated list calls.
+package localdb
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	dbEnvVar  = "primeCliDb"
+	dbName    = "primectl.db"
+	driver    = "sqlite"
+	timestamp = "2006-01-02T15:04:05.000000Z"
+)
+
+// Each table keeps the columns most queries filter on plus the full API
+// object in raw, which can be read with json_extract.
+const schema = `
+CREATE TABLE IF NOT EXISTS sync_state (
+	resource        TEXT NOT NULL,
+	portfolio_id    TEXT NOT NULL,
+	high_water_mark TEXT NOT NULL,
+	synced_at       TEXT NOT NULL,
+	PRIMARY KEY (resource, portfolio_id)
+);
+
+CREATE TABLE IF NOT EXISTS orders (
+	id                   TEXT PRIMARY KEY,
+	portfolio_id         TEXT NOT NULL,
+	client_order_id      TEXT,
+	product_id           TEXT,
+	side                 TEXT,
+	type                 TEXT,
+	status               TEXT,
+	base_quantity        TEXT,
+	quote_value          TEXT,
+	limit_price          TEXT,
+	filled_quantity      TEXT,
+	filled_value         TEXT,
+	average_filled_price TEXT,
+	commission           TEXT,
+	created_at           TEXT,
+	raw                  TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS orders_created_at ON orders (portfolio_id, created_at);
+
+CREATE TABLE IF NOT EXISTS fills (
+	id              TEXT PRIMARY KEY,
+	portfolio_id    TEXT NOT NULL,
+	order_id        TEXT,
+	product_id      TEXT,
+	side            TEXT,
+	filled_quantity TEXT,
+	filled_value    TEXT,
+	price           TEXT,
+	commission      TEXT,
+	venue           TEXT,
+	time            TEXT,
+	raw             TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS fills_time ON fills (portfolio_id, time);
+CREATE INDEX IF NOT EXISTS fills_order_id ON fills (order_id);
+
+CREATE TABLE IF NOT EXISTS transactions (
+	id           TEXT PRIMARY KEY,
+	portfolio_id TEXT NOT NULL,
+	wallet_id    TEXT,
+	type         TEXT,
+	status       TEXT,
+	symbol       TEXT,
+	amount       TEXT,
+	fees         TEXT,
+	created_at   TEXT,
+	completed_at TEXT,
+	raw          TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS transactions_created_at ON transactions (portfolio_id, created_at);
+
+CREATE TABLE IF NOT EXISTS activities (
+	id           TEXT PRIMARY KEY,
+	portfolio_id TEXT NOT NULL,
+	reference_id TEXT,
+	category     TEXT,
+	type         TEXT,
+	status       TEXT,
+	title        TEXT,
+	created_at   TEXT,
+	updated_at   TEXT,
+	raw          TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS activities_created_at ON activities (portfolio_id, created_at);
+
+CREATE TABLE IF NOT EXISTS allocations (
+	root_id       TEXT PRIMARY KEY,
+	portfolio_id  TEXT NOT NULL,
+	product_id    TEXT,
+	side          TEXT,
+	status        TEXT,
+	avg_price     TEXT,
+	base_quantity TEXT,
+	quote_value   TEXT,
+	fees          TEXT,
+	completed_at  TEXT,
+	raw           TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS allocations_completed_at ON allocations (portfolio_id, completed_at);
+
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+	snapshot_at         TEXT NOT NULL,
+	portfolio_id        TEXT NOT NULL,
+	symbol              TEXT NOT NULL,
+	amount              TEXT,
+	holds               TEXT,
+	withdrawable_amount TEXT,
+	raw                 TEXT NOT NULL,
+	PRIMARY KEY (snapshot_at, portfolio_id, symbol)
+);
+`
+
+// DefaultPath is primectl.db in the primectl config directory, unless the
+// primeCliDb environment variable names another file.
+func DefaultPath() (string, error) {
+	if path := os.Getenv(dbEnvVar); path != "" {
+		return path, nil
+	}
+
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, dbName), nil
+}
+
+// Open opens the database for syncing, creating the file and schema if
+// needed.
+func Open(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create database directory: %w", err)
+	}
+
+	db, err := sql.Open(driver, path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database %s: %w", path, err)
+	}
+	// SQLite allows one writer; a single connection avoids SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("cannot create schema in %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// OpenReadOnly opens an existing database for queries.
+func OpenReadOnly(path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("cannot open database %s, run primectl sync first: %w", path, err)
+	}
+
+	db, err := sql.Open(driver, "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Query runs a statement and returns the column names and every row with
+// values rendered as strings. NULL becomes an empty string.
+func Query(db *sql.DB, query string, args ...any) ([]string, [][]string, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result [][]string
+	values := make([]any, len(columns))
+	pointers := make([]any, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		row := make([]string, len(columns))
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+			case []byte:
+				row[i] = string(v)
+			case time.Time:
+				row[i] = formatTime(v)
+			default:
+				row[i] = fmt.Sprint(v)
+			}
+		}
+		result = append(result, row)
+	}
+	return columns, result, rows.Err()
+}
+
+// formatTime stores times in UTC with a fixed width so they sort as text.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(timestamp)
+}
+
+// normalizeTime reformats an API timestamp string the same way. Values that
+// do not parse are kept as-is.
+func normalizeTime(value string) string {
+	if value == "" {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return value
+	}
+	return formatTime(t)
+}
+
+func parseTime(value string) time.Time {
+	t, err := time.Parse(timestamp, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,590 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package localdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/activities"
+	"github.com/coinbase/prime-sdk-go/allocations"
+	"github.com/coinbase/prime-sdk-go/balances"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/coinbase/prime-sdk-go/transactions"
+)
+
+const (
+	ResourceOrders       = "orders"
+	ResourceFills        = "fills"
+	ResourceTransactions = "transactions"
+	ResourceActivities   = "activities"
+	ResourceAllocations  = "allocations"
+	ResourceBalances     = "balances"
+
+	pageLimit = 250
+)
+
+var Resources = []string{
+	ResourceOrders,
+	ResourceFills,
+	ResourceTransactions,
+	ResourceActivities,
+	ResourceAllocations,
+	ResourceBalances,
+}
+
+type Options struct {
+	PortfolioId string
+	Resources   []string
+
+	// Since is where a resource starts when it has never been synced.
+	Since time.Time
+
+	// Overlap is re-fetched behind each high-water mark to pick up records
+	// that were written late or changed shortly after creation.
+	Overlap time.Duration
+
+	NewContext func() (context.Context, context.CancelFunc)
+	Progress   io.Writer
+}
+
+// Result reports one resource. Fetched counts records returned by list
+// calls, Refreshed counts unfinished records re-read individually.
+type Result struct {
+	Resource      string `json:"resource"`
+	From          string `json:"from,omitempty"`
+	Fetched       int    `json:"fetched"`
+	Refreshed     int    `json:"refreshed,omitempty"`
+	HighWaterMark string `json:"high_water_mark,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type syncer struct {
+	client client.RestClient
+	db     *sql.DB
+	opts   Options
+	now    time.Time
+}
+
+// syncFunc fetches everything from start onward, stores it and returns the
+// newest record time seen.
+type syncFunc func(s *syncer, start time.Time, result *Result) (time.Time, error)
+
+var syncFuncs = map[string]syncFunc{
+	ResourceOrders:       (*syncer).orders,
+	ResourceFills:        (*syncer).fills,
+	ResourceTransactions: (*syncer).transactions,
+	ResourceActivities:   (*syncer).activities,
+	ResourceAllocations:  (*syncer).allocations,
+	ResourceBalances:     (*syncer).balances,
+}
+
+// ValidateResources rejects unknown resource names.
+func ValidateResources(names []string) error {
+	for _, name := range names {
+		if _, ok := syncFuncs[name]; !ok {
+			return fmt.Errorf("unknown resource %q, expected one of: %s", name, strings.Join(Resources, ", "))
+		}
+	}
+	return nil
+}
+
+// Sync mirrors each requested resource in turn. A failing resource keeps its
+// previous high-water mark and does not stop the others.
+func Sync(c client.RestClient, db *sql.DB, opts Options) []*Result {
+	if len(opts.Resources) == 0 {
+		opts.Resources = Resources
+	}
+	s := &syncer{client: c, db: db, opts: opts, now: time.Now().UTC()}
+
+	var results []*Result
+	for _, name := range opts.Resources {
+		result := &Result{Resource: name}
+		results = append(results, result)
+
+		hwm, err := s.highWaterMark(name)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		start := opts.Since
+		if !hwm.IsZero() {
+			start = hwm.Add(-opts.Overlap)
+		}
+		result.From = formatTime(start)
+		s.progress("syncing %s from %s", name, result.From)
+
+		newest, err := syncFuncs[name](s, start, result)
+		if err != nil {
+			result.Error = err.Error()
+			s.progress("%s failed: %v", name, err)
+			continue
+		}
+		if newest.Before(hwm) {
+			newest = hwm
+		}
+		if err := s.saveHighWaterMark(name, newest); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.HighWaterMark = formatTime(newest)
+		s.progress("%s: %d fetched, %d refreshed", name, result.Fetched, result.Refreshed)
+	}
+	return results
+}
+
+func (s *syncer) progress(format string, args ...any) {
+	if s.opts.Progress != nil {
+		fmt.Fprintf(s.opts.Progress, format+"\n", args...)
+	}
+}
+
+func (s *syncer) highWaterMark(resource string) (time.Time, error) {
+	var value string
+	err := s.db.QueryRow(
+		"SELECT high_water_mark FROM sync_state WHERE resource = ? AND portfolio_id = ?",
+		resource, s.opts.PortfolioId,
+	).Scan(&value)
+	if err == sql.ErrNoRows {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot read sync state for %s: %w", resource, err)
+	}
+	return parseTime(value), nil
+}
+
+func (s *syncer) saveHighWaterMark(resource string, hwm time.Time) error {
+	_, err := s.db.Exec(
+		"INSERT OR REPLACE INTO sync_state (resource, portfolio_id, high_water_mark, synced_at) VALUES (?, ?, ?, ?)",
+		resource, s.opts.PortfolioId, formatTime(hwm), formatTime(s.now),
+	)
+	if err != nil {
+		return fmt.Errorf("cannot save sync state for %s: %w", resource, err)
+	}
+	return nil
+}
+
+// row is one record to store: its column values in table order and the raw
+// API object.
+type row struct {
+	values []any
+	raw    any
+}
+
+// store upserts rows in a single transaction. columns excludes raw, which is
+// always last.
+func (s *syncer) store(table string, columns []string, rows []row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)+1), ", ")
+	stmt, err := tx.Prepare(fmt.Sprintf(
+		"INSERT OR REPLACE INTO %s (%s, raw) VALUES (%s)",
+		table, strings.Join(columns, ", "), placeholders,
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range rows {
+		raw, err := json.Marshal(r.raw)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.Exec(append(r.values, string(raw))...); err != nil {
+			return fmt.Errorf("cannot store %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// fetchPages follows cursors until the last page, with a fresh context for
+// each request.
+func fetchPages[T any](
+	s *syncer,
+	fetch func(ctx context.Context, pagination *model.PaginationParams) ([]T, *model.Pagination, error),
+) ([]T, error) {
+	pagination := &model.PaginationParams{Limit: pageLimit}
+	var all []T
+	for {
+		ctx, cancel := s.opts.NewContext()
+		items, page, err := fetch(ctx, pagination)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if page == nil || !page.HasNext || page.NextCursor == "" {
+			return all, nil
+		}
+		pagination.Cursor = page.NextCursor
+	}
+}
+
+// unfinished returns the IDs in table whose status is not terminal.
+func (s *syncer) unfinished(table, idColumn string, skip map[string]bool) ([]string, error) {
+	rows, err := s.db.Query(
+		fmt.Sprintf("SELECT %s, status FROM %s WHERE portfolio_id = ?", idColumn, table),
+		s.opts.PortfolioId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id, status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, err
+		}
+		if terminal, _ := utils.ClassifyStatus(status); !terminal && !skip[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids, rows.Err()
+}
+
+func latest(current time.Time, value time.Time) time.Time {
+	if value.After(current) {
+		return value
+	}
+	return current
+}
+
+var orderColumns = []string{
+	"id", "portfolio_id", "client_order_id", "product_id", "side", "type", "status",
+	"base_quantity", "quote_value", "limit_price", "filled_quantity", "filled_value",
+	"average_filled_price", "commission", "created_at",
+}
+
+func orderRow(portfolioId string, o *model.Order) row {
+	return row{
+		values: []any{
+			o.Id, portfolioId, o.ClientOrderId, o.ProductId, o.Side, o.Type, o.Status,
+			o.BaseQuantity, o.QuoteValue, o.LimitPrice, o.FilledQuantity, o.FilledValue,
+			o.AverageFilledPrice, o.Commission, normalizeTime(o.Created),
+		},
+		raw: o,
+	}
+}
+
+// orders lists orders created since start plus every open order, then
+// re-reads stored orders that were still working at the last sync. An order
+// that cannot be re-read keeps its stored row and is retried next time.
+func (s *syncer) orders(start time.Time, result *Result) (time.Time, error) {
+	svc := orders.NewOrdersService(s.client)
+	portfolioId := s.opts.PortfolioId
+
+	listed, err := fetchPages(s, func(ctx context.Context, p *model.PaginationParams) ([]*model.Order, *model.Pagination, error) {
+		response, err := svc.ListOrders(ctx, &orders.ListOrdersRequest{
+			PortfolioId: portfolioId,
+			Start:       start,
+			End:         s.now,
+			Pagination:  p,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list orders: %w", err)
+		}
+		return response.Orders, response.Pagination, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	open, err := fetchPages(s, func(ctx context.Context, p *model.PaginationParams) ([]*model.Order, *model.Pagination, error) {
+		response, err := svc.ListOpenOrders(ctx, &orders.ListOpenOrdersRequest{PortfolioId: portfolioId, Pagination: p})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list open orders: %w", err)
+		}
+		return response.Orders, response.Pagination, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	seen := map[string]bool{}
+	var rows []row
+	var newest time.Time
+	for _, o := range append(listed, open...) {
+		if seen[o.Id] {
+			continue
+		}
+		seen[o.Id] = true
+		rows = append(rows, orderRow(portfolioId, o))
+		newest = latest(newest, parseTime(normalizeTime(o.Created)))
+	}
+	result.Fetched = len(rows)
+
+	stale, err := s.unfinished("orders", "id", seen)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, id := range stale {
+		ctx, cancel := s.opts.NewContext()
+		response, err := svc.GetOrder(ctx, &orders.GetOrderRequest{PortfolioId: portfolioId, OrderId: id})
+		cancel()
+		if err != nil {
+			s.progress("warning: cannot refresh order %s: %v", id, err)
+			continue
+		}
+		if response.Order == nil {
+			s.progress("warning: cannot refresh order %s: order missing from response", id)
+			continue
+		}
+		rows = append(rows, orderRow(portfolioId, response.Order))
+		result.Refreshed++
+	}
+
+	return newest, s.store("orders", orderColumns, rows)
+}
+
+func (s *syncer) fills(start time.Time, result *Result) (time.Time, error) {
+	svc := orders.NewOrdersService(s.client)
+	portfolioId := s.opts.PortfolioId
+
+	fills, err := fetchPages(s, func(ctx context.Context, p *model.PaginationParams) ([]*model.OrderFill, *model.Pagination, error) {
+		response, err := svc.ListPortfolioFills(ctx, &orders.ListPortfolioFillsRequest{
+			PortfolioId: portfolioId,
+			Start:       start,
+			End:         s.now,
+			Pagination:  p,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list portfolio fills: %w", err)
+		}
+		return response.Fills, response.Pagination, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var rows []row
+	var newest time.Time
+	for _, f := range fills {
+		rows = append(rows, row{
+			values: []any{
+				f.Id, portfolioId, f.OrderId, f.ProductId, f.Side, f.FilledQuantity,
+				f.FilledValue, f.Price, f.Commission, f.Venue, formatTime(f.Time),
+			},
+			raw: f,
+		})
+		newest = latest(newest, f.Time)
+	}
+	result.Fetched = len(rows)
+
+	return newest, s.store("fills", []string{
+		"id", "portfolio_id", "order_id", "product_id", "side", "filled_quantity",
+		"filled_value", "price", "commission", "venue", "time",
+	}, rows)
+}
+
+var transactionColumns = []string{
+	"id", "portfolio_id", "wallet_id", "type", "status", "symbol", "amount", "fees",
+	"created_at", "completed_at",
+}
+
+func transactionRow(portfolioId string, t *model.Transaction) row {
+	return row{
+		values: []any{
+			t.Id, portfolioId, t.WalletId, t.Type, t.Status, t.Symbol, t.Amount, t.Fees,
+			formatTime(t.Created), formatTime(t.Completed),
+		},
+		raw: t,
+	}
+}
+
+// transactions lists transactions created since start, then re-reads stored
+// transactions that had not completed at the last sync.
+func (s *syncer) transactions(start time.Time, result *Result) (time.Time, error) {
+	svc := transactions.NewTransactionsService(s.client)
+	portfolioId := s.opts.PortfolioId
+
+	listed, err := fetchPages(s, func(ctx context.Context, p *model.PaginationParams) ([]*model.Transaction, *model.Pagination, error) {
+		response, err := svc.ListPortfolioTransactions(ctx, &transactions.ListPortfolioTransactionsRequest{
+			PortfolioId: portfolioId,
+			Start:       start,
+			End:         s.now,
+			Pagination:  p,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list transactions: %w", err)
+		}
+		return response.Transactions, response.Pagination, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	seen := map[string]bool{}
+	var rows []row
+	var newest time.Time
+	for _, t := range listed {
+		seen[t.Id] = true
+		rows = append(rows, transactionRow(portfolioId, t))
+		newest = latest(newest, t.Created)
+	}
+	result.Fetched = len(rows)
+
+	stale, err := s.unfinished("transactions", "id", seen)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, id := range stale {
+		ctx, cancel := s.opts.NewContext()
+		response, err := svc.GetTransaction(ctx, &transactions.GetTransactionRequest{PortfolioId: portfolioId, TransactionId: id})
+		cancel()
+		if err != nil {
+			s.progress("warning: cannot refresh transaction %s: %v", id, err)
+			continue
+		}
+		if response.Transaction == nil {
+			s.progress("warning: cannot refresh transaction %s: transaction missing from response", id)
+			continue
+		}
+		rows = append(rows, transactionRow(portfolioId, response.Transaction))
+		result.Refreshed++
+	}
+
+	return newest, s.store("transactions", transactionColumns, rows)
+}
+
+func (s *syncer) activities(start time.Time, result *Result) (time.Time, error) {
+	svc := activities.NewActivitiesService(s.client)
+	portfolioId := s.opts.PortfolioId
+
+	listed, err := fetchPages(s, func(ctx context.Context, p *model.PaginationParams) ([]*model.Activity, *model.Pagination, error) {
+		response, err := svc.ListActivities(ctx, &activities.ListActivitiesRequest{
+			PortfolioId: portfolioId,
+			Start:       start,
+			End:         s.now,
+			Pagination:  p,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list activities: %w", err)
+		}
+		return response.Activities, response.Pagination, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var rows []row
+	var newest time.Time
+	for _, a := range listed {
+		created := normalizeTime(a.Created)
+		rows = append(rows, row{
+			values: []any{
+				a.Id, portfolioId, a.ReferenceId, a.Category, a.PrimaryType, a.Status, a.Title,
+				created, normalizeTime(a.Updated),
+			},
+			raw: a,
+		})
+		newest = latest(newest, parseTime(created))
+	}
+	result.Fetched = len(rows)
+
+	return newest, s.store("activities", []string{
+		"id", "portfolio_id", "reference_id", "category", "type", "status", "title",
+		"created_at", "updated_at",
+	}, rows)
+}
+
+func (s *syncer) allocations(start time.Time, result *Result) (time.Time, error) {
+	svc := allocations.NewAllocationsService(s.client)
+	portfolioId := s.opts.PortfolioId
+
+	listed, err := fetchPages(s, func(ctx context.Context, p *model.PaginationParams) ([]*model.Allocation, *model.Pagination, error) {
+		response, err := svc.ListPortfolioAllocations(ctx, &allocations.ListPortfolioAllocationsRequest{
+			PortfolioId: portfolioId,
+			Start:       start,
+			End:         s.now,
+			Pagination:  p,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot list allocations: %w", err)
+		}
+		return response.Allocations, response.Pagination, nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var rows []row
+	var newest time.Time
+	for _, a := range listed {
+		completed := normalizeTime(a.Completed)
+		rows = append(rows, row{
+			values: []any{
+				a.RootId, portfolioId, a.ProductId, a.Side, a.Status, a.AvgPrice,
+				a.BaseQuantity, a.QuoteValue, a.FeesAllocated, completed,
+			},
+			raw: a,
+		})
+		newest = latest(newest, parseTime(completed))
+	}
+	result.Fetched = len(rows)
+
+	return newest, s.store("allocations", []string{
+		"root_id", "portfolio_id", "product_id", "side", "status", "avg_price",
+		"base_quantity", "quote_value", "fees", "completed_at",
+	}, rows)
+}
+
+// balances appends a snapshot of the current balances on every run; its
+// high-water mark is the time of the latest snapshot.
+func (s *syncer) balances(_ time.Time, result *Result) (time.Time, error) {
+	ctx, cancel := s.opts.NewContext()
+	defer cancel()
+
+	response, err := balances.NewBalancesService(s.client).ListPortfolioBalances(ctx, &balances.ListPortfolioBalancesRequest{
+		PortfolioId: s.opts.PortfolioId,
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot list balances: %w", err)
+	}
+
+	snapshotAt := formatTime(s.now)
+	var rows []row
+	for _, b := range response.Balances {
+		rows = append(rows, row{
+			values: []any{snapshotAt, s.opts.PortfolioId, b.Symbol, b.Amount, b.Holds, b.WithdrawableAmount},
+			raw:    b,
+		})
+	}
+	result.Fetched = len(rows)
+
+	return s.now, s.store("balance_snapshots", []string{
+		"snapshot_at", "portfolio_id", "symbol", "amount", "holds", "withdrawable_amount",
+	}, rows)
+}
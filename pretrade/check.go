@@ -0,0 +1,341 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package pretrade gathers buying power, balances, an order preview and the
+// commission rate for a proposed order and reduces them to a single verdict.
+package pretrade
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/balances"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/commission"
+	"github.com/coinbase/prime-sdk-go/financing"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	VerdictSufficientFunds  = "sufficient_funds"
+	VerdictSufficientCredit = "sufficient_credit"
+	VerdictInsufficient     = "insufficient"
+	VerdictUnknown          = "unknown"
+)
+
+// Report is the outcome of a check. Fees and the estimated total are in the
+// product's quote currency. Required, available and buying power are in the
+// currency the order spends: the quote currency for a BUY and the base
+// currency for a SELL.
+type Report struct {
+	Verdict        string   `json:"verdict"`
+	ProductId      string   `json:"product_id"`
+	Side           string   `json:"side"`
+	Type           string   `json:"type"`
+	BaseQuantity   string   `json:"base_quantity,omitempty"`
+	Price          string   `json:"estimated_price,omitempty"`
+	Notional       string   `json:"notional,omitempty"`
+	Fees           string   `json:"expected_fees,omitempty"`
+	CommissionRate string   `json:"commission_rate,omitempty"`
+	EstimatedTotal string   `json:"estimated_total,omitempty"`
+	QuoteCurrency  string   `json:"quote_currency"`
+	SpendCurrency  string   `json:"spend_currency"`
+	Required       string   `json:"required,omitempty"`
+	Available      string   `json:"available,omitempty"`
+	BuyingPower    string   `json:"buying_power,omitempty"`
+	Warnings       []string `json:"warnings,omitempty"`
+
+	Preview *model.Order `json:"preview,omitempty"`
+}
+
+type results struct {
+	preview     *model.Order
+	balance     *model.Balance
+	buyingPower *model.BuyingPower
+	commission  *model.Commission
+
+	previewErr, balanceErr, buyingPowerErr, commissionErr error
+}
+
+// Check runs the four lookups concurrently. A failed lookup becomes a
+// warning rather than an error so the remaining information is still
+// reported; the verdict is unknown when funds cannot be compared. The side is
+// upper-cased in place and must be BUY or SELL.
+func Check(
+	c client.RestClient,
+	order *model.Order,
+	newContext func() (context.Context, context.CancelFunc),
+) (*Report, error) {
+	base, quote, ok := strings.Cut(order.ProductId, "-")
+	if !ok || base == "" || quote == "" {
+		return nil, fmt.Errorf("product ID %q is not in BASE-QUOTE form", order.ProductId)
+	}
+
+	order.Side = strings.ToUpper(order.Side)
+	if err := utils.ValidateSideValue(order.Side); err != nil {
+		return nil, err
+	}
+	order.Type = strings.ToUpper(order.Type)
+
+	spend := quote
+	if order.Side == utils.OrderSideSell {
+		spend = base
+	}
+
+	r := fetch(c, order, base, quote, spend, newContext)
+
+	report := &Report{
+		Verdict:       VerdictUnknown,
+		ProductId:     order.ProductId,
+		Side:          order.Side,
+		Type:          order.Type,
+		QuoteCurrency: quote,
+		SpendCurrency: spend,
+		Preview:       r.preview,
+	}
+
+	warn := func(format string, args ...any) {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(format, args...))
+	}
+	if r.previewErr != nil {
+		warn("order preview failed: %v", r.previewErr)
+	}
+	if r.balanceErr != nil {
+		warn("balance lookup failed: %v", r.balanceErr)
+	}
+	if r.buyingPowerErr != nil {
+		warn("buying power lookup failed: %v", r.buyingPowerErr)
+	}
+	if r.commissionErr != nil {
+		warn("commission lookup failed: %v", r.commissionErr)
+	}
+
+	price := estimatePrice(order, r.preview)
+	if price.IsPositive() {
+		report.Price = price.String()
+	} else {
+		warn("no price available to estimate the notional")
+	}
+
+	quantity := amount(order.BaseQuantity)
+	if r.preview != nil && amount(r.preview.BaseQuantity).IsPositive() {
+		quantity = amount(r.preview.BaseQuantity)
+	}
+	notional := amount(order.QuoteValue)
+	if r.preview != nil && amount(r.preview.QuoteValue).IsPositive() {
+		notional = amount(r.preview.QuoteValue)
+	}
+	switch {
+	case !quantity.IsPositive() && notional.IsPositive() && price.IsPositive():
+		quantity = notional.Div(price)
+	case !notional.IsPositive() && quantity.IsPositive() && price.IsPositive():
+		notional = quantity.Mul(price)
+	}
+	if quantity.IsPositive() {
+		report.BaseQuantity = quantity.String()
+	}
+	if notional.IsPositive() {
+		report.Notional = notional.String()
+	}
+
+	if r.commission != nil {
+		report.CommissionRate = r.commission.Rate
+	}
+	fees, feesKnown := decimal.Zero, false
+	if p := r.preview; p != nil && (p.Commission != "" || p.ExchangeFee != "") {
+		fees, feesKnown = amount(p.Commission).Add(amount(p.ExchangeFee)), true
+	} else if rate := amount(report.CommissionRate); rate.IsPositive() && notional.IsPositive() {
+		fees, feesKnown = notional.Mul(rate), true
+		warn("fees estimated from the commission rate because the preview has none")
+	}
+	if feesKnown {
+		report.Fees = fees.String()
+	}
+
+	if notional.IsPositive() {
+		if order.Side == utils.OrderSideBuy {
+			report.EstimatedTotal = notional.Add(fees).String()
+		} else {
+			report.EstimatedTotal = notional.Sub(fees).String()
+		}
+	}
+
+	var required decimal.Decimal
+	if order.Side == utils.OrderSideBuy {
+		if notional.IsPositive() {
+			required = notional.Add(fees)
+		}
+	} else {
+		required = quantity
+	}
+	if required.IsPositive() {
+		report.Required = required.String()
+	}
+
+	available, haveAvailable := decimal.Zero, false
+	if r.balance != nil {
+		available, haveAvailable = amount(r.balance.Amount).Sub(amount(r.balance.Holds)), true
+		report.Available = available.String()
+		if amount(r.balance.Holds).IsPositive() {
+			warn("%s %s is on hold and excluded from the available balance", r.balance.Holds, spend)
+		}
+	} else if r.balanceErr == nil {
+		available, haveAvailable = decimal.Zero, true
+		report.Available = "0"
+	}
+
+	buyingPower, haveBuyingPower := decimal.Zero, false
+	if bp := r.buyingPower; bp != nil {
+		value := bp.QuoteBuyingPower
+		if order.Side != utils.OrderSideBuy {
+			value = bp.BaseBuyingPower
+		}
+		if value != "" {
+			buyingPower, haveBuyingPower = amount(value), true
+			report.BuyingPower = buyingPower.String()
+		}
+	}
+
+	switch {
+	case !required.IsPositive():
+	case haveAvailable && available.GreaterThanOrEqual(required):
+		report.Verdict = VerdictSufficientFunds
+	case haveBuyingPower && buyingPower.GreaterThanOrEqual(required):
+		report.Verdict = VerdictSufficientCredit
+		warn("the order exceeds the available %s balance and relies on credit", spend)
+	case haveAvailable && haveBuyingPower:
+		report.Verdict = VerdictInsufficient
+	case haveAvailable && !haveBuyingPower:
+		// Credit may still cover the shortfall, but it could not be checked.
+		warn("the available %s balance is short and buying power is unknown", spend)
+	}
+
+	if p := r.preview; p != nil && order.Type == utils.OrderTypeLimit {
+		limit := amount(order.LimitPrice)
+		if order.Side == utils.OrderSideBuy && amount(p.BestAsk).IsPositive() && limit.GreaterThanOrEqual(amount(p.BestAsk)) {
+			warn("limit price %s is at or above the best ask %s and will take liquidity", order.LimitPrice, p.BestAsk)
+		}
+		if order.Side != utils.OrderSideBuy && amount(p.BestBid).IsPositive() && limit.IsPositive() && limit.LessThanOrEqual(amount(p.BestBid)) {
+			warn("limit price %s is at or below the best bid %s and will take liquidity", order.LimitPrice, p.BestBid)
+		}
+	}
+
+	return report, nil
+}
+
+func fetch(
+	c client.RestClient,
+	order *model.Order,
+	base, quote, spend string,
+	newContext func() (context.Context, context.CancelFunc),
+) *results {
+	r := &results{}
+	var wg sync.WaitGroup
+	run := func(f func(ctx context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := newContext()
+			defer cancel()
+			f(ctx)
+		}()
+	}
+
+	run(func(ctx context.Context) {
+		response, err := orders.NewOrdersService(c).CreateOrderPreview(ctx, &orders.CreateOrderRequest{Order: order})
+		if err != nil {
+			r.previewErr = err
+			return
+		}
+		r.preview = response.Order
+	})
+
+	run(func(ctx context.Context) {
+		response, err := balances.NewBalancesService(c).ListPortfolioBalances(ctx, &balances.ListPortfolioBalancesRequest{
+			PortfolioId: order.PortfolioId,
+			Symbols:     []string{spend},
+		})
+		if err != nil {
+			r.balanceErr = err
+			return
+		}
+		for _, b := range response.Balances {
+			if strings.EqualFold(b.Symbol, spend) {
+				r.balance = b
+				break
+			}
+		}
+	})
+
+	run(func(ctx context.Context) {
+		response, err := financing.NewFinancingService(c).GetBuyingPower(ctx, &financing.GetBuyingPowerRequest{
+			PortfolioId:   order.PortfolioId,
+			BaseCurrency:  base,
+			QuoteCurrency: quote,
+		})
+		if err != nil {
+			r.buyingPowerErr = err
+			return
+		}
+		r.buyingPower = response.BuyingPower
+	})
+
+	run(func(ctx context.Context) {
+		response, err := commission.NewCommissionService(c).GetPortfolioCommission(ctx, &commission.GetPortfolioCommissionRequest{
+			PortfolioId: order.PortfolioId,
+			ProductId:   order.ProductId,
+		})
+		if err != nil {
+			r.commissionErr = err
+			return
+		}
+		r.commission = response.Commission
+	})
+
+	wg.Wait()
+	return r
+}
+
+// estimatePrice prefers the previewed average price, then the limit price
+// and finally the side of the book the order would take.
+func estimatePrice(order, preview *model.Order) decimal.Decimal {
+	candidates := []string{order.LimitPrice}
+	if preview != nil {
+		book := preview.BestBid
+		if order.Side == utils.OrderSideBuy {
+			book = preview.BestAsk
+		}
+		candidates = []string{preview.AverageFilledPrice, order.LimitPrice, book}
+	}
+	for _, price := range candidates {
+		if p := amount(price); p.IsPositive() {
+			return p
+		}
+	}
+	return decimal.Zero
+}
+
+func amount(value string) decimal.Decimal {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero
+	}
+	return d
+}
@@ -0,0 +1,407 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/invoice"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	monthLayout      = "2006-01"
+	unknownOrderType = "UNKNOWN"
+	orderTypeWorkers = 8
+	rateDecimals     = 6
+
+	FlagRate      = "rate"
+	FlagInvoice   = "entity-invoice"
+	FlagNoInvoice = "entity-no-invoice"
+)
+
+// FeeGroup totals the fills for one month, product and order type.
+type FeeGroup struct {
+	Month       string
+	ProductId   string
+	OrderType   string
+	Quote       string
+	Fills       int
+	FilledValue decimal.Decimal
+	Commission  decimal.Decimal
+}
+
+func (g *FeeGroup) EffectiveRate() decimal.Decimal {
+	if g.FilledValue.IsZero() {
+		return decimal.Zero
+	}
+	return g.Commission.Div(g.FilledValue)
+}
+
+// MonthSummary reconciles one month and quote currency. Invoice amounts are
+// only compared for USD, the currency invoices are billed in. Invoices cover
+// the whole entity, so the invoiced amounts are entity-wide while the
+// commission is the portfolio's own.
+type MonthSummary struct {
+	Month              string
+	Quote              string
+	FilledValue        decimal.Decimal
+	Commission         decimal.Decimal
+	ExpectedCommission decimal.Decimal
+	InvoicedCommission *decimal.Decimal
+	InvoiceTotal       *decimal.Decimal
+	Flags              []string
+}
+
+// AggregateFees groups fills by month (UTC), product and order type.
+// orderTypes maps order IDs to types; missing orders are grouped as UNKNOWN.
+func AggregateFees(fills []*model.OrderFill, orderTypes map[string]string) ([]*FeeGroup, error) {
+	groups := map[string]*FeeGroup{}
+	for _, f := range fills {
+		value, err := fillValue(f)
+		if err != nil {
+			return nil, err
+		}
+		commission := decimal.Zero
+		if f.Commission != "" {
+			if commission, err = decimal.NewFromString(f.Commission); err != nil {
+				return nil, fmt.Errorf("fill %s has invalid commission %q: %w", f.Id, f.Commission, err)
+			}
+		}
+
+		orderType := orderTypes[f.OrderId]
+		if orderType == "" {
+			orderType = unknownOrderType
+		}
+		month := f.Time.UTC().Format(monthLayout)
+		key := month + "|" + f.ProductId + "|" + orderType
+
+		g, ok := groups[key]
+		if !ok {
+			g = &FeeGroup{Month: month, ProductId: f.ProductId, OrderType: orderType, Quote: quoteOf(f.ProductId)}
+			groups[key] = g
+		}
+		g.Fills++
+		g.FilledValue = g.FilledValue.Add(value)
+		g.Commission = g.Commission.Add(commission)
+	}
+
+	result := make([]*FeeGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.ProductId != b.ProductId {
+			return a.ProductId < b.ProductId
+		}
+		return a.OrderType < b.OrderType
+	})
+	return result, nil
+}
+
+// fillValue prefers the reported filled value and falls back to
+// quantity times price.
+func fillValue(f *model.OrderFill) (decimal.Decimal, error) {
+	if f.FilledValue != "" {
+		value, err := decimal.NewFromString(f.FilledValue)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("fill %s has invalid filled value %q: %w", f.Id, f.FilledValue, err)
+		}
+		return value, nil
+	}
+	quantity, err := decimal.NewFromString(f.FilledQuantity)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("fill %s has invalid filled quantity %q: %w", f.Id, f.FilledQuantity, err)
+	}
+	price, err := decimal.NewFromString(f.Price)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("fill %s has invalid price %q: %w", f.Id, f.Price, err)
+	}
+	return quantity.Mul(price), nil
+}
+
+func quoteOf(productId string) string {
+	if i := strings.LastIndex(productId, "-"); i >= 0 {
+		return productId[i+1:]
+	}
+	return ""
+}
+
+// Reconcile totals the groups per month and quote currency and compares them
+// with the commission expected at rate and with the month's invoices, keyed
+// by "2006-01". Differences larger than tolerance (relative) are flagged.
+// A nil invoicesByMonth skips the invoice comparison; callers should only
+// pass invoices when the fills cover whole billing months.
+func Reconcile(
+	groups []*FeeGroup,
+	rate decimal.Decimal,
+	invoicesByMonth map[string][]*model.Invoice,
+	tolerance decimal.Decimal,
+) []*MonthSummary {
+	byKey := map[string]*MonthSummary{}
+	var summaries []*MonthSummary
+	for _, g := range groups {
+		key := g.Month + "|" + g.Quote
+		s, ok := byKey[key]
+		if !ok {
+			s = &MonthSummary{Month: g.Month, Quote: g.Quote}
+			byKey[key] = s
+			summaries = append(summaries, s)
+		}
+		s.FilledValue = s.FilledValue.Add(g.FilledValue)
+		s.Commission = s.Commission.Add(g.Commission)
+	}
+
+	for _, s := range summaries {
+		s.ExpectedCommission = s.FilledValue.Mul(rate)
+		if differs(s.Commission, s.ExpectedCommission, tolerance) {
+			s.Flags = append(s.Flags, FlagRate)
+		}
+
+		if invoicesByMonth == nil || s.Quote != "USD" {
+			continue
+		}
+		invoices, ok := invoicesByMonth[s.Month]
+		if !ok || len(invoices) == 0 {
+			s.Flags = append(s.Flags, FlagNoInvoice)
+			continue
+		}
+		invoiced, total := decimal.Zero, decimal.Zero
+		found := false
+		for _, inv := range invoices {
+			for _, item := range inv.Items {
+				amount := decimal.NewFromFloat(item.Total)
+				total = total.Add(amount)
+				if isCommissionItem(item) {
+					invoiced = invoiced.Add(amount)
+					found = true
+				}
+			}
+		}
+		s.InvoiceTotal = &total
+		if found {
+			s.InvoicedCommission = &invoiced
+			if differs(s.Commission, invoiced, tolerance) {
+				s.Flags = append(s.Flags, FlagInvoice)
+			}
+		}
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Month != summaries[j].Month {
+			return summaries[i].Month < summaries[j].Month
+		}
+		return summaries[i].Quote < summaries[j].Quote
+	})
+	return summaries
+}
+
+// isCommissionItem picks out invoice lines for trading commission. Invoice
+// types only name custody fees, so commission lines are matched on the
+// description.
+func isCommissionItem(item *model.InvoiceItem) bool {
+	description := strings.ToLower(item.Description)
+	return strings.Contains(description, "commission") || strings.Contains(description, "trading")
+}
+
+func differs(actual, expected, tolerance decimal.Decimal) bool {
+	diff := actual.Sub(expected).Abs()
+	if expected.IsZero() {
+		return !diff.IsZero()
+	}
+	return diff.GreaterThan(expected.Abs().Mul(tolerance))
+}
+
+// FetchOrderTypes looks up the type of each order. Orders that cannot be
+// fetched are returned in the error map and left out of the result.
+func FetchOrderTypes(
+	c client.RestClient,
+	portfolioId string,
+	orderIds []string,
+	newContext func() (context.Context, context.CancelFunc),
+) (map[string]string, map[string]error) {
+	types := map[string]string{}
+	failed := map[string]error{}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	ids := make(chan string)
+	for range orderTypeWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			svc := orders.NewOrdersService(c)
+			for id := range ids {
+				ctx, cancel := newContext()
+				response, err := svc.GetOrder(ctx, &orders.GetOrderRequest{PortfolioId: portfolioId, OrderId: id})
+				cancel()
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					failed[id] = err
+				case response.Order == nil:
+					failed[id] = fmt.Errorf("order %s not returned", id)
+				default:
+					types[id] = response.Order.Type
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, id := range orderIds {
+		ids <- id
+	}
+	close(ids)
+	wg.Wait()
+	return types, failed
+}
+
+// OrderIds returns the distinct order IDs of the fills.
+func OrderIds(fills []*model.OrderFill) []string {
+	seen := map[string]bool{}
+	var ids []string
+	for _, f := range fills {
+		if f.OrderId != "" && !seen[f.OrderId] {
+			seen[f.OrderId] = true
+			ids = append(ids, f.OrderId)
+		}
+	}
+	return ids
+}
+
+// FetchInvoices lists the entity's invoices for each month, keyed by
+// "2006-01".
+func FetchInvoices(
+	c client.RestClient,
+	entityId string,
+	months []string,
+	newContext func() (context.Context, context.CancelFunc),
+) (map[string][]*model.Invoice, error) {
+	svc := invoice.NewInvoiceService(c)
+	result := map[string][]*model.Invoice{}
+	for _, month := range months {
+		t, err := time.Parse(monthLayout, month)
+		if err != nil {
+			return nil, fmt.Errorf("invalid month %q: %w", month, err)
+		}
+
+		ctx, cancel := newContext()
+		response, err := svc.ListInvoices(ctx, &invoice.ListInvoicesRequest{
+			EntityId:     entityId,
+			BillingYear:  int32(t.Year()),
+			BillingMonth: int32(t.Month()),
+			Pagination:   &model.PaginationParams{},
+		})
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("cannot list invoices for %s: %w", month, err)
+		}
+		all, err := response.Iterator().FetchAll(ctx)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list invoices for %s: %w", month, err)
+		}
+		result[month] = all
+	}
+	return result, nil
+}
+
+// Months returns the distinct months of the groups in order.
+func Months(groups []*FeeGroup) []string {
+	seen := map[string]bool{}
+	var months []string
+	for _, g := range groups {
+		if !seen[g.Month] {
+			seen[g.Month] = true
+			months = append(months, g.Month)
+		}
+	}
+	sort.Strings(months)
+	return months
+}
+
+func FeesTable(groups []*FeeGroup, rate decimal.Decimal) *Table {
+	t := &Table{
+		Title: "Commission by month, product and order type",
+		Headers: []string{
+			"MONTH", "PRODUCT", "ORDER_TYPE", "FILLS", "FILLED_VALUE", "COMMISSION",
+			"EFFECTIVE_RATE", "PORTFOLIO_RATE", "EXPECTED_COMMISSION", "DIFFERENCE",
+		},
+	}
+	for _, g := range groups {
+		expected := g.FilledValue.Mul(rate)
+		t.Rows = append(t.Rows, []string{
+			g.Month,
+			g.ProductId,
+			g.OrderType,
+			fmt.Sprint(g.Fills),
+			g.FilledValue.Round(priceDecimals).String(),
+			g.Commission.String(),
+			g.EffectiveRate().Round(rateDecimals).String(),
+			rate.String(),
+			expected.Round(priceDecimals).String(),
+			g.Commission.Sub(expected).Round(priceDecimals).String(),
+		})
+	}
+	return t
+}
+
+func ReconciliationTable(summaries []*MonthSummary) *Table {
+	t := &Table{
+		Title: "Reconciliation",
+		Headers: []string{
+			"MONTH", "QUOTE", "FILLED_VALUE", "COMMISSION", "EXPECTED_COMMISSION",
+			"ENTITY_INVOICED_COMMISSION", "ENTITY_INVOICE_TOTAL", "STATUS",
+		},
+	}
+	for _, s := range summaries {
+		invoiced, total := "", ""
+		if s.InvoicedCommission != nil {
+			invoiced = s.InvoicedCommission.String()
+		}
+		if s.InvoiceTotal != nil {
+			total = s.InvoiceTotal.String()
+		}
+		status := "OK"
+		if len(s.Flags) > 0 {
+			status = "MISMATCH: " + strings.Join(s.Flags, ",")
+		}
+		t.Rows = append(t.Rows, []string{
+			s.Month,
+			s.Quote,
+			s.FilledValue.Round(priceDecimals).String(),
+			s.Commission.String(),
+			s.ExpectedCommission.Round(priceDecimals).String(),
+			invoiced,
+			total,
+			status,
+		})
+	}
+	return t
+}
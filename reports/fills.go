@@ -0,0 +1,84 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+)
+
+const fillsPageLimit = 250
+
+// FetchFills pages through every portfolio fill between start and end and
+// returns them oldest first. Each page gets its own context so long periods
+// are not cut short by the request timeout.
+func FetchFills(
+	c client.RestClient,
+	portfolioId string,
+	start,
+	end time.Time,
+	newContext func() (context.Context, context.CancelFunc),
+) ([]*model.OrderFill, error) {
+	svc := orders.NewOrdersService(c)
+	request := &orders.ListPortfolioFillsRequest{
+		PortfolioId: portfolioId,
+		Start:       start,
+		End:         end,
+		Pagination:  &model.PaginationParams{Limit: fillsPageLimit, SortDirection: "ASC"},
+	}
+
+	var all []*model.OrderFill
+	for {
+		ctx, cancel := newContext()
+		response, err := svc.ListPortfolioFills(ctx, request)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("cannot list portfolio fills: %w", err)
+		}
+		all = append(all, response.Fills...)
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			break
+		}
+		request.Pagination.Cursor = response.Pagination.NextCursor
+	}
+
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Time.Before(all[j].Time) })
+	return all, nil
+}
+
+// FilterProducts keeps fills for the given products. An empty list keeps all.
+func FilterProducts(fills []*model.OrderFill, productIds []string) []*model.OrderFill {
+	if len(productIds) == 0 {
+		return fills
+	}
+	keep := map[string]bool{}
+	for _, id := range productIds {
+		keep[id] = true
+	}
+	var result []*model.OrderFill
+	for _, f := range fills {
+		if keep[f.ProductId] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
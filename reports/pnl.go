@@ -0,0 +1,263 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package reports
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/products"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	MethodFifo    = "fifo"
+	MethodLifo    = "lifo"
+	MethodAverage = "average"
+
+	markLookback    = 24 * time.Hour
+	markGranularity = model.CandleGranularityOneHour
+	priceDecimals   = 8
+)
+
+var Methods = []string{MethodFifo, MethodLifo, MethodAverage}
+
+// lot is an open quantity at a cost. Long lots have a positive quantity and
+// short lots a negative one.
+type lot struct {
+	quantity decimal.Decimal
+	price    decimal.Decimal
+}
+
+// ProductPnl is the result for one product. Amounts are in the product's
+// quote currency; commission is assumed to be charged in the quote currency.
+type ProductPnl struct {
+	ProductId      string
+	Fills          int
+	Bought         decimal.Decimal
+	Sold           decimal.Decimal
+	Position       decimal.Decimal
+	CostBasis      decimal.Decimal
+	RealizedPnl    decimal.Decimal
+	Commission     decimal.Decimal
+	Mark           *decimal.Decimal
+	UnrealizedPnl  *decimal.Decimal
+	lots           []lot
+	averageCost    bool
+	lastInFirstOut bool
+}
+
+func (p *ProductPnl) NetRealizedPnl() decimal.Decimal {
+	return p.RealizedPnl.Sub(p.Commission)
+}
+
+// AverageCost is the cost per unit of the open position, or zero when flat.
+func (p *ProductPnl) AverageCost() decimal.Decimal {
+	if p.Position.IsZero() {
+		return decimal.Zero
+	}
+	return p.CostBasis.Div(p.Position).Abs()
+}
+
+// ComputePnl replays fills oldest first and matches closing quantity against
+// open lots using method. Only fills in the slice are considered, so a
+// position opened before the period starts is treated as opened by the first
+// closing fill seen.
+func ComputePnl(fills []*model.OrderFill, method string) ([]*ProductPnl, error) {
+	if err := ValidateMethod(method); err != nil {
+		return nil, err
+	}
+
+	byProduct := map[string]*ProductPnl{}
+	for _, f := range fills {
+		quantity, err := decimal.NewFromString(f.FilledQuantity)
+		if err != nil {
+			return nil, fmt.Errorf("fill %s has invalid filled quantity %q: %w", f.Id, f.FilledQuantity, err)
+		}
+		price, err := decimal.NewFromString(f.Price)
+		if err != nil {
+			return nil, fmt.Errorf("fill %s has invalid price %q: %w", f.Id, f.Price, err)
+		}
+		commission := decimal.Zero
+		if f.Commission != "" {
+			if commission, err = decimal.NewFromString(f.Commission); err != nil {
+				return nil, fmt.Errorf("fill %s has invalid commission %q: %w", f.Id, f.Commission, err)
+			}
+		}
+
+		p, ok := byProduct[f.ProductId]
+		if !ok {
+			p = &ProductPnl{
+				ProductId:      f.ProductId,
+				averageCost:    method == MethodAverage,
+				lastInFirstOut: method == MethodLifo,
+			}
+			byProduct[f.ProductId] = p
+		}
+
+		p.Fills++
+		p.Commission = p.Commission.Add(commission)
+		switch strings.ToUpper(f.Side) {
+		case "BUY":
+			p.Bought = p.Bought.Add(quantity)
+			p.apply(quantity, price)
+		case "SELL":
+			p.Sold = p.Sold.Add(quantity)
+			p.apply(quantity.Neg(), price)
+		default:
+			return nil, fmt.Errorf("fill %s has unknown side %q", f.Id, f.Side)
+		}
+	}
+
+	result := make([]*ProductPnl, 0, len(byProduct))
+	for _, p := range byProduct {
+		p.Position, p.CostBasis = decimal.Zero, decimal.Zero
+		for _, l := range p.lots {
+			p.Position = p.Position.Add(l.quantity)
+			p.CostBasis = p.CostBasis.Add(l.quantity.Mul(l.price))
+		}
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ProductId < result[j].ProductId })
+	return result, nil
+}
+
+// apply adds a signed quantity, first closing lots on the opposite side.
+func (p *ProductPnl) apply(quantity, price decimal.Decimal) {
+	for !quantity.IsZero() && len(p.lots) > 0 && p.lots[0].quantity.Sign() != quantity.Sign() {
+		idx := 0
+		if p.lastInFirstOut {
+			idx = len(p.lots) - 1
+		}
+		open := &p.lots[idx]
+
+		closed := decimal.Min(quantity.Abs(), open.quantity.Abs())
+		direction := decimal.NewFromInt(int64(open.quantity.Sign()))
+		p.RealizedPnl = p.RealizedPnl.Add(closed.Mul(price.Sub(open.price)).Mul(direction))
+
+		open.quantity = open.quantity.Sub(closed.Mul(direction))
+		quantity = quantity.Add(closed.Mul(direction))
+		if open.quantity.IsZero() {
+			p.lots = append(p.lots[:idx], p.lots[idx+1:]...)
+		}
+	}
+
+	if quantity.IsZero() {
+		return
+	}
+	if p.averageCost && len(p.lots) == 1 {
+		held := p.lots[0]
+		total := held.quantity.Add(quantity)
+		p.lots[0] = lot{
+			quantity: total,
+			price:    held.quantity.Mul(held.price).Add(quantity.Mul(price)).Div(total),
+		}
+		return
+	}
+	p.lots = append(p.lots, lot{quantity: quantity, price: price})
+}
+
+// MarkToMarket sets the mark price and unrealized PnL of the open position.
+func (p *ProductPnl) MarkToMarket(mark decimal.Decimal) {
+	unrealized := mark.Mul(p.Position).Sub(p.CostBasis)
+	p.Mark, p.UnrealizedPnl = &mark, &unrealized
+}
+
+func ValidateMethod(method string) error {
+	for _, m := range Methods {
+		if m == method {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown method %q, expected one of: %s", method, strings.Join(Methods, ", "))
+}
+
+// LatestClose returns the close of the most recent hourly candle at or
+// before at.
+func LatestClose(
+	ctx context.Context,
+	c client.RestClient,
+	portfolioId,
+	productId string,
+	at time.Time,
+) (decimal.Decimal, error) {
+	response, err := products.NewProductsService(c).GetProductCandles(ctx, &products.GetProductCandlesRequest{
+		PortfolioId: portfolioId,
+		ProductId:   productId,
+		StartTime:   at.Add(-markLookback),
+		EndTime:     at,
+		Granularity: markGranularity,
+	})
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("cannot get candles for %s: %w", productId, err)
+	}
+
+	var latest *model.Candle
+	var latestAt time.Time
+	for _, candle := range response.Candles {
+		t, err := time.Parse(time.RFC3339, candle.Timestamp)
+		if err != nil {
+			continue
+		}
+		if latest == nil || t.After(latestAt) {
+			latest, latestAt = candle, t
+		}
+	}
+	if latest == nil {
+		return decimal.Zero, fmt.Errorf("no candles for %s in the %s before %s", productId, markLookback, at.Format(time.RFC3339))
+	}
+
+	closePrice, err := decimal.NewFromString(latest.Close)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid close %q for %s: %w", latest.Close, productId, err)
+	}
+	return closePrice, nil
+}
+
+// PnlTable lays out results one row per product.
+func PnlTable(results []*ProductPnl) *Table {
+	t := &Table{Headers: []string{
+		"PRODUCT", "FILLS", "BOUGHT", "SOLD", "POSITION", "AVG_COST", "COST_BASIS",
+		"REALIZED_PNL", "COMMISSION", "NET_REALIZED_PNL", "MARK", "UNREALIZED_PNL",
+	}}
+	for _, p := range results {
+		mark, unrealized := "", ""
+		if p.Mark != nil {
+			mark = p.Mark.String()
+			unrealized = p.UnrealizedPnl.Round(priceDecimals).String()
+		}
+		t.Rows = append(t.Rows, []string{
+			p.ProductId,
+			fmt.Sprint(p.Fills),
+			p.Bought.String(),
+			p.Sold.String(),
+			p.Position.String(),
+			p.AverageCost().Round(priceDecimals).String(),
+			p.CostBasis.Round(priceDecimals).String(),
+			p.RealizedPnl.Round(priceDecimals).String(),
+			p.Commission.String(),
+			p.NetRealizedPnl().Round(priceDecimals).String(),
+			mark,
+			unrealized,
+		})
+	}
+	return t
+}
@@ -0,0 +1,89 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package reports computes trading reports from portfolio fills and renders
+// them as aligned tables or CSV.
+package reports
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	OutputTable = "table"
+	OutputCsv   = "csv"
+)
+
+var Outputs = []string{OutputTable, OutputCsv}
+
+// Table is a titled grid of rows. The title is printed above text output
+// and omitted from CSV.
+type Table struct {
+	Title   string
+	Headers []string
+	Rows    [][]string
+}
+
+// Write renders the table in the given output format.
+func (t *Table) Write(w io.Writer, output string) error {
+	switch output {
+	case OutputTable:
+		return t.writeText(w)
+	case OutputCsv:
+		return t.writeCsv(w)
+	default:
+		return fmt.Errorf("unknown output %q, expected one of: %s", output, strings.Join(Outputs, ", "))
+	}
+}
+
+// WriteTables renders several tables separated by a blank line.
+func WriteTables(w io.Writer, output string, tables ...*Table) error {
+	for i, t := range tables {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if err := t.Write(w, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) writeText(w io.Writer) error {
+	if t.Title != "" {
+		fmt.Fprintln(w, t.Title)
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Headers, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t *Table) writeCsv(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,210 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coinbase/prime-sdk-go/addressbook"
+	"github.com/coinbase/prime-sdk-go/onchainaddressbook"
+)
+
+const (
+	SourceAddressBook  = "address_book"
+	SourceOnchainGroup = "onchain_address_group"
+)
+
+// Destination is a withdrawal destination known to the portfolio, taken from
+// either the address book or an onchain address group.
+type Destination struct {
+	Name              string `json:"name"`
+	Address           string `json:"address"`
+	AccountIdentifier string `json:"account_identifier,omitempty"`
+	Source            string `json:"source"`
+	Group             string `json:"group,omitempty"`
+	EntryId           string `json:"entry_id,omitempty"`
+	State             string `json:"state,omitempty"`
+}
+
+// activeStates are the address book entry states that can receive funds.
+// Entries pending approval or rejected are refused.
+var activeStates = []string{"ACTIVE", "APPROVED"}
+
+// Active reports whether the destination can be sent to. Onchain address
+// group entries carry no state and are always active.
+func (d *Destination) Active() bool {
+	if d.Source == SourceOnchainGroup {
+		return true
+	}
+	for _, state := range activeStates {
+		if strings.EqualFold(d.State, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe renders the destination for confirmation and error messages.
+func (d *Destination) Describe() string {
+	if d.Group != "" {
+		return fmt.Sprintf("%q [%s %q, address=%s]", d.Name, d.Source, d.Group, d.Address)
+	}
+	return fmt.Sprintf("%q [%s, address=%s]", d.Name, d.Source, d.Address)
+}
+
+// Destinations lists the address book entries for symbol followed by every
+// address in the portfolio's onchain address groups. Onchain groups are keyed
+// by network rather than currency, so they are not filtered by symbol.
+func (r *Resolver) Destinations(ctx context.Context, portfolioId, symbol string) ([]*Destination, error) {
+	response, err := addressbook.NewAddressBookService(r.client).GetAddressBook(ctx, &addressbook.GetAddressBookRequest{
+		PortfolioId: portfolioId,
+		Symbol:      symbol,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list address book: %w", err)
+	}
+
+	entries, err := response.Iterator().FetchAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list address book: %w", err)
+	}
+
+	var destinations []*Destination
+	for _, e := range entries {
+		if symbol != "" && e.Symbol != "" && !strings.EqualFold(e.Symbol, symbol) {
+			continue
+		}
+		destinations = append(destinations, &Destination{
+			Name:              e.Name,
+			Address:           e.Address,
+			AccountIdentifier: e.AccountIdentifier,
+			Source:            SourceAddressBook,
+			EntryId:           e.Id,
+			State:             e.State,
+		})
+	}
+
+	groups, err := onchainaddressbook.NewOnchainAddressBookService(r.client).ListOnchainAddressBookGroups(ctx, &onchainaddressbook.ListOnchainAddressBookGroupsRequest{
+		PortfolioId: portfolioId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list onchain address groups: %w", err)
+	}
+
+	for _, g := range groups.AddressGroups {
+		for _, a := range g.Addresses {
+			destinations = append(destinations, &Destination{
+				Name:    a.Name,
+				Address: a.Address,
+				Source:  SourceOnchainGroup,
+				Group:   g.Name,
+				EntryId: g.Id,
+			})
+		}
+	}
+
+	return destinations, nil
+}
+
+// VerifyDestination returns the known destination for address, or an error
+// when the address is in neither the address book nor an onchain group. An
+// entry that carries an account identifier (memo or tag) only matches when
+// the same identifier is supplied.
+func (r *Resolver) VerifyDestination(ctx context.Context, portfolioId, symbol, address, accountIdentifier string) (*Destination, error) {
+	destinations, err := r.Destinations(ctx, portfolioId, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatched, inactive *Destination
+	for _, d := range destinations {
+		if !SameAddress(d.Address, address) {
+			continue
+		}
+		if !d.Active() {
+			inactive = d
+			continue
+		}
+		if d.AccountIdentifier == accountIdentifier || d.Source == SourceOnchainGroup {
+			return d, nil
+		}
+		mismatched = d
+	}
+
+	switch {
+	case mismatched != nil:
+		return nil, fmt.Errorf("address %s matches %s but account identifier %q does not match %q", address, mismatched.Describe(), accountIdentifier, mismatched.AccountIdentifier)
+	case inactive != nil:
+		return nil, fmt.Errorf("address %s matches %s but the entry is %s, not active", address, inactive.Describe(), inactive.State)
+	}
+	return nil, fmt.Errorf("address %s is not in the %s address book or any onchain address group", address, symbol)
+}
+
+// DestinationByName resolves an active address book or onchain group entry
+// by its exact, case-insensitive name. Entries that only contain name are
+// listed in the error but never selected.
+func (r *Resolver) DestinationByName(ctx context.Context, portfolioId, symbol, name string) (*Destination, error) {
+	destinations, err := r.Destinations(ctx, portfolioId, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var exact, partial []*Destination
+	for _, d := range destinations {
+		if strings.EqualFold(d.Name, name) {
+			exact = append(exact, d)
+		} else if strings.Contains(strings.ToLower(d.Name), strings.ToLower(name)) {
+			partial = append(partial, d)
+		}
+	}
+
+	switch len(exact) {
+	case 0:
+		if len(partial) > 0 {
+			return nil, &AmbiguousError{Kind: "destination", Ref: name, Candidates: describeDestinations(partial)}
+		}
+		return nil, fmt.Errorf("no %s address book or onchain group entry is named %q", symbol, name)
+	case 1:
+		if !exact[0].Active() {
+			return nil, fmt.Errorf("destination %s is %s, not active", exact[0].Describe(), exact[0].State)
+		}
+		return exact[0], nil
+	default:
+		return nil, &AmbiguousError{Kind: "destination", Ref: name, Candidates: describeDestinations(exact)}
+	}
+}
+
+func describeDestinations(destinations []*Destination) []string {
+	var candidates []string
+	for _, d := range destinations {
+		candidates = append(candidates, d.Describe())
+	}
+	return candidates
+}
+
+// SameAddress compares blockchain addresses. Hex (0x) addresses are compared
+// case-insensitively since their case is only a checksum; all other formats
+// are case-sensitive.
+func SameAddress(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	if strings.HasPrefix(a, "0x") && strings.HasPrefix(b, "0x") {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,371 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package resolver turns human-friendly wallet and portfolio references into
+// Prime IDs. It is shared by the CLI flags and the MCP tools.
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/portfolios"
+	"github.com/coinbase/prime-sdk-go/wallets"
+	"github.com/google/uuid"
+)
+
+const WalletTypeQc = "QC"
+
+// searchWalletTypes are listed when a wallet is referenced by name only.
+var searchWalletTypes = []string{
+	model.WalletTypeTrading,
+	model.WalletTypeVault,
+	WalletTypeQc,
+	model.WalletTypeOnchain,
+}
+
+// AmbiguousError is returned when a reference matches more than one wallet or
+// portfolio. Candidates describe each match so the caller can disambiguate.
+type AmbiguousError struct {
+	Kind       string
+	Ref        string
+	Candidates []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%s %q is ambiguous; candidates: %s", e.Kind, e.Ref, strings.Join(e.Candidates, ", "))
+}
+
+// WalletQuery filters wallets by symbol, type, network and name. An empty
+// Type prefers TRADING wallets and falls back to QC.
+type WalletQuery struct {
+	Symbol       string
+	Type         string
+	NetworkId    string
+	NameContains string
+}
+
+type Resolver struct {
+	client  client.RestClient
+	aliases config.Aliases
+}
+
+// New returns a resolver using the aliases from the user config.
+func New(c client.RestClient) (*Resolver, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Resolver{client: c, aliases: cfg.Aliases}, nil
+}
+
+// IsId reports whether ref is already a UUID and needs no lookup.
+func IsId(ref string) bool {
+	if len(ref) != 36 {
+		return false
+	}
+	_, err := uuid.Parse(ref)
+	return err == nil
+}
+
+func lookupAlias(aliases map[string]string, ref string) (string, bool) {
+	for name, value := range aliases {
+		if strings.EqualFold(name, ref) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// PortfolioId resolves a portfolio UUID, alias or name.
+func (r *Resolver) PortfolioId(ctx context.Context, ref string) (string, error) {
+	if IsId(ref) {
+		return ref, nil
+	}
+
+	if value, ok := lookupAlias(r.aliases.Portfolios, ref); ok {
+		if IsId(value) {
+			return value, nil
+		}
+		ref = value
+	}
+
+	response, err := portfolios.NewPortfoliosService(r.client).ListPortfolios(ctx, &portfolios.ListPortfoliosRequest{})
+	if err != nil {
+		return "", fmt.Errorf("cannot list portfolios: %w", err)
+	}
+
+	exact, partial := matchPortfolios(response.Portfolios, ref)
+	switch {
+	case len(exact) == 1:
+		return exact[0].Id, nil
+	case len(exact) > 1:
+		return "", &AmbiguousError{Kind: "portfolio", Ref: ref, Candidates: describePortfolios(exact)}
+	case len(partial) > 0:
+		return "", &AmbiguousError{Kind: "portfolio", Ref: ref, Candidates: describePortfolios(partial)}
+	default:
+		var names []string
+		for _, p := range response.Portfolios {
+			names = append(names, fmt.Sprintf("%q", p.Name))
+		}
+		return "", fmt.Errorf("no portfolio matches %q; available: %s", ref, strings.Join(names, ", "))
+	}
+}
+
+// matchPortfolios returns the portfolios named ref and, separately, those
+// whose name only contains it. Partial matches are never selected; they are
+// offered as candidates.
+func matchPortfolios(all []*model.Portfolio, ref string) (exact, partial []*model.Portfolio) {
+	for _, p := range all {
+		if strings.EqualFold(p.Name, ref) {
+			exact = append(exact, p)
+		} else if strings.Contains(strings.ToLower(p.Name), strings.ToLower(ref)) {
+			partial = append(partial, p)
+		}
+	}
+	return exact, partial
+}
+
+func describePortfolios(matched []*model.Portfolio) []string {
+	var candidates []string
+	for _, p := range matched {
+		candidates = append(candidates, fmt.Sprintf("%q [id=%s]", p.Name, p.Id))
+	}
+	return candidates
+}
+
+// WalletId resolves a wallet reference within a portfolio. Accepted forms are
+// a UUID, an alias, SYMBOL:TYPE (e.g. USDC:TRADING), the exact wallet name, or
+// words that each equal a word of the name, the symbol or the type (e.g.
+// "Trading USDC") when only one wallet has them all. Wallets whose name only
+// partially matches are reported as candidates rather than selected.
+func (r *Resolver) WalletId(ctx context.Context, portfolioId, ref string) (string, error) {
+	wallet, err := r.Wallet(ctx, portfolioId, ref)
+	if err != nil {
+		return "", err
+	}
+	return wallet.Id, nil
+}
+
+// Wallet resolves a wallet reference to the wallet itself. UUIDs are returned
+// without a lookup, so only the Id field is populated for them.
+func (r *Resolver) Wallet(ctx context.Context, portfolioId, ref string) (*model.Wallet, error) {
+	if IsId(ref) {
+		return &model.Wallet{Id: ref}, nil
+	}
+
+	if value, ok := lookupAlias(r.aliases.Wallets, ref); ok {
+		if IsId(value) {
+			return &model.Wallet{Id: value}, nil
+		}
+		ref = value
+	}
+
+	var exact, words, partial []*model.Wallet
+	if symbol, walletType, ok := splitSymbolType(ref); ok {
+		found, _, err := r.FindWallets(ctx, portfolioId, WalletQuery{Symbol: symbol, Type: walletType})
+		if err != nil {
+			return nil, err
+		}
+		exact = found
+	} else {
+		var err error
+		exact, words, partial, err = r.searchWallets(ctx, portfolioId, ref)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case len(exact) == 1:
+		return exact[0], nil
+	case len(exact) > 1:
+		return nil, &AmbiguousError{Kind: "wallet", Ref: ref, Candidates: DescribeWallets(exact)}
+	case len(words) == 1:
+		return words[0], nil
+	case len(words) > 1:
+		return nil, &AmbiguousError{Kind: "wallet", Ref: ref, Candidates: DescribeWallets(words)}
+	case len(partial) > 0:
+		return nil, &AmbiguousError{Kind: "wallet", Ref: ref, Candidates: DescribeWallets(partial)}
+	default:
+		return nil, fmt.Errorf("no wallet in portfolio %s matches %q", portfolioId, ref)
+	}
+}
+
+// splitSymbolType splits a SYMBOL:TYPE reference. Refs whose suffix is not a
+// known wallet type, such as names containing a colon, are not split.
+func splitSymbolType(ref string) (string, string, bool) {
+	symbol, walletType, ok := strings.Cut(ref, ":")
+	if !ok || symbol == "" {
+		return "", "", false
+	}
+	walletType = strings.ToUpper(strings.TrimSpace(walletType))
+	for _, known := range searchWalletTypes {
+		if walletType == known {
+			return strings.ToUpper(strings.TrimSpace(symbol)), walletType, true
+		}
+	}
+	return "", "", false
+}
+
+// searchWallets returns the wallets named ref, those matching every word of
+// ref (see matchesWords) and, separately, those that only partially match it.
+func (r *Resolver) searchWallets(ctx context.Context, portfolioId, ref string) (exact, words, partial []*model.Wallet, err error) {
+	var all []*model.Wallet
+	for _, walletType := range searchWalletTypes {
+		found, err := r.listWallets(ctx, portfolioId, walletType, "")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		all = append(all, found...)
+	}
+
+	tokens := strings.Fields(strings.ToLower(ref))
+	for _, w := range all {
+		switch {
+		case strings.EqualFold(w.Name, ref):
+			exact = append(exact, w)
+		case matchesWords(w, tokens):
+			words = append(words, w)
+		case matchesTokens(w, tokens):
+			partial = append(partial, w)
+		}
+	}
+	return exact, words, partial, nil
+}
+
+// matchesWords reports whether every token equals a whole word of the
+// wallet's name, its symbol or its type.
+func matchesWords(w *model.Wallet, tokens []string) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	nameWords := strings.Fields(strings.ToLower(w.Name))
+	for _, token := range tokens {
+		if !slices.Contains(nameWords, token) &&
+			!strings.EqualFold(w.Symbol, token) &&
+			!strings.EqualFold(w.Type, token) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesTokens reports whether every token is contained in the wallet's
+// name or equals its symbol or type.
+func matchesTokens(w *model.Wallet, tokens []string) bool {
+	if len(tokens) == 0 {
+		return false
+	}
+	name := strings.ToLower(w.Name)
+	for _, token := range tokens {
+		if !strings.Contains(name, token) &&
+			!strings.EqualFold(w.Symbol, token) &&
+			!strings.EqualFold(w.Type, token) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindWallets lists the wallets matching q and returns the wallet type that
+// was searched, for use in error messages.
+func (r *Resolver) FindWallets(ctx context.Context, portfolioId string, q WalletQuery) ([]*model.Wallet, string, error) {
+	if q.Type != "" {
+		matched, err := r.filterWallets(ctx, portfolioId, q.Type, q)
+		if err != nil {
+			return nil, q.Type, fmt.Errorf("failed to list %s wallets: %w", q.Type, err)
+		}
+		return matched, q.Type, nil
+	}
+
+	matched, err := r.filterWallets(ctx, portfolioId, model.WalletTypeTrading, q)
+	if err != nil {
+		return nil, model.WalletTypeTrading, fmt.Errorf("failed to list TRADING wallets: %w", err)
+	}
+	if len(matched) > 0 {
+		return matched, model.WalletTypeTrading, nil
+	}
+
+	matched, err = r.filterWallets(ctx, portfolioId, WalletTypeQc, q)
+	if err != nil {
+		return nil, "TRADING or QC", fmt.Errorf("failed to list QC wallets: %w", err)
+	}
+	return matched, "TRADING or QC", nil
+}
+
+func (r *Resolver) filterWallets(ctx context.Context, portfolioId, walletType string, q WalletQuery) ([]*model.Wallet, error) {
+	all, err := r.listWallets(ctx, portfolioId, walletType, q.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*model.Wallet
+	for _, w := range all {
+		if q.Symbol != "" && w.Symbol != "" && !strings.EqualFold(w.Symbol, q.Symbol) {
+			continue
+		}
+		if q.NetworkId != "" && w.Network != nil && w.Network.Id != q.NetworkId {
+			continue
+		}
+		if q.NameContains != "" && !strings.Contains(strings.ToLower(w.Name), strings.ToLower(q.NameContains)) {
+			continue
+		}
+		matched = append(matched, w)
+	}
+	return matched, nil
+}
+
+func (r *Resolver) listWallets(ctx context.Context, portfolioId, walletType, symbol string) ([]*model.Wallet, error) {
+	request := &wallets.ListWalletsRequest{
+		PortfolioId: portfolioId,
+		Type:        walletType,
+	}
+	if symbol != "" {
+		request.Symbols = []string{symbol}
+	}
+
+	response, err := wallets.NewWalletsService(r.client).ListWallets(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Iterator().FetchAll(ctx)
+}
+
+// DescribeWallets renders wallets for ambiguity and selection messages.
+func DescribeWallets(matched []*model.Wallet) []string {
+	var descriptions []string
+	for _, w := range matched {
+		details := "id=" + w.Id
+		if w.Type != "" {
+			details += " type=" + w.Type
+		}
+		if w.Symbol != "" {
+			details += " symbol=" + w.Symbol
+		}
+		if w.Network != nil && w.Network.Id != "" {
+			details += " network=" + w.Network.Id
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%q [%s]", w.Name, details))
+	}
+	return descriptions
+}
@@ -0,0 +1,102 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package runlock keeps two processes from working the same job or state
+// file at once. A lock is an operating system lock on a file (flock on Unix,
+// LockFileEx on Windows), so taking it is atomic and a crashed holder's lock
+// is released by the kernel rather than by a staleness check. The lock file
+// is left in place on release; removing it would let a waiting process lock
+// an unlinked file while a third creates a new one.
+package runlock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var ErrLocked = errors.New("already running")
+
+// errWouldBlock is returned by tryLock when another handle holds the lock.
+var errWouldBlock = errors.New("lock is held")
+
+// holder is written into the lock file so a refused caller can say who holds
+// the lock.
+type holder struct {
+	Pid        int       `json:"pid"`
+	Host       string    `json:"host"`
+	AcquiredAt time.Time `json:"acquired_at"`
+}
+
+// Held reports whether a live process holds the lock at path. It probes with
+// a shared lock, which conflicts only with a holder's exclusive lock.
+func Held(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	if err := tryLock(f, false); err != nil {
+		return errors.Is(err, errWouldBlock)
+	}
+	unlock(f)
+	return false
+}
+
+// Acquire takes the lock at path, creating the file if needed, and holds it
+// until the returned release is called or the process exits. When a live
+// process already holds it, the error wraps ErrLocked and names the holder.
+func Acquire(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open lock: %w", err)
+	}
+
+	if err := tryLock(f, true); err != nil {
+		f.Close()
+		if errors.Is(err, errWouldBlock) {
+			return nil, lockedError(path)
+		}
+		return nil, fmt.Errorf("cannot take lock: %w", err)
+	}
+
+	// The holder details are informational, so a failure to record them does
+	// not give up the lock.
+	host, _ := os.Hostname()
+	if data, err := json.Marshal(holder{Pid: os.Getpid(), Host: host, AcquiredAt: time.Now()}); err == nil {
+		if f.Truncate(0) == nil {
+			f.WriteAt(data, 0)
+		}
+	}
+
+	return func() {
+		unlock(f)
+		f.Close()
+	}, nil
+}
+
+// lockedError describes the current holder. A lock whose details cannot be
+// read, e.g. because the holder is still writing them, reports only its path.
+func lockedError(path string) error {
+	var h holder
+	if raw, err := os.ReadFile(path); err == nil && json.Unmarshal(raw, &h) == nil && h.Pid != 0 {
+		return fmt.Errorf("%w: %s is held by pid %d on %s since %s", ErrLocked, path, h.Pid, h.Host, h.AcquiredAt.Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w: %s is locked", ErrLocked, path)
+}
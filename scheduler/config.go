@@ -0,0 +1,214 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CatchUpSkip = "skip"
+	CatchUpOnce = "once"
+	CatchUpAll  = "all"
+
+	defaultTimeout = time.Hour
+
+	// maxCatchUpRuns bounds the "all" policy after a long outage.
+	maxCatchUpRuns = 100
+)
+
+var CatchUpPolicies = []string{CatchUpSkip, CatchUpOnce, CatchUpAll}
+
+type Config struct {
+	// Timezone the schedules are evaluated in. Defaults to the --timezone
+	// flag, then primeCliTimezone, then UTC.
+	Timezone string `yaml:"timezone"`
+
+	// StateDir holds run history, last run times and job locks. Defaults to
+	// a scheduler directory next to the primectl config file.
+	StateDir string `yaml:"state_dir"`
+
+	Jobs []*Job `yaml:"jobs"`
+}
+
+type Job struct {
+	Name     string `yaml:"name"`
+	Schedule string `yaml:"schedule"`
+
+	// Command is a primectl command line without the program name, e.g.
+	// "snapshot create --out snapshots/". Quotes group words and $VAR
+	// references are expanded from the environment.
+	Command string `yaml:"command"`
+
+	Env map[string]string `yaml:"env"`
+
+	// Jitter delays each run by a random duration up to this value.
+	Jitter time.Duration `yaml:"jitter"`
+
+	// Timeout stops a run that takes longer. Defaults to 1h.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// CatchUp decides what happens to runs missed while the scheduler was
+	// stopped: skip them (default), run once, or run each one.
+	CatchUp string `yaml:"catch_up"`
+
+	schedule *Schedule
+	args     []string
+}
+
+// LoadConfig reads and validates a jobs file. Unknown keys are rejected.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read jobs config: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	cfg := &Config{}
+	if err := decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse jobs config %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid jobs config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c *Config) validate() error {
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("at least one job is required")
+	}
+
+	names := map[string]bool{}
+	for i, job := range c.Jobs {
+		if job.Name == "" {
+			return fmt.Errorf("job %d has no name", i+1)
+		}
+		if strings.ContainsAny(job.Name, "/\\") {
+			return fmt.Errorf("job name %q cannot contain path separators", job.Name)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("duplicate job name %q", job.Name)
+		}
+		names[job.Name] = true
+
+		if err := job.validate(); err != nil {
+			return fmt.Errorf("job %q: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (j *Job) validate() error {
+	schedule, err := ParseSchedule(j.Schedule)
+	if err != nil {
+		return err
+	}
+	j.schedule = schedule
+
+	args, err := SplitCommand(j.Command)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("command is required")
+	}
+	for i, arg := range args {
+		args[i] = os.ExpandEnv(arg)
+	}
+	if args[0] == "primectl" || strings.HasSuffix(args[0], "/primectl") {
+		args = args[1:]
+	}
+	if len(args) > 0 && args[0] == "scheduler" {
+		return fmt.Errorf("command cannot start another scheduler")
+	}
+	j.args = args
+
+	if j.Jitter < 0 || j.Timeout < 0 {
+		return fmt.Errorf("durations cannot be negative")
+	}
+	if j.Timeout == 0 {
+		j.Timeout = defaultTimeout
+	}
+
+	switch j.CatchUp {
+	case "":
+		j.CatchUp = CatchUpSkip
+	case CatchUpSkip, CatchUpOnce, CatchUpAll:
+	default:
+		return fmt.Errorf("unknown catch_up %q (supported: %s)", j.CatchUp, strings.Join(CatchUpPolicies, ", "))
+	}
+	return nil
+}
+
+// SplitCommand splits a command line into words. Single and double quotes
+// group words and a backslash escapes the next character outside single
+// quotes. No other shell syntax is interpreted.
+func SplitCommand(line string) ([]string, error) {
+	var args []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' && i+1 < len(runes) {
+				i++
+				word.WriteRune(runes[i])
+			} else {
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command %q", line)
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,251 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const outputLimit = 8 * 1024
+
+type Scheduler struct {
+	cfg        *Config
+	store      *Store
+	loc        *time.Location
+	executable string
+
+	// Log receives progress messages. It may be nil.
+	Log func(format string, args ...any)
+}
+
+func New(cfg *Config, store *Store, loc *time.Location, executable string) *Scheduler {
+	return &Scheduler{cfg: cfg, store: store, loc: loc, executable: executable}
+}
+
+// NextRuns returns each job's next schedule time after now.
+func (s *Scheduler) NextRuns(now time.Time) map[string]time.Time {
+	next := map[string]time.Time{}
+	for _, job := range s.cfg.Jobs {
+		next[job.Name] = job.schedule.Next(now.In(s.loc))
+	}
+	return next
+}
+
+// Run starts one loop per job and blocks until ctx is cancelled and every
+// running job has finished.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.cfg.Jobs {
+		wg.Add(1)
+		go func(job *Job) {
+			defer wg.Done()
+			s.runJob(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) logf(format string, args ...any) {
+	if s.Log != nil {
+		s.Log(format, args...)
+	}
+}
+
+// runJob handles missed runs and then runs the job on its schedule. Ticks
+// that pass while the job is running are not queued.
+func (s *Scheduler) runJob(ctx context.Context, job *Job) {
+	s.catchUp(ctx, job)
+
+	for {
+		next := job.schedule.Next(time.Now().In(s.loc))
+		if next.IsZero() {
+			s.logf("%s: schedule %q never matches, stopping", job.Name, job.Schedule)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.execute(ctx, job, next, false)
+	}
+}
+
+// catchUp applies the job's policy to schedule times missed since its last
+// recorded run. A job seen for the first time starts from now.
+func (s *Scheduler) catchUp(ctx context.Context, job *Job) {
+	now := time.Now().In(s.loc)
+	last := s.store.LastScheduled(job.Name)
+	if last.IsZero() {
+		if err := s.store.SetLastScheduled(job.Name, now); err != nil {
+			s.logf("%s: %v", job.Name, err)
+		}
+		return
+	}
+
+	var missed []time.Time
+	for t := job.schedule.Next(last.In(s.loc)); !t.IsZero() && !t.After(now); t = job.schedule.Next(t) {
+		missed = append(missed, t)
+		if len(missed) > maxCatchUpRuns {
+			missed = missed[1:]
+		}
+	}
+	if len(missed) == 0 {
+		return
+	}
+
+	switch job.CatchUp {
+	case CatchUpSkip:
+		s.logf("%s: skipping %d missed run(s)", job.Name, len(missed))
+		if err := s.store.SetLastScheduled(job.Name, missed[len(missed)-1]); err != nil {
+			s.logf("%s: %v", job.Name, err)
+		}
+	case CatchUpOnce:
+		s.logf("%s: catching up once for %d missed run(s)", job.Name, len(missed))
+		s.execute(ctx, job, missed[len(missed)-1], true)
+	case CatchUpAll:
+		s.logf("%s: catching up %d missed run(s)", job.Name, len(missed))
+		for _, t := range missed {
+			if ctx.Err() != nil {
+				return
+			}
+			s.execute(ctx, job, t, true)
+		}
+	}
+}
+
+// execute runs the job once after its jitter delay and records the outcome.
+// The schedule time is recorded even when the run is skipped or fails, so a
+// restart does not catch up on it again.
+func (s *Scheduler) execute(ctx context.Context, job *Job, scheduledAt time.Time, catchUp bool) {
+	if job.Jitter > 0 {
+		delay := time.Duration(rand.Int63n(int64(job.Jitter)))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	run := &Run{Job: job.Name, ScheduledAt: scheduledAt, StartedAt: time.Now(), CatchUp: catchUp}
+
+	unlock, err := s.store.Lock(job.Name)
+	if err != nil {
+		run.Status = RunSkipped
+		run.ExitCode = -1
+		run.Error = err.Error()
+	} else {
+		s.logf("%s: starting", job.Name)
+		s.runCommand(ctx, job, run)
+		unlock()
+	}
+
+	run.FinishedAt = time.Now()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt).Round(time.Millisecond).String()
+	if run.Error != "" {
+		s.logf("%s: %s: %s", job.Name, run.Status, run.Error)
+	} else {
+		s.logf("%s: %s in %s", job.Name, run.Status, run.Duration)
+	}
+
+	if err := s.store.AppendRun(run); err != nil {
+		s.logf("%s: %v", job.Name, err)
+	}
+	if err := s.store.SetLastScheduled(job.Name, scheduledAt); err != nil {
+		s.logf("%s: %v", job.Name, err)
+	}
+}
+
+func (s *Scheduler) runCommand(ctx context.Context, job *Job, run *Run) {
+	ctx, cancel := context.WithTimeout(ctx, job.Timeout)
+	defer cancel()
+
+	output := &tailBuffer{limit: outputLimit}
+	command := exec.CommandContext(ctx, s.executable, job.args...)
+	command.Stdout = output
+	command.Stderr = output
+	// Bound the wait for output pipes held open by grandchildren after a
+	// timeout kills the command.
+	command.WaitDelay = 10 * time.Second
+	command.Env = os.Environ()
+	for name, value := range job.Env {
+		command.Env = append(command.Env, name+"="+os.ExpandEnv(value))
+	}
+
+	err := command.Run()
+	run.Output = output.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		run.Status = RunSucceeded
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		run.Status = RunFailed
+		run.ExitCode = -1
+		run.Error = fmt.Sprintf("timed out after %s", job.Timeout)
+	case errors.As(err, &exitErr):
+		run.Status = RunFailed
+		run.ExitCode = exitErr.ExitCode()
+		run.Error = err.Error()
+	default:
+		run.Status = RunFailed
+		run.ExitCode = -1
+		run.Error = err.Error()
+	}
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	mu        sync.Mutex
+	limit     int
+	data      []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+		b.truncated = true
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.truncated {
+		return "...\n" + string(b.data)
+	}
+	return string(b.data)
+}
@@ -0,0 +1,198 @@
+/**
+ * Copyright 2025-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package scheduler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/config"
+	"github.com/coinbase-samples/prime-cli/runlock"
+)
+
+const (
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+	RunSkipped   = "skipped"
+
+	stateFileName   = "state.json"
+	historyFileName = "history.jsonl"
+	locksDirName    = "locks"
+)
+
+// Run is one entry in the run history.
+type Run struct {
+	Job         string    `json:"job"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	StartedAt   time.Time `json:"started_at"`
+	FinishedAt  time.Time `json:"finished_at"`
+	Duration    string    `json:"duration"`
+	CatchUp     bool      `json:"catch_up,omitempty"`
+	Status      string    `json:"status"`
+	ExitCode    int       `json:"exit_code"`
+	Error       string    `json:"error,omitempty"`
+	Output      string    `json:"output,omitempty"`
+}
+
+type jobState struct {
+	LastScheduled time.Time `json:"last_scheduled"`
+}
+
+type state struct {
+	Jobs map[string]*jobState `json:"jobs"`
+}
+
+// Store keeps the scheduler state, run history and job locks in a directory.
+type Store struct {
+	dir   string
+	mu    sync.Mutex
+	state *state
+}
+
+// DefaultStateDir returns the scheduler directory next to the config file.
+func DefaultStateDir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "scheduler"), nil
+}
+
+func OpenStore(dir string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Join(dir, locksDirName), 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create scheduler state directory: %w", err)
+	}
+
+	s := &Store{dir: dir, state: &state{Jobs: map[string]*jobState{}}}
+
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read scheduler state: %w", err)
+	}
+	if err := json.Unmarshal(data, s.state); err != nil {
+		return nil, fmt.Errorf("cannot parse scheduler state: %w", err)
+	}
+	if s.state.Jobs == nil {
+		s.state.Jobs = map[string]*jobState{}
+	}
+	return s, nil
+}
+
+// LastScheduled returns the schedule time of the job's latest run, or the
+// zero time if it has never been scheduled.
+func (s *Store) LastScheduled(job string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if js, ok := s.state.Jobs[job]; ok {
+		return js.LastScheduled
+	}
+	return time.Time{}
+}
+
+func (s *Store) SetLastScheduled(job string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.Jobs[job] = &jobState{LastScheduled: at}
+
+	data, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode scheduler state: %w", err)
+	}
+
+	path := filepath.Join(s.dir, stateFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("cannot write scheduler state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("cannot write scheduler state: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) AppendRun(run *Run) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("cannot encode run: %w", err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(s.dir, historyFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("cannot open run history: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("cannot write run history: %w", err)
+	}
+	return nil
+}
+
+// History returns recorded runs, oldest first, optionally for one job and
+// limited to the most recent entries.
+func History(dir, job string, limit int) ([]*Run, error) {
+	f, err := os.Open(filepath.Join(dir, historyFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return []*Run{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open run history: %w", err)
+	}
+	defer f.Close()
+
+	runs := []*Run{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		run := &Run{}
+		if err := json.Unmarshal(scanner.Bytes(), run); err != nil {
+			continue
+		}
+		if job != "" && run.Job != job {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read run history: %w", err)
+	}
+
+	if limit > 0 && len(runs) > limit {
+		runs = runs[len(runs)-limit:]
+	}
+	return runs, nil
+}
+
+// Lock takes the job's lock file so that overlapping runs, including runs
+// from another scheduler sharing the state directory, are skipped. The lock
+// is released by the operating system if the holder crashes.
+func (s *Store) Lock(job string) (func(), error) {
+	return runlock.Acquire(filepath.Join(s.dir, locksDirName, job+".lock"))
+}
@@ -0,0 +1,382 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"sort"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/shopspring/decimal"
+)
+
+// Report lists what changed between two bundles, from A to B.
+type Report struct {
+	From            time.Time       `json:"from"`
+	To              time.Time       `json:"to"`
+	BalanceDeltas   []AmountDelta   `json:"balance_deltas"`
+	WalletDeltas    []AmountDelta   `json:"wallet_balance_deltas"`
+	NewWallets      []WalletSummary `json:"new_wallets"`
+	ClosedWallets   []WalletSummary `json:"closed_wallets"`
+	PositionDeltas  []PositionDelta `json:"position_deltas"`
+	NewOrders       []OrderSummary  `json:"new_orders"`
+	ClosedOrders    []OrderSummary  `json:"closed_orders"`
+	ChangedOrders   []OrderChange   `json:"changed_orders"`
+	SkippedWallets  []WalletError   `json:"skipped_wallets,omitempty"`
+	MissingSections []string        `json:"missing_sections,omitempty"`
+}
+
+// AmountDelta is keyed by symbol for portfolio balances and by wallet ID for
+// wallet balances. When either amount cannot be parsed, Unknown is set and
+// Delta is left empty.
+type AmountDelta struct {
+	Key     string `json:"key"`
+	Symbol  string `json:"symbol"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Delta   string `json:"delta,omitempty"`
+	Unknown bool   `json:"unknown,omitempty"`
+}
+
+// WalletError is a wallet whose balance could not be fetched in one of the
+// snapshots, so no delta is reported for it.
+type WalletError struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Snapshot string `json:"snapshot"`
+	Error    string `json:"error"`
+}
+
+type WalletSummary struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Symbol string `json:"symbol"`
+	Amount string `json:"amount,omitempty"`
+}
+
+type PositionDelta struct {
+	Symbol    string `json:"symbol"`
+	LongFrom  string `json:"long_from"`
+	LongTo    string `json:"long_to"`
+	ShortFrom string `json:"short_from"`
+	ShortTo   string `json:"short_to"`
+}
+
+type OrderSummary struct {
+	Id             string `json:"id"`
+	ProductId      string `json:"product_id"`
+	Side           string `json:"side"`
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	FilledQuantity string `json:"filled_quantity,omitempty"`
+}
+
+type OrderChange struct {
+	Id                 string `json:"id"`
+	ProductId          string `json:"product_id"`
+	StatusFrom         string `json:"status_from"`
+	StatusTo           string `json:"status_to"`
+	FilledQuantityFrom string `json:"filled_quantity_from"`
+	FilledQuantityTo   string `json:"filled_quantity_to"`
+}
+
+type balancesSection struct {
+	Balances []*model.Balance `json:"balances"`
+}
+
+// Diff compares two bundles. Sections missing from either bundle are listed
+// in MissingSections and skipped.
+func Diff(a, b *Bundle) (*Report, error) {
+	report := &Report{
+		From:           a.Manifest.CapturedAt,
+		To:             b.Manifest.CapturedAt,
+		BalanceDeltas:  []AmountDelta{},
+		WalletDeltas:   []AmountDelta{},
+		NewWallets:     []WalletSummary{},
+		ClosedWallets:  []WalletSummary{},
+		PositionDeltas: []PositionDelta{},
+		NewOrders:      []OrderSummary{},
+		ClosedOrders:   []OrderSummary{},
+		ChangedOrders:  []OrderChange{},
+	}
+
+	var balancesA, balancesB balancesSection
+	if ok, err := decodeBoth(a, b, SectionBalances, &balancesA, &balancesB, report); err != nil {
+		return nil, err
+	} else if ok {
+		report.BalanceDeltas = diffBalances(balancesA.Balances, balancesB.Balances)
+	}
+
+	var walletsA, walletsB []*WalletBalance
+	if ok, err := decodeBoth(a, b, SectionWalletBalances, &walletsA, &walletsB, report); err != nil {
+		return nil, err
+	} else if ok {
+		diffWallets(walletsA, walletsB, report)
+	}
+
+	var positionsA, positionsB []*model.EntityPosition
+	if ok, err := decodeBoth(a, b, SectionPositions, &positionsA, &positionsB, report); err != nil {
+		return nil, err
+	} else if ok {
+		report.PositionDeltas = diffPositions(positionsA, positionsB)
+	}
+
+	var ordersA, ordersB []*model.Order
+	if ok, err := decodeBoth(a, b, SectionOpenOrders, &ordersA, &ordersB, report); err != nil {
+		return nil, err
+	} else if ok {
+		diffOrders(ordersA, ordersB, report)
+	}
+
+	return report, nil
+}
+
+func decodeBoth(a, b *Bundle, section string, va, vb any, report *Report) (bool, error) {
+	okA, err := a.Decode(section, va)
+	if err != nil {
+		return false, err
+	}
+	okB, err := b.Decode(section, vb)
+	if err != nil {
+		return false, err
+	}
+	if !okA || !okB {
+		report.MissingSections = append(report.MissingSections, section)
+		return false, nil
+	}
+	return true, nil
+}
+
+func amountDelta(key, symbol, from, to string) (AmountDelta, bool) {
+	fromAmount, fromOk := parseAmount(from)
+	toAmount, toOk := parseAmount(to)
+	if !fromOk || !toOk {
+		if from == to {
+			return AmountDelta{}, false
+		}
+		return AmountDelta{Key: key, Symbol: symbol, From: from, To: to, Unknown: true}, true
+	}
+	if fromAmount.Equal(toAmount) {
+		return AmountDelta{}, false
+	}
+	return AmountDelta{
+		Key:    key,
+		Symbol: symbol,
+		From:   fromAmount.String(),
+		To:     toAmount.String(),
+		Delta:  toAmount.Sub(fromAmount).String(),
+	}, true
+}
+
+// parseAmount treats an empty value, such as a symbol absent from one
+// snapshot, as zero. Any other unparsable value is reported as not ok.
+func parseAmount(value string) (decimal.Decimal, bool) {
+	if value == "" {
+		return decimal.Zero, true
+	}
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, false
+	}
+	return amount, true
+}
+
+// sameAmount compares two amounts numerically, falling back to comparing the
+// raw values when either cannot be parsed.
+func sameAmount(a, b string) bool {
+	amountA, okA := parseAmount(a)
+	amountB, okB := parseAmount(b)
+	if !okA || !okB {
+		return a == b
+	}
+	return amountA.Equal(amountB)
+}
+
+func diffBalances(a, b []*model.Balance) []AmountDelta {
+	from := map[string]string{}
+	to := map[string]string{}
+	for _, balance := range a {
+		from[balance.Symbol] = balance.Amount
+	}
+	for _, balance := range b {
+		to[balance.Symbol] = balance.Amount
+	}
+
+	deltas := []AmountDelta{}
+	for _, symbol := range unionKeys(from, to) {
+		if delta, changed := amountDelta(symbol, symbol, from[symbol], to[symbol]); changed {
+			deltas = append(deltas, delta)
+		}
+	}
+	return deltas
+}
+
+func diffWallets(a, b []*WalletBalance, report *Report) {
+	from := map[string]*WalletBalance{}
+	to := map[string]*WalletBalance{}
+	for _, w := range a {
+		from[w.Wallet.Id] = w
+	}
+	for _, w := range b {
+		to[w.Wallet.Id] = w
+	}
+
+	for _, id := range unionKeys(from, to) {
+		before, hadBefore := from[id]
+		after, hasAfter := to[id]
+		switch {
+		case !hadBefore:
+			report.NewWallets = append(report.NewWallets, summarizeWallet(after))
+		case !hasAfter:
+			report.ClosedWallets = append(report.ClosedWallets, summarizeWallet(before))
+		case before.Error != "":
+			report.SkippedWallets = append(report.SkippedWallets, walletError(before, "from"))
+			if after.Error != "" {
+				report.SkippedWallets = append(report.SkippedWallets, walletError(after, "to"))
+			}
+		case after.Error != "":
+			report.SkippedWallets = append(report.SkippedWallets, walletError(after, "to"))
+		default:
+			if delta, changed := amountDelta(id, after.Wallet.Symbol, walletAmount(before), walletAmount(after)); changed {
+				report.WalletDeltas = append(report.WalletDeltas, delta)
+			}
+		}
+	}
+}
+
+func walletError(w *WalletBalance, snapshot string) WalletError {
+	return WalletError{
+		Id:       w.Wallet.Id,
+		Name:     w.Wallet.Name,
+		Symbol:   w.Wallet.Symbol,
+		Snapshot: snapshot,
+		Error:    w.Error,
+	}
+}
+
+func walletAmount(w *WalletBalance) string {
+	if w.Balance == nil {
+		return ""
+	}
+	return w.Balance.Amount
+}
+
+func summarizeWallet(w *WalletBalance) WalletSummary {
+	return WalletSummary{
+		Id:     w.Wallet.Id,
+		Name:   w.Wallet.Name,
+		Type:   w.Wallet.Type,
+		Symbol: w.Wallet.Symbol,
+		Amount: walletAmount(w),
+	}
+}
+
+func diffPositions(a, b []*model.EntityPosition) []PositionDelta {
+	from := map[string]*model.EntityPosition{}
+	to := map[string]*model.EntityPosition{}
+	for _, p := range a {
+		from[p.Symbol] = p
+	}
+	for _, p := range b {
+		to[p.Symbol] = p
+	}
+
+	deltas := []PositionDelta{}
+	for _, symbol := range unionKeys(from, to) {
+		before, after := from[symbol], to[symbol]
+		if before == nil {
+			before = &model.EntityPosition{}
+		}
+		if after == nil {
+			after = &model.EntityPosition{}
+		}
+		if sameAmount(before.Long, after.Long) && sameAmount(before.Short, after.Short) {
+			continue
+		}
+		deltas = append(deltas, PositionDelta{
+			Symbol:    symbol,
+			LongFrom:  before.Long,
+			LongTo:    after.Long,
+			ShortFrom: before.Short,
+			ShortTo:   after.Short,
+		})
+	}
+	return deltas
+}
+
+func diffOrders(a, b []*model.Order, report *Report) {
+	from := map[string]*model.Order{}
+	to := map[string]*model.Order{}
+	for _, o := range a {
+		from[o.Id] = o
+	}
+	for _, o := range b {
+		to[o.Id] = o
+	}
+
+	for _, id := range unionKeys(from, to) {
+		before, hadBefore := from[id]
+		after, hasAfter := to[id]
+		switch {
+		case !hadBefore:
+			report.NewOrders = append(report.NewOrders, summarizeOrder(after))
+		case !hasAfter:
+			report.ClosedOrders = append(report.ClosedOrders, summarizeOrder(before))
+		case before.Status != after.Status || !sameAmount(before.FilledQuantity, after.FilledQuantity):
+			report.ChangedOrders = append(report.ChangedOrders, OrderChange{
+				Id:                 id,
+				ProductId:          after.ProductId,
+				StatusFrom:         before.Status,
+				StatusTo:           after.Status,
+				FilledQuantityFrom: before.FilledQuantity,
+				FilledQuantityTo:   after.FilledQuantity,
+			})
+		}
+	}
+}
+
+func summarizeOrder(o *model.Order) OrderSummary {
+	return OrderSummary{
+		Id:             o.Id,
+		ProductId:      o.ProductId,
+		Side:           o.Side,
+		Type:           o.Type,
+		Status:         o.Status,
+		FilledQuantity: o.FilledQuantity,
+	}
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	seen := map[string]bool{}
+	var keys []string
+	for k := range a {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	for k := range b {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
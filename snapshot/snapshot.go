@@ -0,0 +1,391 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package snapshot captures a point-in-time bundle of portfolio state and
+// compares two bundles.
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/balances"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/financing"
+	"github.com/coinbase/prime-sdk-go/futures"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/coinbase/prime-sdk-go/positions"
+	"github.com/coinbase/prime-sdk-go/wallets"
+)
+
+const (
+	SectionBalances       = "balances"
+	SectionWalletBalances = "wallet_balances"
+	SectionPositions      = "positions"
+	SectionOpenOrders     = "open_orders"
+	SectionFuturesBalance = "futures_balance"
+	SectionCreditInfo     = "credit_info"
+	SectionMarginInfo     = "margin_info"
+
+	ManifestFile    = "manifest.json"
+	manifestVersion = 1
+
+	walletBalanceWorkers = 8
+)
+
+// Manifest describes a bundle. Each section is stored in its own file with a
+// SHA-256 checksum; sections that could not be captured record the error.
+type Manifest struct {
+	Version     int           `json:"version"`
+	CapturedAt  time.Time     `json:"captured_at"`
+	CompletedAt time.Time     `json:"completed_at"`
+	PortfolioId string        `json:"portfolio_id"`
+	EntityId    string        `json:"entity_id,omitempty"`
+	Sections    []SectionInfo `json:"sections"`
+}
+
+type SectionInfo struct {
+	Name   string `json:"name"`
+	File   string `json:"file,omitempty"`
+	Sha256 string `json:"sha256,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type WalletBalance struct {
+	Wallet  *model.Wallet  `json:"wallet"`
+	Balance *model.Balance `json:"balance,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// Bundle is a loaded snapshot: the manifest plus each section's raw JSON.
+type Bundle struct {
+	Dir      string
+	Manifest *Manifest
+	Sections map[string]json.RawMessage
+}
+
+type capturer struct {
+	client      client.RestClient
+	portfolioId string
+	entityId    string
+	newContext  func() (context.Context, context.CancelFunc)
+}
+
+// Capture gathers every section concurrently and writes the bundle to a new
+// timestamped directory under outDir. Sections that fail are recorded in the
+// manifest rather than aborting the capture, since some endpoints (futures,
+// margin) are not enabled for every entity.
+func Capture(
+	c client.RestClient,
+	portfolioId,
+	entityId,
+	outDir string,
+	newContext func() (context.Context, context.CancelFunc),
+) (*Bundle, error) {
+	cp := &capturer{client: c, portfolioId: portfolioId, entityId: entityId, newContext: newContext}
+
+	fetchers := []struct {
+		name  string
+		fetch func() (any, error)
+	}{
+		{SectionBalances, cp.balances},
+		{SectionWalletBalances, cp.walletBalances},
+		{SectionPositions, cp.positions},
+		{SectionOpenOrders, cp.openOrders},
+		{SectionFuturesBalance, cp.futuresBalance},
+		{SectionCreditInfo, cp.creditInfo},
+		{SectionMarginInfo, cp.marginInfo},
+	}
+
+	manifest := &Manifest{
+		Version:     manifestVersion,
+		CapturedAt:  time.Now().UTC(),
+		PortfolioId: portfolioId,
+		EntityId:    entityId,
+		Sections:    make([]SectionInfo, len(fetchers)),
+	}
+	results := make([]any, len(fetchers))
+
+	var wg sync.WaitGroup
+	for i, f := range fetchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			manifest.Sections[i].Name = f.name
+			result, err := f.fetch()
+			if err != nil {
+				manifest.Sections[i].Error = err.Error()
+				return
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+	manifest.CompletedAt = time.Now().UTC()
+
+	// Milliseconds keep snapshots taken within the same second apart, and
+	// Mkdir refuses to reuse a directory rather than overwrite its files.
+	if err := os.MkdirAll(outDir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create snapshot directory: %w", err)
+	}
+	dir := filepath.Join(outDir, "snapshot-"+manifest.CapturedAt.Format("20060102T150405.000Z"))
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create snapshot directory: %w", err)
+	}
+
+	bundle := &Bundle{Dir: dir, Manifest: manifest, Sections: map[string]json.RawMessage{}}
+	for i, result := range results {
+		if manifest.Sections[i].Error != "" {
+			continue
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode %s: %w", manifest.Sections[i].Name, err)
+		}
+		file := manifest.Sections[i].Name + ".json"
+		if err := os.WriteFile(filepath.Join(dir, file), data, 0o600); err != nil {
+			return nil, fmt.Errorf("cannot write %s: %w", file, err)
+		}
+		manifest.Sections[i].File = file
+		manifest.Sections[i].Sha256 = checksum(data)
+		bundle.Sections[manifest.Sections[i].Name] = data
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), data, 0o600); err != nil {
+		return nil, fmt.Errorf("cannot write manifest: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// Load reads a bundle directory (or its manifest path) and verifies every
+// section against its checksum.
+func Load(path string) (*Bundle, error) {
+	if filepath.Base(path) == ManifestFile {
+		path = filepath.Dir(path)
+	}
+
+	data, err := os.ReadFile(filepath.Join(path, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read snapshot manifest: %w", err)
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("cannot parse snapshot manifest %s: %w", path, err)
+	}
+
+	bundle := &Bundle{Dir: path, Manifest: manifest, Sections: map[string]json.RawMessage{}}
+	for _, section := range manifest.Sections {
+		if section.File == "" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(path, section.File))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read section %s: %w", section.Name, err)
+		}
+		if sum := checksum(data); sum != section.Sha256 {
+			return nil, fmt.Errorf("checksum mismatch for %s in %s: expected %s, got %s", section.File, path, section.Sha256, sum)
+		}
+		bundle.Sections[section.Name] = data
+	}
+	return bundle, nil
+}
+
+// Decode unmarshals a section into v. Missing sections leave v untouched and
+// return false.
+func (b *Bundle) Decode(section string, v any) (bool, error) {
+	data, ok := b.Sections[section]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("cannot decode %s: %w", section, err)
+	}
+	return true, nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (cp *capturer) balances() (any, error) {
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	response, err := balances.NewBalancesService(cp.client).ListPortfolioBalances(ctx, &balances.ListPortfolioBalancesRequest{
+		PortfolioId: cp.portfolioId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list portfolio balances: %w", err)
+	}
+	return response, nil
+}
+
+func (cp *capturer) walletBalances() (any, error) {
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	var all []*model.Wallet
+	for _, walletType := range []string{model.WalletTypeTrading, model.WalletTypeVault} {
+		response, err := wallets.NewWalletsService(cp.client).ListWallets(ctx, &wallets.ListWalletsRequest{
+			PortfolioId: cp.portfolioId,
+			Type:        walletType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s wallets: %w", walletType, err)
+		}
+		found, err := response.Iterator().FetchAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s wallets: %w", walletType, err)
+		}
+		all = append(all, found...)
+	}
+
+	result := make([]*WalletBalance, len(all))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range walletBalanceWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result[i] = cp.walletBalance(all[i])
+			}
+		}()
+	}
+	for i := range all {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return result, nil
+}
+
+func (cp *capturer) walletBalance(w *model.Wallet) *WalletBalance {
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	response, err := balances.NewBalancesService(cp.client).GetWalletBalance(ctx, &balances.GetWalletBalanceRequest{
+		PortfolioId: cp.portfolioId,
+		Id:          w.Id,
+	})
+	if err != nil {
+		return &WalletBalance{Wallet: w, Error: err.Error()}
+	}
+	return &WalletBalance{Wallet: w, Balance: response.Balance}
+}
+
+func (cp *capturer) positions() (any, error) {
+	if cp.entityId == "" {
+		return nil, errors.New("entity ID is required for positions")
+	}
+
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	response, err := positions.NewPositionsService(cp.client).ListEntityPositions(ctx, &positions.ListEntityPositionsRequest{
+		EntityId: cp.entityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list entity positions: %w", err)
+	}
+	return response.Iterator().FetchAll(ctx)
+}
+
+func (cp *capturer) openOrders() (any, error) {
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	var all []*model.Order
+	request := &orders.ListOpenOrdersRequest{
+		PortfolioId: cp.portfolioId,
+		Pagination:  &model.PaginationParams{},
+	}
+	for {
+		response, err := orders.NewOrdersService(cp.client).ListOpenOrders(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list open orders: %w", err)
+		}
+		all = append(all, response.Orders...)
+		if response.Pagination == nil || !response.Pagination.HasNext || response.Pagination.NextCursor == "" {
+			return all, nil
+		}
+		request.Pagination.Cursor = response.Pagination.NextCursor
+	}
+}
+
+func (cp *capturer) futuresBalance() (any, error) {
+	if cp.entityId == "" {
+		return nil, errors.New("entity ID is required for the futures balance")
+	}
+
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	response, err := futures.NewFuturesService(cp.client).GetEntityFcmBalance(ctx, &futures.GetEntityFcmBalanceRequest{
+		EntityId: cp.entityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get futures balance: %w", err)
+	}
+	return response, nil
+}
+
+func (cp *capturer) creditInfo() (any, error) {
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	response, err := financing.NewFinancingService(cp.client).GetPortfolioCreditInfo(ctx, &financing.GetPortfolioCreditInfoRequest{
+		PortfolioId: cp.portfolioId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get portfolio credit information: %w", err)
+	}
+	return response, nil
+}
+
+func (cp *capturer) marginInfo() (any, error) {
+	if cp.entityId == "" {
+		return nil, errors.New("entity ID is required for margin information")
+	}
+
+	ctx, cancel := cp.newContext()
+	defer cancel()
+
+	response, err := financing.NewFinancingService(cp.client).GetMarginInfo(ctx, &financing.GetMarginInfoRequest{
+		EntityId: cp.entityId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get margin information: %w", err)
+	}
+	return response, nil
+}
@@ -0,0 +1,278 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package stream subscribes to the Prime WebSocket feed and writes each
+// message as a line of JSON, reconnecting and resubscribing when the
+// connection drops or a sequence gap is detected.
+package stream
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/credentials"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	DefaultUrl = "wss://ws-feed.prime.coinbase.com"
+
+	ChannelL2         = "l2_data"
+	ChannelOrders     = "orders"
+	ChannelHeartbeats = "heartbeats"
+
+	readTimeout         = time.Minute
+	initialRetryBackoff = time.Second
+	maxRetryBackoff     = 30 * time.Second
+)
+
+// Subscription selects a channel and the products or portfolio to follow.
+type Subscription struct {
+	Channel     string
+	ProductIds  []string
+	PortfolioId string
+}
+
+type Options struct {
+	Url         string
+	Credentials *credentials.Credentials
+	// Out receives every feed message as one line of JSON.
+	Out io.Writer
+	// Status receives connection, gap and reconnect notices as JSON lines.
+	Status io.Writer
+	// MaxMessages stops the stream after this many feed messages when set.
+	MaxMessages int
+	// MaxReconnects gives up after this many consecutive failed connections
+	// when set.
+	MaxReconnects int
+}
+
+type subscribeMessage struct {
+	Type        string   `json:"type"`
+	Channel     string   `json:"channel"`
+	AccessKey   string   `json:"access_key"`
+	ApiKeyId    string   `json:"api_key_id"`
+	Timestamp   string   `json:"timestamp"`
+	Passphrase  string   `json:"passphrase"`
+	Signature   string   `json:"signature"`
+	PortfolioId string   `json:"portfolio_id,omitempty"`
+	ProductIds  []string `json:"product_ids,omitempty"`
+}
+
+type feedMessage struct {
+	Type        string `json:"type"`
+	Channel     string `json:"channel"`
+	SequenceNum *int64 `json:"sequence_num"`
+	Message     string `json:"message"`
+}
+
+// StatusEvent describes the state of the connection rather than feed data.
+type StatusEvent struct {
+	Type     string `json:"type"`
+	Event    string `json:"event"`
+	Url      string `json:"url,omitempty"`
+	Attempt  int    `json:"attempt,omitempty"`
+	Expected *int64 `json:"expected,omitempty"`
+	Received *int64 `json:"received,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Time     string `json:"time"`
+}
+
+// SubscriptionError is returned when the feed rejects a subscription, e.g.
+// because the signature or credentials are invalid. It is not retried.
+type SubscriptionError struct {
+	Message string
+}
+
+func (e *SubscriptionError) Error() string {
+	return "subscription rejected: " + e.Message
+}
+
+// errSequenceGap makes the read loop reconnect so that l2 books are rebuilt
+// from a fresh snapshot.
+var errSequenceGap = errors.New("sequence gap")
+
+// Sign returns the base64 HMAC-SHA256 signature Prime expects for a
+// subscription: channel, access key, service account ID, timestamp, portfolio
+// ID and the concatenated product IDs.
+func Sign(signingKey, channel, accessKey, svcAccountId, timestamp, portfolioId string, productIds []string) string {
+	message := channel + accessKey + svcAccountId + timestamp + portfolioId + strings.Join(productIds, "")
+	h := hmac.New(sha256.New, []byte(signingKey))
+	h.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+func newSubscribeMessage(creds *credentials.Credentials, sub Subscription, now time.Time) *subscribeMessage {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	return &subscribeMessage{
+		Type:        "subscribe",
+		Channel:     sub.Channel,
+		AccessKey:   creds.AccessKey,
+		ApiKeyId:    creds.SvcAccountId,
+		Timestamp:   timestamp,
+		Passphrase:  creds.Passphrase,
+		Signature:   Sign(creds.SigningKey, sub.Channel, creds.AccessKey, creds.SvcAccountId, timestamp, sub.PortfolioId, sub.ProductIds),
+		PortfolioId: sub.PortfolioId,
+		ProductIds:  sub.ProductIds,
+	}
+}
+
+// Run streams until ctx is done, MaxMessages is reached, the subscription is
+// rejected or MaxReconnects consecutive connections fail.
+func Run(ctx context.Context, opts Options, subs []Subscription) error {
+	url := opts.Url
+	if url == "" {
+		url = DefaultUrl
+	}
+
+	received := 0
+	failures := 0
+	backoff := initialRetryBackoff
+
+	for {
+		delivered, err := runConnection(ctx, opts, url, subs, &received)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err == nil {
+			return nil
+		}
+
+		var subErr *SubscriptionError
+		if errors.As(err, &subErr) {
+			return err
+		}
+
+		if delivered {
+			failures = 0
+			backoff = initialRetryBackoff
+		}
+		failures++
+		if opts.MaxReconnects > 0 && failures > opts.MaxReconnects {
+			return fmt.Errorf("giving up after %d reconnect attempts: %w", opts.MaxReconnects, err)
+		}
+
+		if !errors.Is(err, errSequenceGap) {
+			writeStatus(opts.Status, StatusEvent{Event: "disconnected", Error: err.Error()})
+		}
+		writeStatus(opts.Status, StatusEvent{Event: "reconnecting", Attempt: failures})
+
+		// A gap is not a connection failure, so resubscribe straight away.
+		if errors.Is(err, errSequenceGap) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// runConnection dials, subscribes and copies messages to opts.Out. It reports
+// whether any feed message was delivered and returns nil only when
+// MaxMessages has been reached.
+func runConnection(ctx context.Context, opts Options, url string, subs []Subscription, received *int) (bool, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// Unblock ReadMessage when the caller cancels.
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	writeStatus(opts.Status, StatusEvent{Event: "connected", Url: url})
+
+	for _, sub := range subs {
+		if err := conn.WriteJSON(newSubscribeMessage(opts.Credentials, sub, time.Now())); err != nil {
+			return false, fmt.Errorf("cannot subscribe to %s: %w", sub.Channel, err)
+		}
+	}
+
+	var expected *int64
+	delivered := false
+
+	for {
+		conn.SetReadDeadline(time.Now().Add(readTimeout))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return delivered, err
+		}
+
+		var msg feedMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return delivered, fmt.Errorf("cannot decode message: %w", err)
+		}
+
+		if msg.Type == "error" {
+			if !delivered {
+				return delivered, &SubscriptionError{Message: msg.Message}
+			}
+			return delivered, fmt.Errorf("feed error: %s", msg.Message)
+		}
+
+		if msg.SequenceNum != nil {
+			if expected != nil && *msg.SequenceNum != *expected {
+				writeStatus(opts.Status, StatusEvent{Event: "sequence_gap", Expected: expected, Received: msg.SequenceNum})
+				return delivered, errSequenceGap
+			}
+			next := *msg.SequenceNum + 1
+			expected = &next
+		}
+
+		line := &bytes.Buffer{}
+		if err := json.Compact(line, data); err != nil {
+			return delivered, fmt.Errorf("cannot compact message: %w", err)
+		}
+		line.WriteByte('\n')
+		if _, err := opts.Out.Write(line.Bytes()); err != nil {
+			return delivered, err
+		}
+
+		delivered = true
+		*received++
+		if opts.MaxMessages > 0 && *received >= opts.MaxMessages {
+			return delivered, nil
+		}
+	}
+}
+
+func writeStatus(w io.Writer, event StatusEvent) {
+	if w == nil {
+		return
+	}
+	event.Type = "stream_status"
+	event.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	w.Write(append(data, '\n'))
+}
@@ -0,0 +1,188 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/credentials"
+	"github.com/gorilla/websocket"
+)
+
+const testSigningKey = "signing-key"
+
+// fakeFeed serves one script per connection. Each script lists the sequence
+// numbers to send after the subscription; a negative number sends an error
+// message instead. The connection is closed once the script is done, and
+// connections beyond the scripts stay idle.
+type fakeFeed struct {
+	t        *testing.T
+	scripts  [][]int64
+	upgrader websocket.Upgrader
+
+	mu            sync.Mutex
+	connections   int
+	subscriptions []*subscribeMessage
+}
+
+func (f *fakeFeed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := f.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	f.mu.Lock()
+	f.connections++
+	connection := f.connections
+	f.mu.Unlock()
+
+	var sub subscribeMessage
+	if err := conn.ReadJSON(&sub); err != nil {
+		return
+	}
+	expected := Sign(testSigningKey, sub.Channel, sub.AccessKey, sub.ApiKeyId, sub.Timestamp, sub.PortfolioId, sub.ProductIds)
+	if sub.Signature != expected {
+		f.t.Errorf("connection %d: bad signature for %s", connection, sub.Channel)
+	}
+	f.mu.Lock()
+	f.subscriptions = append(f.subscriptions, &sub)
+	f.mu.Unlock()
+
+	if connection > len(f.scripts) {
+		conn.ReadMessage()
+		return
+	}
+	for _, sequence := range f.scripts[connection-1] {
+		msg := map[string]any{"channel": sub.Channel, "sequence_num": sequence}
+		if sequence < 0 {
+			msg = map[string]any{"type": "error", "message": "authentication failure"}
+		}
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+func runFeed(t *testing.T, scripts [][]int64, maxMessages int) (*fakeFeed, []StatusEvent, int, error) {
+	t.Helper()
+
+	feed := &fakeFeed{t: t, scripts: scripts}
+	srv := httptest.NewServer(feed)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var out, status bytes.Buffer
+	err := Run(ctx, Options{
+		Url:           "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Credentials:   &credentials.Credentials{AccessKey: "access-key", Passphrase: "passphrase", SigningKey: testSigningKey, SvcAccountId: "account"},
+		Out:           &out,
+		Status:        &status,
+		MaxMessages:   maxMessages,
+		MaxReconnects: 3,
+	}, []Subscription{{Channel: ChannelL2, ProductIds: []string{"BTC-USD"}}})
+	if ctx.Err() != nil {
+		t.Fatal("stream did not finish before the timeout")
+	}
+
+	var events []StatusEvent
+	for _, line := range strings.Split(strings.TrimSpace(status.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var event StatusEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("invalid status line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return feed, events, strings.Count(out.String(), "\n"), err
+}
+
+func eventNames(events []StatusEvent) []string {
+	var names []string
+	for _, e := range events {
+		names = append(names, e.Event)
+	}
+	return names
+}
+
+func TestRunResubscribesAfterSequenceGap(t *testing.T) {
+	feed, events, delivered, err := runFeed(t, [][]int64{{0, 1, 3}, {0, 1, 2}}, 5)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if delivered != 5 {
+		t.Errorf("delivered %d messages, want 5", delivered)
+	}
+	if feed.connections != 2 || len(feed.subscriptions) != 2 {
+		t.Errorf("got %d connections and %d subscriptions, want 2 of each", feed.connections, len(feed.subscriptions))
+	}
+
+	want := []string{"connected", "sequence_gap", "reconnecting", "connected"}
+	if got := eventNames(events); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("status events %v, want %v", got, want)
+	}
+	gap := events[1]
+	if gap.Expected == nil || *gap.Expected != 2 || gap.Received == nil || *gap.Received != 3 {
+		t.Errorf("sequence gap expected=%v received=%v, want 2 and 3", gap.Expected, gap.Received)
+	}
+}
+
+func TestRunReconnectsAfterDrop(t *testing.T) {
+	feed, events, delivered, err := runFeed(t, [][]int64{{0, 1}, {0, 1, 2}}, 4)
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if delivered != 4 {
+		t.Errorf("delivered %d messages, want 4", delivered)
+	}
+	if feed.connections != 2 || len(feed.subscriptions) != 2 {
+		t.Errorf("got %d connections and %d subscriptions, want 2 of each", feed.connections, len(feed.subscriptions))
+	}
+
+	want := []string{"connected", "disconnected", "reconnecting", "connected"}
+	if got := eventNames(events); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("status events %v, want %v", got, want)
+	}
+	if events[2].Attempt != 1 {
+		t.Errorf("reconnect attempt %d, want 1", events[2].Attempt)
+	}
+}
+
+func TestRunDoesNotRetryRejectedSubscription(t *testing.T) {
+	feed, _, delivered, err := runFeed(t, [][]int64{{-1}}, 0)
+
+	var subErr *SubscriptionError
+	if !errors.As(err, &subErr) {
+		t.Fatalf("Run returned %v, want a SubscriptionError", err)
+	}
+	if delivered != 0 || feed.connections != 1 {
+		t.Errorf("delivered %d messages over %d connections, want 0 over 1", delivered, feed.connections)
+	}
+}
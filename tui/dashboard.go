@@ -0,0 +1,423 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tui implements a full-screen terminal dashboard of balances, open
+// orders, recent fills and pending transactions for one portfolio.
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"golang.org/x/term"
+)
+
+type pane int
+
+const (
+	paneBalances pane = iota
+	paneOrders
+	paneFills
+	paneTransactions
+	paneCount
+)
+
+type mode int
+
+const (
+	modeNormal mode = iota
+	modeConfirmCancel
+	modeWallets
+	modePortfolios
+)
+
+type key int
+
+const (
+	keyRune key = iota
+	keyUp
+	keyDown
+	keyTab
+	keyBackTab
+	keyEnter
+	keyEscape
+	keyCtrlC
+)
+
+type keyEvent struct {
+	key  key
+	char byte
+}
+
+type snapshotEvent struct {
+	portfolioId string
+	snapshot    *snapshot
+}
+
+type walletsEvent struct {
+	symbol  string
+	wallets []walletBalance
+	err     error
+}
+
+// cancelTarget is the order chosen with 'c'. It is captured at that moment
+// so a refresh before the confirmation cannot change which order is cancelled.
+type cancelTarget struct {
+	portfolioId string
+	orderId     string
+	productId   string
+	side        string
+}
+
+type messageEvent struct {
+	text    string
+	refresh bool
+}
+
+type Dashboard struct {
+	client   client.RestClient
+	interval time.Duration
+	out      io.Writer
+
+	portfolioId   string
+	portfolioName string
+	portfolios    []*model.Portfolio
+
+	data       *snapshot
+	loading    bool
+	focus      pane
+	selected   [paneCount]int
+	mode       mode
+	overlayIdx int
+	cancel     *cancelTarget
+
+	walletSymbol string
+	wallets      []walletBalance
+	walletsErr   error
+
+	status string
+	events chan any
+}
+
+func New(c client.RestClient, portfolioId string, interval time.Duration) *Dashboard {
+	return &Dashboard{
+		client:        c,
+		interval:      interval,
+		out:           os.Stdout,
+		portfolioId:   portfolioId,
+		portfolioName: portfolioId,
+		events:        make(chan any, 16),
+	}
+}
+
+// Run takes over the terminal until the user quits.
+func (d *Dashboard) Run() error {
+	inFd := int(os.Stdin.Fd())
+	if !term.IsTerminal(inFd) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("tui requires an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return fmt.Errorf("cannot switch terminal to raw mode: %w", err)
+	}
+	defer term.Restore(inFd, state)
+
+	fmt.Fprint(d.out, enterAltScreen+hideCursor)
+	defer fmt.Fprint(d.out, showCursor+exitAltScreen)
+
+	if portfolios, err := d.loadPortfolios(); err == nil {
+		d.portfolios = portfolios
+		d.portfolioName = d.nameOf(d.portfolioId)
+	} else {
+		d.status = err.Error()
+	}
+
+	go readKeys(os.Stdin, d.events)
+	d.refresh()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.render()
+	for {
+		select {
+		case <-ticker.C:
+			d.refresh()
+		case event := <-d.events:
+			if quit := d.handle(event); quit {
+				return nil
+			}
+		}
+		d.render()
+	}
+}
+
+func (d *Dashboard) refresh() {
+	if d.loading {
+		return
+	}
+	d.loading = true
+	portfolioId := d.portfolioId
+	go func() {
+		d.events <- snapshotEvent{portfolioId: portfolioId, snapshot: d.loadSnapshot(portfolioId)}
+	}()
+}
+
+func (d *Dashboard) nameOf(portfolioId string) string {
+	for _, p := range d.portfolios {
+		if p.Id == portfolioId {
+			return p.Name
+		}
+	}
+	return portfolioId
+}
+
+func (d *Dashboard) handle(event any) bool {
+	switch e := event.(type) {
+	case snapshotEvent:
+		d.loading = false
+		if e.portfolioId != d.portfolioId {
+			// A refresh for the previous portfolio finished after a switch.
+			d.refresh()
+			return false
+		}
+		d.data = e.snapshot
+		d.clampSelections()
+	case walletsEvent:
+		if d.mode == modeWallets && e.symbol == d.walletSymbol {
+			d.wallets, d.walletsErr = e.wallets, e.err
+		}
+	case messageEvent:
+		d.status = e.text
+		if e.refresh {
+			d.refresh()
+		}
+	case keyEvent:
+		return d.handleKey(e)
+	}
+	return false
+}
+
+func (d *Dashboard) handleKey(e keyEvent) bool {
+	if e.key == keyCtrlC {
+		return true
+	}
+
+	switch d.mode {
+	case modeConfirmCancel:
+		if target := d.cancel; target != nil && e.key == keyRune && (e.char == 'y' || e.char == 'Y') {
+			d.status = "cancelling order " + target.orderId + "..."
+			go func() {
+				if err := d.cancelOrder(target.portfolioId, target.orderId); err != nil {
+					d.events <- messageEvent{text: err.Error()}
+					return
+				}
+				d.events <- messageEvent{text: "cancel requested for order " + target.orderId, refresh: true}
+			}()
+		} else {
+			d.status = "cancel aborted"
+		}
+		d.mode = modeNormal
+		d.cancel = nil
+		return false
+	case modeWallets:
+		switch {
+		case e.key == keyEscape || (e.key == keyRune && e.char == 'q'):
+			d.mode = modeNormal
+		case e.key == keyUp || (e.key == keyRune && e.char == 'k'):
+			d.overlayIdx = max(d.overlayIdx-1, 0)
+		case e.key == keyDown || (e.key == keyRune && e.char == 'j'):
+			d.overlayIdx = min(d.overlayIdx+1, max(len(d.wallets)-1, 0))
+		}
+		return false
+	case modePortfolios:
+		switch {
+		case e.key == keyEscape || (e.key == keyRune && e.char == 'q'):
+			d.mode = modeNormal
+		case e.key == keyUp || (e.key == keyRune && e.char == 'k'):
+			d.overlayIdx = max(d.overlayIdx-1, 0)
+		case e.key == keyDown || (e.key == keyRune && e.char == 'j'):
+			d.overlayIdx = min(d.overlayIdx+1, max(len(d.portfolios)-1, 0))
+		case e.key == keyEnter && d.overlayIdx < len(d.portfolios):
+			p := d.portfolios[d.overlayIdx]
+			d.mode = modeNormal
+			if p.Id != d.portfolioId {
+				d.portfolioId, d.portfolioName = p.Id, p.Name
+				d.data = nil
+				d.selected = [paneCount]int{}
+				d.status = "switched to " + p.Name
+				d.refresh()
+			}
+		}
+		return false
+	}
+
+	switch e.key {
+	case keyTab:
+		d.focus = (d.focus + 1) % paneCount
+	case keyBackTab:
+		d.focus = (d.focus + paneCount - 1) % paneCount
+	case keyUp:
+		d.moveSelection(-1)
+	case keyDown:
+		d.moveSelection(1)
+	case keyEnter:
+		d.openWallets()
+	case keyRune:
+		switch e.char {
+		case 'q':
+			return true
+		case 'k':
+			d.moveSelection(-1)
+		case 'j':
+			d.moveSelection(1)
+		case 'r':
+			d.status = "refreshing..."
+			d.refresh()
+		case 'c':
+			if order := d.selectedOrder(); order != nil {
+				d.cancel = &cancelTarget{
+					portfolioId: d.portfolioId,
+					orderId:     order.Id,
+					productId:   order.ProductId,
+					side:        order.Side,
+				}
+				d.mode = modeConfirmCancel
+			} else {
+				d.status = "select an open order to cancel"
+			}
+		case 'w':
+			d.openWallets()
+		case 'p':
+			if len(d.portfolios) == 0 {
+				d.status = "no portfolios available"
+				break
+			}
+			d.mode = modePortfolios
+			d.overlayIdx = 0
+			for i, p := range d.portfolios {
+				if p.Id == d.portfolioId {
+					d.overlayIdx = i
+				}
+			}
+		case '1', '2', '3', '4':
+			d.focus = pane(e.char - '1')
+		}
+	}
+	return false
+}
+
+func (d *Dashboard) rowCount(p pane) int {
+	if d.data == nil {
+		return 0
+	}
+	switch p {
+	case paneBalances:
+		return len(d.data.balances)
+	case paneOrders:
+		return len(d.data.orders)
+	case paneFills:
+		return len(d.data.fills)
+	default:
+		return len(d.data.transactions)
+	}
+}
+
+func (d *Dashboard) moveSelection(delta int) {
+	n := d.rowCount(d.focus)
+	if n == 0 {
+		return
+	}
+	d.selected[d.focus] = min(max(d.selected[d.focus]+delta, 0), n-1)
+}
+
+func (d *Dashboard) clampSelections() {
+	for p := pane(0); p < paneCount; p++ {
+		d.selected[p] = min(d.selected[p], max(d.rowCount(p)-1, 0))
+	}
+}
+
+func (d *Dashboard) selectedOrder() *model.Order {
+	if d.focus != paneOrders || d.rowCount(paneOrders) == 0 {
+		return nil
+	}
+	return d.data.orders[d.selected[paneOrders]]
+}
+
+// openWallets drills into the wallets holding the selected balance's asset.
+func (d *Dashboard) openWallets() {
+	if d.focus != paneBalances || d.rowCount(paneBalances) == 0 {
+		d.status = "select a balance to see its wallets"
+		return
+	}
+	symbol := d.data.balances[d.selected[paneBalances]].Symbol
+
+	d.mode = modeWallets
+	d.overlayIdx = 0
+	d.walletSymbol = symbol
+	d.wallets, d.walletsErr = nil, nil
+
+	portfolioId := d.portfolioId
+	go func() {
+		wallets, err := d.loadWallets(portfolioId, symbol)
+		d.events <- walletsEvent{symbol: symbol, wallets: wallets, err: err}
+	}()
+}
+
+// readKeys translates raw terminal input into key events.
+func readKeys(r io.Reader, events chan<- any) {
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			events <- keyEvent{key: keyCtrlC}
+			return
+		}
+		input := buf[:n]
+		for len(input) > 0 {
+			switch {
+			case len(input) >= 3 && input[0] == 0x1b && input[1] == '[':
+				switch input[2] {
+				case 'A':
+					events <- keyEvent{key: keyUp}
+				case 'B':
+					events <- keyEvent{key: keyDown}
+				case 'Z':
+					events <- keyEvent{key: keyBackTab}
+				}
+				input = input[3:]
+				continue
+			case input[0] == 0x1b:
+				events <- keyEvent{key: keyEscape}
+			case input[0] == 0x03:
+				events <- keyEvent{key: keyCtrlC}
+			case input[0] == '\t':
+				events <- keyEvent{key: keyTab}
+			case input[0] == '\r' || input[0] == '\n':
+				events <- keyEvent{key: keyEnter}
+			default:
+				events <- keyEvent{key: keyRune, char: input[0]}
+			}
+			input = input[1:]
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coinbase-samples/prime-cli/utils"
+	"github.com/coinbase/prime-sdk-go/balances"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/coinbase/prime-sdk-go/portfolios"
+	"github.com/coinbase/prime-sdk-go/transactions"
+	"github.com/coinbase/prime-sdk-go/wallets"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	fillsLookback        = 24 * time.Hour
+	transactionsLookback = 7 * 24 * time.Hour
+	pageLimit            = 100
+)
+
+// snapshot holds one refresh of every pane. Errors are kept per pane so a
+// failing endpoint does not blank the others.
+type snapshot struct {
+	balances     []*model.Balance
+	orders       []*model.Order
+	fills        []*model.OrderFill
+	transactions []*model.Transaction
+	errors       map[pane]error
+	at           time.Time
+}
+
+type walletBalance struct {
+	wallet  *model.Wallet
+	balance *model.Balance
+	err     error
+}
+
+func (d *Dashboard) loadSnapshot(portfolioId string) *snapshot {
+	s := &snapshot{errors: map[pane]error{}, at: time.Now()}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	run := func(p pane, fn func(ctx context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := utils.GetContextWithTimeout()
+			defer cancel()
+			if err := fn(ctx); err != nil {
+				mu.Lock()
+				s.errors[p] = err
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run(paneBalances, func(ctx context.Context) error {
+		response, err := balances.NewBalancesService(d.client).ListPortfolioBalances(ctx, &balances.ListPortfolioBalancesRequest{
+			PortfolioId: portfolioId,
+		})
+		if err != nil {
+			return err
+		}
+		s.balances = nonZeroBalances(response.Balances)
+		return nil
+	})
+
+	run(paneOrders, func(ctx context.Context) error {
+		response, err := orders.NewOrdersService(d.client).ListOpenOrders(ctx, &orders.ListOpenOrdersRequest{
+			PortfolioId: portfolioId,
+			Pagination:  &model.PaginationParams{Limit: pageLimit},
+		})
+		if err != nil {
+			return err
+		}
+		s.orders = response.Orders
+		return nil
+	})
+
+	run(paneFills, func(ctx context.Context) error {
+		now := time.Now()
+		response, err := orders.NewOrdersService(d.client).ListPortfolioFills(ctx, &orders.ListPortfolioFillsRequest{
+			PortfolioId: portfolioId,
+			Start:       now.Add(-fillsLookback),
+			End:         now,
+			Pagination:  &model.PaginationParams{Limit: pageLimit, SortDirection: "DESC"},
+		})
+		if err != nil {
+			return err
+		}
+		s.fills = response.Fills
+		sort.Slice(s.fills, func(i, j int) bool { return s.fills[i].Time.After(s.fills[j].Time) })
+		return nil
+	})
+
+	run(paneTransactions, func(ctx context.Context) error {
+		now := time.Now()
+		response, err := transactions.NewTransactionsService(d.client).ListPortfolioTransactions(ctx, &transactions.ListPortfolioTransactionsRequest{
+			PortfolioId: portfolioId,
+			Start:       now.Add(-transactionsLookback),
+			End:         now,
+			Pagination:  &model.PaginationParams{Limit: pageLimit, SortDirection: "DESC"},
+		})
+		if err != nil {
+			return err
+		}
+		for _, t := range response.Transactions {
+			if terminal, _ := utils.ClassifyStatus(t.Status); !terminal {
+				s.transactions = append(s.transactions, t)
+			}
+		}
+		return nil
+	})
+
+	wg.Wait()
+	return s
+}
+
+func nonZeroBalances(all []*model.Balance) []*model.Balance {
+	var result []*model.Balance
+	for _, b := range all {
+		amount, err := decimal.NewFromString(b.Amount)
+		if err == nil && amount.IsZero() {
+			continue
+		}
+		result = append(result, b)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Symbol < result[j].Symbol })
+	return result
+}
+
+func (d *Dashboard) loadPortfolios() ([]*model.Portfolio, error) {
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	response, err := portfolios.NewPortfoliosService(d.client).ListPortfolios(ctx, &portfolios.ListPortfoliosRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list portfolios: %w", err)
+	}
+	return response.Portfolios, nil
+}
+
+// loadWallets lists every wallet holding symbol along with its balance.
+func (d *Dashboard) loadWallets(portfolioId, symbol string) ([]walletBalance, error) {
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	var found []*model.Wallet
+	for _, walletType := range []string{model.WalletTypeTrading, model.WalletTypeVault} {
+		response, err := wallets.NewWalletsService(d.client).ListWallets(ctx, &wallets.ListWalletsRequest{
+			PortfolioId: portfolioId,
+			Type:        walletType,
+			Symbols:     []string{symbol},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list %s wallets: %w", walletType, err)
+		}
+		found = append(found, response.Wallets...)
+	}
+
+	result := make([]walletBalance, len(found))
+	var wg sync.WaitGroup
+	for i, w := range found {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := balances.NewBalancesService(d.client).GetWalletBalance(ctx, &balances.GetWalletBalanceRequest{
+				PortfolioId: portfolioId,
+				Id:          w.Id,
+			})
+			result[i] = walletBalance{wallet: w, err: err}
+			if err == nil {
+				result[i].balance = response.Balance
+			}
+		}()
+	}
+	wg.Wait()
+	return result, nil
+}
+
+func (d *Dashboard) cancelOrder(portfolioId, orderId string) error {
+	ctx, cancel := utils.GetContextWithTimeout()
+	defer cancel()
+
+	_, err := orders.NewOrdersService(d.client).CancelOrder(ctx, &orders.CancelOrderRequest{
+		PortfolioId: portfolioId,
+		OrderId:     orderId,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot cancel order %s: %w", orderId, err)
+	}
+	return nil
+}
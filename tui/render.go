@@ -0,0 +1,305 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+const (
+	enterAltScreen = "\033[?1049h"
+	exitAltScreen  = "\033[?1049l"
+	hideCursor     = "\033[?25l"
+	showCursor     = "\033[?25h"
+	moveHome       = "\033[H"
+	clearLine      = "\033[K"
+	clearBelow     = "\033[J"
+	bold           = "\033[1m"
+	reverse        = "\033[7m"
+	red            = "\033[31m"
+	dim            = "\033[2m"
+	reset          = "\033[0m"
+
+	defaultWidth  = 120
+	defaultHeight = 40
+)
+
+var paneTitles = [paneCount]string{
+	"Balances",
+	"Open orders",
+	"Fills (24h)",
+	"Pending transactions",
+}
+
+type table struct {
+	header string
+	rows   []string
+}
+
+// columns pads each value to its width, truncating long values.
+func columns(widths []int, values ...string) string {
+	var b strings.Builder
+	for i, v := range values {
+		if i < len(widths) {
+			w := widths[i]
+			if len(v) > w {
+				v = v[:max(w-1, 0)] + "~"
+			}
+			b.WriteString(fmt.Sprintf("%-*s ", w, v))
+		} else {
+			b.WriteString(v)
+		}
+	}
+	return b.String()
+}
+
+func fit(line string, width int) string {
+	if len(line) > width {
+		return line[:width]
+	}
+	return line + strings.Repeat(" ", width-len(line))
+}
+
+func (d *Dashboard) tableFor(p pane) table {
+	if d.data == nil {
+		return table{}
+	}
+
+	switch p {
+	case paneBalances:
+		widths := []int{10, 22, 18, 22}
+		t := table{header: columns(widths, "SYMBOL", "AMOUNT", "HOLDS", "WITHDRAWABLE")}
+		for _, b := range d.data.balances {
+			t.rows = append(t.rows, columns(widths, b.Symbol, b.Amount, b.Holds, b.WithdrawableAmount))
+		}
+		return t
+	case paneOrders:
+		widths := []int{36, 12, 4, 8, 16, 14, 16, 10}
+		t := table{header: columns(widths, "ORDER ID", "PRODUCT", "SIDE", "TYPE", "SIZE", "LIMIT", "FILLED", "STATUS")}
+		for _, o := range d.data.orders {
+			size := o.BaseQuantity
+			if size == "" {
+				size = o.QuoteValue
+			}
+			t.rows = append(t.rows, columns(widths, o.Id, o.ProductId, o.Side, o.Type, size, o.LimitPrice, o.FilledQuantity, o.Status))
+		}
+		return t
+	case paneFills:
+		widths := []int{19, 12, 4, 16, 14, 12, 12}
+		t := table{header: columns(widths, "TIME", "PRODUCT", "SIDE", "QUANTITY", "PRICE", "COMMISSION", "VENUE")}
+		for _, f := range d.data.fills {
+			t.rows = append(t.rows, columns(widths, formatTime(f.Time), f.ProductId, f.Side, f.FilledQuantity, f.Price, f.Commission, f.Venue))
+		}
+		return t
+	default:
+		widths := []int{19, 18, 8, 18, 24, 36}
+		t := table{header: columns(widths, "CREATED", "TYPE", "SYMBOL", "AMOUNT", "STATUS", "TRANSACTION ID")}
+		for _, tx := range d.data.transactions {
+			t.rows = append(t.rows, columns(widths, formatTime(tx.Created), tx.Type, tx.Symbol, tx.Amount, tx.Status, tx.Id))
+		}
+		return t
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
+
+func (d *Dashboard) render() {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = defaultWidth, defaultHeight
+	}
+
+	var lines []string
+	header := fmt.Sprintf(" primectl tui | portfolio: %s", d.portfolioName)
+	if d.data != nil {
+		header += " | updated " + d.data.at.Format("15:04:05")
+	}
+	if d.loading {
+		header += " | refreshing"
+	}
+	lines = append(lines, reverse+fit(header, width)+reset)
+
+	body := height - 3
+	switch d.mode {
+	case modeWallets:
+		lines = append(lines, d.renderWallets(width, body)...)
+	case modePortfolios:
+		lines = append(lines, d.renderPortfolios(width, body)...)
+	default:
+		lines = append(lines, d.renderPanes(width, body)...)
+	}
+
+	for len(lines) < height-2 {
+		lines = append(lines, "")
+	}
+
+	status := d.status
+	if d.mode == modeConfirmCancel {
+		if target := d.cancel; target != nil {
+			status = fmt.Sprintf("Cancel %s %s order %s? (y/n)", target.side, target.productId, target.orderId)
+		}
+	}
+	lines = append(lines, bold+fit(" "+status, width)+reset)
+	lines = append(lines, dim+fit(" "+d.keyHelp(), width)+reset)
+
+	var b strings.Builder
+	b.WriteString(moveHome)
+	for i, line := range lines {
+		b.WriteString(line)
+		b.WriteString(clearLine)
+		if i < len(lines)-1 {
+			b.WriteString("\r\n")
+		}
+	}
+	b.WriteString(clearBelow)
+	fmt.Fprint(d.out, b.String())
+}
+
+func (d *Dashboard) keyHelp() string {
+	switch d.mode {
+	case modeWallets, modePortfolios:
+		if d.mode == modePortfolios {
+			return "up/down select  enter switch  esc back"
+		}
+		return "up/down scroll  esc back"
+	case modeConfirmCancel:
+		return "y confirm  any other key aborts"
+	default:
+		return "tab/1-4 pane  up/down select  c cancel order  enter/w wallets  p portfolio  r refresh  q quit"
+	}
+}
+
+// renderPanes stacks the four panes, sharing the rows between them and
+// scrolling each so its selected row stays visible.
+func (d *Dashboard) renderPanes(width, height int) []string {
+	per := max(height/int(paneCount), 3)
+	var lines []string
+
+	for p := pane(0); p < paneCount; p++ {
+		t := d.tableFor(p)
+
+		title := fmt.Sprintf(" [%d] %s (%d)", p+1, paneTitles[p], len(t.rows))
+		if p == d.focus {
+			lines = append(lines, bold+reverse+fit(title, width)+reset)
+		} else {
+			lines = append(lines, bold+fit(title, width)+reset)
+		}
+
+		rows := per - 2
+		if err := d.paneError(p); err != nil {
+			lines = append(lines, red+fit("  error: "+err.Error(), width)+reset)
+			rows--
+		}
+		lines = append(lines, dim+fit("  "+t.header, width)+reset)
+
+		lines = append(lines, d.renderRows(t.rows, d.selected[p], p == d.focus, width, rows)...)
+	}
+	return lines
+}
+
+func (d *Dashboard) paneError(p pane) error {
+	if d.data == nil {
+		return nil
+	}
+	return d.data.errors[p]
+}
+
+func (d *Dashboard) renderRows(rows []string, selected int, focused bool, width, limit int) []string {
+	if limit <= 0 {
+		return nil
+	}
+
+	start := 0
+	if selected >= limit {
+		start = selected - limit + 1
+	}
+
+	var lines []string
+	for i := start; i < len(rows) && i < start+limit; i++ {
+		line := fit("  "+rows[i], width)
+		if focused && i == selected {
+			line = reverse + line + reset
+		}
+		lines = append(lines, line)
+	}
+	if len(rows) == 0 {
+		text := "  (none)"
+		if d.data == nil {
+			text = "  loading..."
+		}
+		lines = append(lines, dim+fit(text, width)+reset)
+	}
+	for len(lines) < limit {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+func (d *Dashboard) renderWallets(width, height int) []string {
+	lines := []string{bold + reverse + fit(" "+d.walletSymbol+" wallets", width) + reset}
+
+	switch {
+	case d.walletsErr != nil:
+		return append(lines, red+fit("  error: "+d.walletsErr.Error(), width)+reset)
+	case d.wallets == nil:
+		return append(lines, dim+fit("  loading...", width)+reset)
+	}
+
+	widths := []int{28, 10, 36, 22, 18, 22}
+	lines = append(lines, dim+fit("  "+columns(widths, "NAME", "TYPE", "WALLET ID", "AMOUNT", "HOLDS", "WITHDRAWABLE"), width)+reset)
+
+	var rows []string
+	for _, w := range d.wallets {
+		if w.err != nil {
+			rows = append(rows, columns(widths, w.wallet.Name, w.wallet.Type, w.wallet.Id, "error: "+w.err.Error()))
+			continue
+		}
+		amount, holds, withdrawable := "", "", ""
+		if w.balance != nil {
+			amount, holds, withdrawable = w.balance.Amount, w.balance.Holds, w.balance.WithdrawableAmount
+		}
+		rows = append(rows, columns(widths, w.wallet.Name, w.wallet.Type, w.wallet.Id, amount, holds, withdrawable))
+	}
+	return append(lines, d.renderRows(rows, d.overlayIdx, true, width, height-2)...)
+}
+
+func (d *Dashboard) renderPortfolios(width, height int) []string {
+	lines := []string{bold + reverse + fit(" Switch portfolio", width) + reset}
+
+	widths := []int{32, 36, 24}
+	lines = append(lines, dim+fit("  "+columns(widths, "NAME", "PORTFOLIO ID", "ENTITY"), width)+reset)
+
+	var rows []string
+	for _, p := range d.portfolios {
+		name := p.Name
+		if p.Id == d.portfolioId {
+			name = "* " + name
+		}
+		rows = append(rows, columns(widths, name, p.Id, p.EntityName))
+	}
+	return append(lines, d.renderRows(rows, d.overlayIdx, true, width, height-2)...)
+}
@@ -0,0 +1,280 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/coinbase/prime-sdk-go/assets"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/model"
+	"github.com/coinbase/prime-sdk-go/products"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+var amountPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ParseAmount parses a positive decimal amount. Thousands separators, signs
+// and exponents are rejected so that typos such as "1,000" fail locally
+// instead of at the API.
+func ParseAmount(name, value string) (decimal.Decimal, error) {
+	if !amountPattern.MatchString(value) {
+		return decimal.Zero, fmt.Errorf("%s must be a plain decimal number such as 1000 or 0.25, got %q", name, value)
+	}
+
+	amount, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+
+	if !amount.IsPositive() {
+		return decimal.Zero, fmt.Errorf("%s must be greater than zero, got %q", name, value)
+	}
+
+	return amount, nil
+}
+
+// ValidateAmountFlags checks the syntax of each named flag that has a value.
+// Empty flags are skipped so optional amounts can be validated the same way.
+func ValidateAmountFlags(cmd *cobra.Command, flagNames ...string) error {
+	for _, flagName := range flagNames {
+		value, err := cmd.Flags().GetString(flagName)
+		if err != nil {
+			return fmt.Errorf("could not retrieve %s: %w", flagName, err)
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if _, err := ParseAmount(flagName, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateIncrement ensures the amount is a whole multiple of increment. An
+// empty or zero increment disables the check.
+func ValidateIncrement(name string, amount decimal.Decimal, increment string) error {
+	if increment == "" {
+		return nil
+	}
+
+	step, err := decimal.NewFromString(increment)
+	if err != nil || !step.IsPositive() {
+		return nil
+	}
+
+	if !amount.Mod(step).IsZero() {
+		return fmt.Errorf(
+			"%s %s is not a multiple of the increment %s (nearest valid values: %s or %s)",
+			name,
+			amount.String(),
+			step.String(),
+			amount.Div(step).Floor().Mul(step).String(),
+			amount.Div(step).Ceil().Mul(step).String(),
+		)
+	}
+	return nil
+}
+
+// ValidateRange ensures the amount is within the optional min and max bounds.
+func ValidateRange(name string, amount decimal.Decimal, min, max string) error {
+	if lower, err := decimal.NewFromString(min); err == nil && lower.IsPositive() && amount.LessThan(lower) {
+		return fmt.Errorf("%s %s is below the minimum of %s", name, amount.String(), lower.String())
+	}
+	if upper, err := decimal.NewFromString(max); err == nil && upper.IsPositive() && amount.GreaterThan(upper) {
+		return fmt.Errorf("%s %s is above the maximum of %s", name, amount.String(), upper.String())
+	}
+	return nil
+}
+
+// ValidatePrecision ensures the amount has no more decimal places than the
+// precision advertised for an asset.
+func ValidatePrecision(name string, amount decimal.Decimal, precision string) error {
+	if precision == "" {
+		return nil
+	}
+
+	places, err := decimal.NewFromString(precision)
+	if err != nil || places.IsNegative() {
+		return nil
+	}
+
+	if amount.Exponent() < 0 && int64(-amount.Exponent()) > places.IntPart() {
+		return fmt.Errorf(
+			"%s %s has %d decimal places but at most %d are supported",
+			name,
+			amount.String(),
+			-amount.Exponent(),
+			places.IntPart(),
+		)
+	}
+	return nil
+}
+
+// GetProduct looks up a single product by ID from the products available to
+// the portfolio.
+func GetProduct(c client.RestClient, portfolioId, productId string) (*model.Product, error) {
+	ctx, cancel := GetContextWithTimeout()
+	defer cancel()
+
+	svc := products.NewProductsService(c)
+
+	response, err := svc.ListProducts(ctx, &products.ListProductsRequest{
+		PortfolioId: portfolioId,
+		Pagination:  &model.PaginationParams{Limit: defaultPaginationLimit},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list products: %w", err)
+	}
+
+	all, err := response.Iterator().FetchAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list products: %w", err)
+	}
+
+	for _, p := range all {
+		if strings.EqualFold(p.Id, productId) {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("product %s is not available to portfolio %s", productId, portfolioId)
+}
+
+// GetAsset looks up a single asset by symbol from the assets available to the
+// entity.
+func GetAsset(c client.RestClient, entityId, symbol string) (*model.Asset, error) {
+	ctx, cancel := GetContextWithTimeout()
+	defer cancel()
+
+	svc := assets.NewAssetsService(c)
+
+	response, err := svc.ListAssets(ctx, &assets.ListAssetsRequest{EntityId: entityId})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list assets: %w", err)
+	}
+
+	for _, a := range response.Assets {
+		if strings.EqualFold(a.Symbol, symbol) {
+			return a, nil
+		}
+	}
+
+	return nil, fmt.Errorf("asset %s is not supported for entity %s", symbol, entityId)
+}
+
+// ValidateOrderAmounts checks the order size and limit price flags against the
+// product's increments and size bounds before the order is sent.
+func ValidateOrderAmounts(cmd *cobra.Command, c client.RestClient, portfolioId, productId string) error {
+	baseQuantity := GetFlagStringValue(cmd, BaseQuantityFlag)
+	quoteValue := GetFlagStringValue(cmd, QuoteValueFlag)
+	limitPrice := GetFlagStringValue(cmd, LimitPriceFlag)
+
+	if baseQuantity == "" && quoteValue == "" && limitPrice == "" {
+		return nil
+	}
+
+	product, err := GetProduct(c, portfolioId, productId)
+	if err != nil {
+		return err
+	}
+
+	return ValidateProductAmounts(product, baseQuantity, quoteValue, limitPrice)
+}
+
+// ValidateProductAmounts checks raw base, quote and limit price values against
+// a product. Empty values are skipped.
+func ValidateProductAmounts(product *model.Product, baseQuantity, quoteValue, limitPrice string) error {
+	if baseQuantity != "" {
+		amount, err := ParseAmount(BaseQuantityFlag, baseQuantity)
+		if err != nil {
+			return err
+		}
+		if err := ValidateIncrement(BaseQuantityFlag, amount, product.BaseIncrement); err != nil {
+			return fmt.Errorf("%s: %w", product.Id, err)
+		}
+		if err := ValidateRange(BaseQuantityFlag, amount, product.BaseMinSize, product.BaseMaxSize); err != nil {
+			return fmt.Errorf("%s: %w", product.Id, err)
+		}
+	}
+
+	if quoteValue != "" {
+		amount, err := ParseAmount(QuoteValueFlag, quoteValue)
+		if err != nil {
+			return err
+		}
+		if err := ValidateIncrement(QuoteValueFlag, amount, product.QuoteIncrement); err != nil {
+			return fmt.Errorf("%s: %w", product.Id, err)
+		}
+		if err := ValidateRange(QuoteValueFlag, amount, product.QuoteMinSize, product.QuoteMaxSize); err != nil {
+			return fmt.Errorf("%s: %w", product.Id, err)
+		}
+	}
+
+	if limitPrice != "" {
+		amount, err := ParseAmount(LimitPriceFlag, limitPrice)
+		if err != nil {
+			return err
+		}
+		if err := ValidateIncrement(LimitPriceFlag, amount, product.PriceIncrement); err != nil {
+			return fmt.Errorf("%s: %w", product.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateAssetAmount checks an amount flag against the decimal precision of
+// an asset. The check is skipped when no entity ID is available, since asset
+// metadata is entity scoped and some credentials omit it.
+func ValidateAssetAmount(cmd *cobra.Command, c client.RestClient, flagName, symbol string) error {
+	value := GetFlagStringValue(cmd, flagName)
+	if value == "" || symbol == "" {
+		return nil
+	}
+
+	amount, err := ParseAmount(flagName, value)
+	if err != nil {
+		return err
+	}
+
+	entityId := GetFlagStringValue(cmd, EntityIdFlag)
+	if entityId == "" {
+		if creds := c.Credentials(); creds != nil {
+			entityId = creds.EntityId
+		}
+	}
+	if entityId == "" {
+		return nil
+	}
+
+	asset, err := GetAsset(c, entityId, symbol)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidatePrecision(flagName, amount, asset.DecimalPrecision); err != nil {
+		return fmt.Errorf("%s: %w", asset.Symbol, err)
+	}
+	return nil
+}
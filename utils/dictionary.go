@@ -23,6 +23,7 @@ const (
 	ActivityIdFlag    = "activity-id"
 	TransactionIdFlag = "transaction-id"
 	WalletIdFlag      = "wallet-id"
+	WalletFlag        = "wallet"
 
 	AddressFlag       = "address"
 	SymbolFlag        = "symbol"
@@ -44,6 +45,8 @@ const (
 	SourceSymbolFlag        = "source-symbol"
 	DestinationWalletIdFlag = "destination-wallet-id"
 	DestinationSymbolFlag   = "destination-symbol"
+	SourceWalletFlag        = "source-wallet"
+	DestinationWalletFlag   = "destination-wallet"
 	AmountFlag              = "amount"
 	DestinationTypeFlag     = "destination-type"
 	DepositTypeFlag         = "deposit-type"
@@ -59,6 +62,8 @@ const (
 	PaymentMethodIdFlag   = "payment-method-id"
 	BlockchainAddressFlag = "blockchain-address"
 	AccountIdentifierFlag = "account-identifier"
+	ToFlag                = "to"
+	StrictFlag            = "strict"
 
 	JsonIndent        = "  "
 	SearchFlag        = "search"
@@ -95,6 +100,11 @@ const (
 	TifImmediateOrCancel  = "IMMEDIATE_OR_CANCEL"
 
 	FormatFlag      = "format"
+	TimezoneFlag    = "timezone"
+	WatchFlag       = "watch"
+	UntilFlag       = "until"
+	WaitFlag        = "wait"
+	TimeoutFlag     = "timeout"
 	AllFlag         = "all"
 	InteractiveFlag = "interactive"
 
@@ -0,0 +1,184 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	timezoneEnvVar = "primeCliTimezone"
+	dateLayout     = "2006-01-02"
+
+	TimeExpressionHelp = "RFC3339, date (2006-01-02), relative (-24h, -7d) or keyword (now, today, yesterday, week-start, month-start, year-start)"
+)
+
+var relativeDayWeekPattern = regexp.MustCompile(`^([+-])(\d+)([dw])$`)
+
+var localLayouts = []string{
+	dateLayout,
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+}
+
+// GetLocation returns the timezone used for date-only and keyword time
+// expressions. The --timezone flag wins over the primeCliTimezone environment
+// variable; UTC is used when neither is set.
+func GetLocation(cmd *cobra.Command) (*time.Location, error) {
+	name := ""
+	if cmd != nil {
+		name, _ = cmd.Flags().GetString(TimezoneFlag)
+	}
+	return loadLocation(name)
+}
+
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = os.Getenv(timezoneEnvVar)
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// ParseTimeExpression resolves an absolute, relative or keyword time
+// expression against now in the given location.
+func ParseTimeExpression(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	now = now.In(loc)
+
+	if t, err := time.Parse(time.RFC3339, expr); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, expr, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch strings.ToLower(expr) {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "week-start":
+		offset := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -offset), nil
+	case "month-start":
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc), nil
+	case "year-start":
+		return time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, loc), nil
+	}
+
+	if m := relativeDayWeekPattern.FindStringSubmatch(expr); m != nil {
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid relative time %q: %w", expr, err)
+		}
+		if m[3] == "w" {
+			n *= 7
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return now.AddDate(0, 0, n), nil
+	}
+
+	if strings.HasPrefix(expr, "-") || strings.HasPrefix(expr, "+") {
+		if d, err := time.ParseDuration(expr); err == nil {
+			return now.Add(d), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("cannot parse time %q: expected %s", expr, TimeExpressionHelp)
+}
+
+// GetTimeFlag parses a time expression flag. The zero time is returned when
+// the flag is empty.
+func GetTimeFlag(cmd *cobra.Command, flagName string) (time.Time, error) {
+	value, err := cmd.Flags().GetString(flagName)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("could not retrieve %s: %w", flagName, err)
+	}
+
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	loc, err := GetLocation(cmd)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t, err := ParseTimeExpression(value, time.Now(), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", flagName, err)
+	}
+	return t, nil
+}
+
+// GetTimeFlagAsRFC3339 parses a time expression flag and formats it as an
+// RFC3339 UTC timestamp for endpoints that take string dates.
+func GetTimeFlagAsRFC3339(cmd *cobra.Command, flagName string) (string, error) {
+	t, err := GetTimeFlag(cmd, flagName)
+	if err != nil || t.IsZero() {
+		return "", err
+	}
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// GetDateFlag parses a time expression flag and formats it as a calendar date
+// (YYYY-MM-DD) in the configured timezone.
+func GetDateFlag(cmd *cobra.Command, flagName string) (string, error) {
+	t, err := GetTimeFlag(cmd, flagName)
+	if err != nil || t.IsZero() {
+		return "", err
+	}
+
+	loc, err := GetLocation(cmd)
+	if err != nil {
+		return "", err
+	}
+	return t.In(loc).Format(dateLayout), nil
+}
+
+// AddTimezoneFlag registers the global --timezone flag.
+func AddTimezoneFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(TimezoneFlag, "", "IANA timezone for date-only and keyword times, e.g. America/New_York. Uses primeCliTimezone or UTC if blank")
+}
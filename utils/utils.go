@@ -28,6 +28,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/coinbase-samples/prime-cli/resolver"
 	"github.com/coinbase/prime-sdk-go/client"
 	"github.com/coinbase/prime-sdk-go/credentials"
 	"github.com/coinbase/prime-sdk-go/model"
@@ -82,12 +83,28 @@ func PrintJsonDocs[T any](cmd *cobra.Command, items []T) error {
 }
 
 func AddPortfolioIdFlag(cmd *cobra.Command) {
-	cmd.Flags().String(PortfolioIdFlag, "", "Portfolio ID. Uses environment variable if blank")
+	cmd.Flags().String(PortfolioIdFlag, "", "Portfolio ID, name or alias. Uses environment variable if blank")
 }
 
 func AddWalletIdFlag(cmd *cobra.Command) {
-	cmd.Flags().String(WalletIdFlag, "", "Wallet ID (Required)")
-	cmd.MarkFlagRequired(WalletIdFlag)
+	addWalletFlags(cmd, WalletIdFlag, WalletFlag, "Wallet")
+}
+
+func AddSourceWalletIdFlag(cmd *cobra.Command) {
+	addWalletFlags(cmd, SourceWalletIdFlag, SourceWalletFlag, "Source wallet")
+}
+
+func AddDestinationWalletIdFlag(cmd *cobra.Command) {
+	addWalletFlags(cmd, DestinationWalletIdFlag, DestinationWalletFlag, "Destination wallet")
+}
+
+// addWalletFlags registers a wallet ID flag alongside a reference flag that
+// accepts a name, alias or SYMBOL:TYPE. Exactly one of the two is required.
+func addWalletFlags(cmd *cobra.Command, idFlag, refFlag, label string) {
+	cmd.Flags().String(idFlag, "", label+" ID")
+	cmd.Flags().String(refFlag, "", label+" name, alias or SYMBOL:TYPE, e.g. \"Trading USDC\" or USDC:TRADING")
+	cmd.MarkFlagsOneRequired(idFlag, refFlag)
+	cmd.MarkFlagsMutuallyExclusive(idFlag, refFlag)
 }
 
 func AddProductIdFlag(cmd *cobra.Command) {
@@ -135,24 +152,24 @@ func AddIdempotencyKeyFlag(cmd *cobra.Command) {
 }
 
 func AddStartEndFlags(cmd *cobra.Command) {
-	cmd.Flags().String(StartFlag, "", "Start time in RFC3339 format")
-	cmd.Flags().String(EndFlag, "", "End time in RFC3339 format")
+	cmd.Flags().String(StartFlag, "", "Start time: "+TimeExpressionHelp)
+	cmd.Flags().String(EndFlag, "", "End time: "+TimeExpressionHelp)
 }
 
 func GetStartEndFlagsAsTime(cmd *cobra.Command) (time.Time, time.Time, error) {
-	var now time.Time
+	var start, end time.Time
 
-	startStr, err := cmd.Flags().GetString(StartFlag)
+	start, err := GetTimeFlag(cmd, StartFlag)
 	if err != nil {
-		return now, now, err
+		return start, end, err
 	}
 
-	endStr, err := cmd.Flags().GetString(EndFlag)
+	end, err = GetTimeFlag(cmd, EndFlag)
 	if err != nil {
-		return now, now, err
+		return start, end, err
 	}
 
-	return ParseDateRange(startStr, endStr)
+	return start, end, nil
 }
 
 func AddPaginationFlags(cmd *cobra.Command, includeSortLimit bool) {
@@ -197,17 +214,21 @@ func GetPaginationParams(cmd *cobra.Command) (*model.PaginationParams, error) {
 	}, nil
 }
 
-func ParseDateRange(startStr, endStr string) (time.Time, time.Time, error) {
+// ParseDateRange parses optional start and end time expressions, reading
+// date-only and keyword forms in loc. Empty values give zero times.
+func ParseDateRange(startStr, endStr string, loc *time.Location) (time.Time, time.Time, error) {
 	var start, end time.Time
 	var err error
+
+	now := time.Now()
 	if startStr != "" {
-		start, err = time.Parse(time.RFC3339, startStr)
+		start, err = ParseTimeExpression(startStr, now, loc)
 		if err != nil {
 			return start, end, fmt.Errorf("invalid start time: %w", err)
 		}
 	}
 	if endStr != "" {
-		end, err = time.Parse(time.RFC3339, endStr)
+		end, err = ParseTimeExpression(endStr, now, loc)
 		if err != nil {
 			return start, end, fmt.Errorf("invalid end time: %w", err)
 		}
@@ -246,6 +267,18 @@ func isBoolFlagSet(cmd *cobra.Command, name string) (bool, error) {
 	return flag, nil
 }
 
+// Confirm asks a yes/no question on stderr and reads the answer from stdin.
+// Anything but y or yes, including end of input, is a no.
+func Confirm(question string) (bool, error) {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
 func printInteractivePrompt() {
 	fmt.Print("Press space to continue, q to quit: ")
 }
@@ -374,6 +407,18 @@ func GetPortfolioId(cmd *cobra.Command, client client.RestClient) (string, error
 		return "", fmt.Errorf("error retrieving portfolio ID: %w", err)
 	}
 
+	if portfolioId != "" && !resolver.IsId(portfolioId) {
+		r, err := resolver.New(client)
+		if err != nil {
+			return "", err
+		}
+
+		ctx, cancel := GetContextWithTimeout()
+		defer cancel()
+
+		return r.PortfolioId(ctx, portfolioId)
+	}
+
 	if portfolioId == "" {
 		creds := client.Credentials()
 		if creds == nil {
@@ -388,6 +433,43 @@ func GetPortfolioId(cmd *cobra.Command, client client.RestClient) (string, error
 	return portfolioId, nil
 }
 
+func GetWalletId(cmd *cobra.Command, client client.RestClient, portfolioId string) (string, error) {
+	return resolveWalletFlags(cmd, client, portfolioId, WalletIdFlag, WalletFlag)
+}
+
+func GetSourceWalletId(cmd *cobra.Command, client client.RestClient, portfolioId string) (string, error) {
+	return resolveWalletFlags(cmd, client, portfolioId, SourceWalletIdFlag, SourceWalletFlag)
+}
+
+func GetDestinationWalletId(cmd *cobra.Command, client client.RestClient, portfolioId string) (string, error) {
+	return resolveWalletFlags(cmd, client, portfolioId, DestinationWalletIdFlag, DestinationWalletFlag)
+}
+
+func resolveWalletFlags(cmd *cobra.Command, client client.RestClient, portfolioId, idFlag, refFlag string) (string, error) {
+	ref := GetFlagStringValue(cmd, idFlag)
+	if ref == "" {
+		ref = GetFlagStringValue(cmd, refFlag)
+	}
+
+	if ref == "" || resolver.IsId(ref) {
+		return ref, nil
+	}
+
+	r, err := resolver.New(client)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := GetContextWithTimeout()
+	defer cancel()
+
+	walletId, err := r.WalletId(ctx, portfolioId, ref)
+	if err != nil {
+		return "", fmt.Errorf("cannot resolve %s: %w", refFlag, err)
+	}
+	return walletId, nil
+}
+
 func GetEntityId(cmd *cobra.Command, client client.RestClient) (string, error) {
 	entityId, err := cmd.Flags().GetString(EntityIdFlag)
 	if err != nil {
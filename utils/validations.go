@@ -47,6 +47,14 @@ func ValidateUUIDFlag(cmd *cobra.Command, flagName string) error {
 	return nil
 }
 
+// ValidateOptionalUUIDFlag validates the flag only when a value was supplied.
+func ValidateOptionalUUIDFlag(cmd *cobra.Command, flagName string) error {
+	if GetFlagStringValue(cmd, flagName) == "" {
+		return nil
+	}
+	return ValidateUUIDFlag(cmd, flagName)
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
@@ -61,7 +69,12 @@ func ValidateSide(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("could not retrieve %s: %w", SideFlag, err)
 	}
+	return ValidateSideValue(side)
+}
 
+// ValidateSideValue checks a side read from somewhere other than a flag, such
+// as a CSV row.
+func ValidateSideValue(side string) error {
 	if side != OrderSideBuy && side != OrderSideSell {
 		return errors.New("side must be either 'BUY' or 'SELL'")
 	}
@@ -78,7 +91,12 @@ func ValidateOrderTypeAndLimitPrice(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("could not retrieve %s: %w", LimitPriceFlag, err)
 	}
+	return ValidateOrderTypeValue(orderType, limitPrice)
+}
 
+// ValidateOrderTypeValue checks an order type and its limit price read from
+// somewhere other than flags.
+func ValidateOrderTypeValue(orderType, limitPrice string) error {
 	switch strings.ToUpper(orderType) {
 	case OrderTypeMarket:
 		// No further validation needed for MARKET
@@ -92,12 +110,50 @@ func ValidateOrderTypeAndLimitPrice(cmd *cobra.Command) error {
 	return nil
 }
 
+// ValidateOrderTypeName checks that an order type is one "orders create"
+// accepts, without checking the fields that type requires.
+func ValidateOrderTypeName(orderType string) error {
+	switch strings.ToUpper(orderType) {
+	case OrderTypeMarket, OrderTypeLimit, OrderTypeTwap, OrderTypeVwap:
+		return nil
+	default:
+		return errors.New("type must be one of MARKET, LIMIT, TWAP, or VWAP")
+	}
+}
+
+// listedOrderTypes are the order types Prime reports on existing orders,
+// including those the CLI cannot create.
+var listedOrderTypes = []string{
+	OrderTypeMarket,
+	OrderTypeLimit,
+	OrderTypeTwap,
+	"BLOCK",
+	OrderTypeVwap,
+	"STOP_LIMIT",
+	"RFQ",
+	"PEG",
+}
+
+// ValidateOrderTypeFilter checks an order type used to select existing
+// orders. An empty value is allowed.
+func ValidateOrderTypeFilter(orderType string) error {
+	if orderType != "" && !contains(listedOrderTypes, orderType) {
+		return fmt.Errorf("order type must be one of %s, got %q", strings.Join(listedOrderTypes, ", "), orderType)
+	}
+	return nil
+}
+
 func ValidateTimeInForce(cmd *cobra.Command) error {
 	timeInForce, err := cmd.Flags().GetString(TimeInForceFlag)
 	if err != nil {
 		return fmt.Errorf("could not retrieve %s: %w", TimeInForceFlag, err)
 	}
+	return ValidateTimeInForceValue(timeInForce)
+}
 
+// ValidateTimeInForceValue checks a time in force read from somewhere other
+// than a flag. An empty value is allowed.
+func ValidateTimeInForceValue(timeInForce string) error {
 	if timeInForce != "" {
 		validOptions := []string{
 			TifFillOrKill,
@@ -121,7 +177,12 @@ func ValidateQuantities(cmd *cobra.Command) error {
 	if err != nil {
 		return fmt.Errorf("could not retrieve %s: %w", QuoteValueFlag, err)
 	}
+	return ValidateQuantityValues(baseQuantity, quoteValue)
+}
 
+// ValidateQuantityValues checks that exactly one of a base quantity and a
+// quote value is set.
+func ValidateQuantityValues(baseQuantity, quoteValue string) error {
 	if baseQuantity != "" && quoteValue != "" {
 		return errors.New("either base-quantity or quote-value must be provided, not both")
 	}
@@ -0,0 +1,255 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coinbase/prime-sdk-go/advancedtransfers"
+	"github.com/coinbase/prime-sdk-go/client"
+	"github.com/coinbase/prime-sdk-go/orders"
+	"github.com/coinbase/prime-sdk-go/transactions"
+	"github.com/spf13/cobra"
+)
+
+const (
+	WaitKindOrder            = "order"
+	WaitKindTransaction      = "transaction"
+	WaitKindUnstake          = "unstake"
+	WaitKindAdvancedTransfer = "advanced-transfer"
+
+	waitInitialInterval = time.Second
+	waitMaxInterval     = 30 * time.Second
+	waitMaxErrors       = 5
+
+	// orderWaitTimeout bounds --wait on orders, since a resting limit order
+	// may never fill.
+	orderWaitTimeout = time.Hour
+
+	waitUsage        = "Block until the created resource reaches a terminal status; exits non-zero if it fails, is cancelled or expires"
+	waitTimeoutUsage = "Give up waiting after this long, e.g. 10m. Waits indefinitely if zero"
+)
+
+// WaitEvent is printed each time a watched resource changes status.
+type WaitEvent struct {
+	Kind     string `json:"kind"`
+	Id       string `json:"id"`
+	Status   string `json:"status"`
+	Terminal bool   `json:"terminal"`
+	Time     string `json:"time"`
+}
+
+// AddWaitFlags registers --wait and --timeout on create commands.
+func AddWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(WaitFlag, false, waitUsage)
+	AddWaitTimeoutFlag(cmd)
+}
+
+// AddOrderWaitFlags registers --wait and --timeout on order commands. Unlike
+// AddWaitFlags, --timeout defaults to an hour so that waiting on a resting
+// GTC order does not block forever; zero still waits indefinitely.
+func AddOrderWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(WaitFlag, false, waitUsage)
+	cmd.Flags().Duration(TimeoutFlag, orderWaitTimeout, waitTimeoutUsage)
+}
+
+// AddWaitTimeoutFlag registers the overall --timeout used while waiting.
+func AddWaitTimeoutFlag(cmd *cobra.Command) {
+	cmd.Flags().Duration(TimeoutFlag, 0, waitTimeoutUsage)
+}
+
+// WaitIfRequested waits for the resource when --wait is set.
+func WaitIfRequested(cmd *cobra.Command, c client.RestClient, kind, portfolioId, id string) error {
+	wait, err := cmd.Flags().GetBool(WaitFlag)
+	if err != nil {
+		return fmt.Errorf("could not retrieve %s: %w", WaitFlag, err)
+	}
+	if !wait {
+		return nil
+	}
+
+	// The resource has already been created, so a failed or timed-out wait
+	// is not a usage error.
+	cmd.SilenceUsage = true
+	if id == "" {
+		return fmt.Errorf("cannot wait: the response did not include a %s id", kind)
+	}
+	return WaitForTerminalStatus(cmd, c, kind, portfolioId, id)
+}
+
+// WaitForTerminalStatus polls the resource with exponential backoff, printing
+// each status change, until it reaches a terminal status or --timeout expires.
+// Failed, cancelled, rejected and expired resources are returned as errors.
+func WaitForTerminalStatus(cmd *cobra.Command, c client.RestClient, kind, portfolioId, id string) error {
+	timeout, err := cmd.Flags().GetDuration(TimeoutFlag)
+	if err != nil {
+		return fmt.Errorf("could not retrieve %s: %w", TimeoutFlag, err)
+	}
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	interval := waitInitialInterval
+	lastStatus := ""
+	errorCount := 0
+
+	for {
+		ctx, cancel := GetContextWithTimeout()
+		status, err := fetchStatus(ctx, c, kind, portfolioId, id)
+		cancel()
+
+		if err != nil {
+			errorCount++
+			if errorCount >= waitMaxErrors {
+				return fmt.Errorf("cannot get %s %s status: %w", kind, id, err)
+			}
+		} else {
+			errorCount = 0
+			terminal, failed := ClassifyStatus(status)
+
+			if status != lastStatus {
+				event := WaitEvent{
+					Kind:     kind,
+					Id:       id,
+					Status:   status,
+					Terminal: terminal,
+					Time:     time.Now().UTC().Format(time.RFC3339),
+				}
+				jsonResponse, err := FormatResponseAsJson(cmd, event)
+				if err != nil {
+					return err
+				}
+				fmt.Println(jsonResponse)
+
+				lastStatus = status
+				interval = waitInitialInterval
+			}
+
+			if failed {
+				return fmt.Errorf("%s %s ended with status %s", kind, id, status)
+			}
+			if terminal {
+				return nil
+			}
+		}
+
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return fmt.Errorf("timed out after %s waiting for %s %s (last status %s)", timeout, kind, id, displayStatus(lastStatus))
+			}
+			if interval > remaining {
+				interval = remaining
+			}
+		}
+
+		time.Sleep(interval)
+		interval = min(interval*2, waitMaxInterval)
+	}
+}
+
+func displayStatus(status string) string {
+	if status == "" {
+		return "unknown"
+	}
+	return status
+}
+
+func fetchStatus(ctx context.Context, c client.RestClient, kind, portfolioId, id string) (string, error) {
+	switch kind {
+	case WaitKindOrder:
+		response, err := orders.NewOrdersService(c).GetOrder(ctx, &orders.GetOrderRequest{
+			PortfolioId: portfolioId,
+			OrderId:     id,
+		})
+		if err != nil {
+			return "", err
+		}
+		if response.Order == nil {
+			return "", errors.New("order missing from response")
+		}
+		return response.Order.Status, nil
+	case WaitKindTransaction, WaitKindUnstake:
+		// Unstakes are tracked through the transaction they create.
+		response, err := transactions.NewTransactionsService(c).GetTransaction(ctx, &transactions.GetTransactionRequest{
+			PortfolioId:   portfolioId,
+			TransactionId: id,
+		})
+		if err != nil {
+			return "", err
+		}
+		if response.Transaction == nil {
+			return "", errors.New("transaction missing from response")
+		}
+		return response.Transaction.Status, nil
+	case WaitKindAdvancedTransfer:
+		return fetchAdvancedTransferState(ctx, c, portfolioId, id)
+	default:
+		return "", fmt.Errorf("unsupported kind %q", kind)
+	}
+}
+
+// fetchAdvancedTransferState pages through the advanced transfers until it
+// finds id, as there is no endpoint to get a single advanced transfer.
+func fetchAdvancedTransferState(ctx context.Context, c client.RestClient, portfolioId, id string) (string, error) {
+	response, err := advancedtransfers.NewAdvancedTransfersService(c).ListAdvancedTransfers(ctx, &advancedtransfers.ListAdvancedTransfersRequest{
+		PortfolioId: portfolioId,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	iterator := response.Iterator()
+	for {
+		for _, transfer := range iterator.Items() {
+			if transfer.Id == id {
+				return string(transfer.State), nil
+			}
+		}
+		if !iterator.HasNext() {
+			return "", fmt.Errorf("advanced transfer %s not found in portfolio %s", id, portfolioId)
+		}
+		if _, err := iterator.Next(ctx); err != nil {
+			return "", err
+		}
+	}
+}
+
+// ClassifyStatus reports whether a status is terminal and, if so, whether it
+// is a failure. It covers order statuses (FILLED, CANCELLED), transaction
+// statuses (TRANSACTION_DONE, TRANSACTION_FAILED) and advanced transfer
+// states (ADVANCED_TRANSFER_STATE_DONE, ADVANCED_TRANSFER_STATE_EXPIRED).
+func ClassifyStatus(status string) (terminal, failed bool) {
+	s := strings.ToUpper(status)
+	s = strings.TrimPrefix(s, "ADVANCED_TRANSFER_STATE_")
+	s = strings.TrimPrefix(s, "TRANSACTION_")
+
+	switch s {
+	case "FILLED", "DONE", "IMPORTED":
+		return true, false
+	case "FAILED", "CANCELLED", "CANCELED", "EXPIRED", "REJECTED":
+		return true, true
+	default:
+		return false, false
+	}
+}
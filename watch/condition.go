@@ -0,0 +1,103 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Condition compares a JSON field with a literal, e.g. status==TRANSACTION_DONE.
+// Path is dot separated and matches any field whose path ends with it, so
+// "status" matches both "status" and "transaction.status".
+type Condition struct {
+	Path  string
+	Op    string
+	Value string
+}
+
+// ParseConditions parses one or more conditions joined with &&.
+func ParseConditions(expr string) ([]Condition, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	var conditions []Condition
+	for _, part := range strings.Split(expr, "&&") {
+		part = strings.TrimSpace(part)
+
+		var c Condition
+		if path, value, ok := strings.Cut(part, "!="); ok {
+			c = Condition{Path: path, Op: "!=", Value: value}
+		} else if path, value, ok := strings.Cut(part, "=="); ok {
+			c = Condition{Path: path, Op: "==", Value: value}
+		} else {
+			return nil, fmt.Errorf("invalid condition %q: expected field==value or field!=value", part)
+		}
+
+		c.Path = strings.TrimSpace(c.Path)
+		c.Value = strings.Trim(strings.TrimSpace(c.Value), `"'`)
+		if c.Path == "" {
+			return nil, fmt.Errorf("invalid condition %q: missing field name", part)
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// Match reports whether the condition holds for the flattened document. An
+// equality holds if any matching field has the value; an inequality holds if
+// some field matches the path and none has the value. Neither holds when no
+// field matches the path, so a misspelled field never satisfies a condition.
+func (c Condition) Match(flat map[string]string) bool {
+	present, found := false, false
+	for path, value := range flat {
+		if !pathMatches(path, c.Path) {
+			continue
+		}
+		present = true
+		if strings.EqualFold(value, c.Value) {
+			found = true
+			break
+		}
+	}
+	if c.Op == "!=" {
+		return present && !found
+	}
+	return found
+}
+
+// MatchAll reports whether every condition holds.
+func MatchAll(conditions []Condition, flat map[string]string) bool {
+	for _, c := range conditions {
+		if !c.Match(flat) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchDocument reports whether every condition holds for a decoded JSON value.
+func MatchDocument(conditions []Condition, doc any) bool {
+	flat := map[string]string{}
+	flatten("", doc, flat)
+	return MatchAll(conditions, flat)
+}
+
+func pathMatches(path, suffix string) bool {
+	return path == suffix || strings.HasSuffix(path, "."+suffix) || strings.HasSuffix(path, "]"+suffix)
+}
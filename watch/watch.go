@@ -0,0 +1,286 @@
+/**
+ * Copyright 2026-present Coinbase Global, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package watch re-runs a read command on an interval, redraws its JSON
+// output and highlights fields that changed since the previous poll.
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+	highlightOn = "\033[1;33m"
+	colorOff    = "\033[0m"
+	indent      = "  "
+)
+
+type Options struct {
+	Interval time.Duration
+	Until    []Condition
+	Title    string
+	Out      io.Writer
+	// Redraw clears the screen between polls and colours changed fields. It
+	// should only be enabled when Out is a terminal.
+	Redraw bool
+}
+
+// Run polls fetch every opts.Interval until ctx is done or the Until
+// conditions match the latest output. Fetch errors are shown in the frame and
+// polling continues, so transient API failures do not end the watch.
+func Run(ctx context.Context, opts Options, fetch func() ([]byte, error)) error {
+	var previous map[string]string
+
+	for {
+		output, fetchErr := fetch()
+
+		docs, parseErr := decodeDocs(output)
+		current := flattenDocs(docs)
+
+		frame := &bytes.Buffer{}
+		if opts.Redraw {
+			frame.WriteString(clearScreen)
+		}
+		fmt.Fprintf(frame, "Every %s: %s    %s\n\n", opts.Interval, opts.Title, time.Now().Format(time.RFC3339))
+
+		switch {
+		case fetchErr != nil:
+			fmt.Fprintf(frame, "error: %s\n", fetchErr)
+		case parseErr != nil:
+			frame.Write(output)
+		default:
+			renderDocs(frame, docs, previous, opts.Redraw)
+			if !opts.Redraw && previous != nil {
+				writeChangeSummary(frame, previous, current)
+			}
+		}
+
+		if _, err := opts.Out.Write(frame.Bytes()); err != nil {
+			return err
+		}
+
+		if fetchErr == nil && parseErr == nil {
+			if len(opts.Until) > 0 && MatchAll(opts.Until, current) {
+				return nil
+			}
+			previous = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// Capture runs fn with os.Stdout redirected and returns what it printed.
+func Capture(fn func() error) ([]byte, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("cannot capture output: %w", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+
+	done := make(chan []byte)
+	go func() {
+		data, _ := io.ReadAll(r)
+		done <- data
+	}()
+
+	runErr := fn()
+
+	os.Stdout = stdout
+	w.Close()
+	output := <-done
+	r.Close()
+
+	return output, runErr
+}
+
+func decodeDocs(output []byte) ([]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	decoder.UseNumber()
+
+	var docs []any
+	for {
+		var doc any
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+func flattenDocs(docs []any) map[string]string {
+	flat := map[string]string{}
+	for i, doc := range docs {
+		prefix := ""
+		if len(docs) > 1 {
+			prefix = "[" + strconv.Itoa(i) + "]"
+		}
+		flatten(prefix, doc, flat)
+	}
+	return flat
+}
+
+func flatten(path string, value any, flat map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			flatten(joinPath(path, key), child, flat)
+		}
+	case []any:
+		for i, child := range v {
+			flatten(path+"["+strconv.Itoa(i)+"]", child, flat)
+		}
+	default:
+		flat[path] = scalarString(v)
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func scalarString(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return s
+	default:
+		return fmt.Sprint(s)
+	}
+}
+
+func renderDocs(w *bytes.Buffer, docs []any, previous map[string]string, color bool) {
+	for i, doc := range docs {
+		prefix := ""
+		if len(docs) > 1 {
+			prefix = "[" + strconv.Itoa(i) + "]"
+		}
+		render(w, prefix, doc, previous, color, 0)
+		w.WriteString("\n")
+	}
+}
+
+func render(w *bytes.Buffer, path string, value any, previous map[string]string, color bool, depth int) {
+	pad := strings.Repeat(indent, depth+1)
+
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 0 {
+			w.WriteString("{}")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		w.WriteString("{\n")
+		for i, key := range keys {
+			w.WriteString(pad)
+			w.WriteString(strconv.Quote(key))
+			w.WriteString(": ")
+			render(w, joinPath(path, key), v[key], previous, color, depth+1)
+			if i < len(keys)-1 {
+				w.WriteString(",")
+			}
+			w.WriteString("\n")
+		}
+		w.WriteString(strings.Repeat(indent, depth))
+		w.WriteString("}")
+	case []any:
+		if len(v) == 0 {
+			w.WriteString("[]")
+			return
+		}
+		w.WriteString("[\n")
+		for i, child := range v {
+			w.WriteString(pad)
+			render(w, path+"["+strconv.Itoa(i)+"]", child, previous, color, depth+1)
+			if i < len(v)-1 {
+				w.WriteString(",")
+			}
+			w.WriteString("\n")
+		}
+		w.WriteString(strings.Repeat(indent, depth))
+		w.WriteString("]")
+	default:
+		raw, _ := json.Marshal(v)
+		old, seen := previous[path]
+		changed := previous != nil && (!seen || old != scalarString(v))
+		if changed && color {
+			w.WriteString(highlightOn)
+			w.Write(raw)
+			w.WriteString(colorOff)
+		} else {
+			w.Write(raw)
+		}
+	}
+}
+
+// writeChangeSummary lists changed paths for non-terminal output, where
+// colour highlighting is not available.
+func writeChangeSummary(w *bytes.Buffer, previous, current map[string]string) {
+	var lines []string
+	for path, value := range current {
+		old, seen := previous[path]
+		switch {
+		case !seen:
+			lines = append(lines, fmt.Sprintf("+ %s: %s", path, value))
+		case old != value:
+			lines = append(lines, fmt.Sprintf("~ %s: %s -> %s", path, old, value))
+		}
+	}
+	for path, old := range previous {
+		if _, ok := current[path]; !ok {
+			lines = append(lines, fmt.Sprintf("- %s: %s", path, old))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+	sort.Strings(lines)
+	w.WriteString("changed since last poll:\n")
+	for _, line := range lines {
+		w.WriteString(line)
+		w.WriteString("\n")
+	}
+}